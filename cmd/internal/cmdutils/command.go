@@ -0,0 +1,90 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cmdutils provides the configuration, credentials and serving code shared by the NSM reference binaries
+package cmdutils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/flags"
+	"github.com/networkservicemesh/sdk/pkg/tools/signalctx"
+)
+
+const (
+	// ConfigKey - key for flag for the configuration file
+	ConfigKey = "config"
+	// InsecureKey - key for flag to serve and connect without TLS
+	InsecureKey = "insecure"
+)
+
+// RunFunc - runs the binary until ctx is done, v contains the configuration
+type RunFunc func(ctx context.Context, v *viper.Viper) error
+
+// NewCommand - returns a command running run with the configuration merged from the flags, NSM_* environment
+//              variables and the configuration file, in that order of precedence. Configuration keys are the flag
+//              names, e.g. listen-on-url can be set with --listen-on-url, NSM_LISTEN_ON_URL or listen-on-url key in
+//              the file. SPIFFE agent URL, insecure and config flags are added to the command.
+func NewCommand(use, short string, run RunFunc) *cobra.Command {
+	v := viper.New()
+	v.SetEnvPrefix(flags.EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	v.AutomaticEnv()
+
+	cmd := &cobra.Command{
+		Use:           use,
+		Short:         short,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := v.BindPFlags(cmd.Flags()); err != nil {
+				return err
+			}
+			if configFile := v.GetString(ConfigKey); configFile != "" {
+				v.SetConfigFile(configFile)
+				if err := v.ReadInConfig(); err != nil {
+					return errors.Wrapf(err, "failed to read %s", configFile)
+				}
+			}
+			return run(cmd.Context(), v)
+		},
+	}
+
+	f := cmd.Flags()
+	f.String(ConfigKey, "", "YAML, JSON or TOML configuration file, keys are the flag names")
+	f.StringP(flags.SpiffeAgentURLKey, flags.SpiffeAgentURLShortHand,
+		flags.SpiffeAgentURLSchemeDefault+"://"+flags.SpiffeAgentURLPathDefault, flags.SpiffeAgentURLUsageDefault)
+	f.Bool(InsecureKey, false, "Serve and connect without TLS, the SPIFFE agent is not used")
+	return cmd
+}
+
+// Execute - executes cmd until SIGTERM or the other exit signal is received, exits with non-zero code on error
+func Execute(cmd *cobra.Command) {
+	ctx := signalctx.WithSignals(context.Background())
+
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutils
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/tools/flags"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+// TokenLifetime - lifetime of the tokens generated by the binaries
+const TokenLifetime = 10 * time.Minute
+
+// Credentials - gRPC options and token generator, SPIFFE mTLS based or insecure
+type Credentials struct {
+	ServerOptions  []grpc.ServerOption
+	DialOptions    []grpc.DialOption
+	TokenGenerator token.GeneratorFunc
+	source         *workloadapi.X509Source
+}
+
+// NewCredentials - returns the credentials using the X509 SVID from the SPIFFE agent, insecure ones if InsecureKey is set
+func NewCredentials(ctx context.Context, v *viper.Viper) (*Credentials, error) {
+	if v.GetBool(InsecureKey) {
+		return &Credentials{
+			DialOptions:    []grpc.DialOption{grpc.WithInsecure()},
+			TokenGenerator: insecureTokenGenerator,
+		}, nil
+	}
+
+	source, err := workloadapi.NewX509Source(ctx, workloadapi.WithClientOptions(
+		workloadapi.WithAddr(v.GetString(flags.SpiffeAgentURLKey))))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get X509 SVID from the SPIFFE agent")
+	}
+	return &Credentials{
+		ServerOptions: []grpc.ServerOption{
+			grpc.Creds(credentials.NewTLS(tlsconfig.MTLSServerConfig(source, source, tlsconfig.AuthorizeAny()))),
+		},
+		DialOptions: []grpc.DialOption{
+			grpc.WithTransportCredentials(credentials.NewTLS(tlsconfig.MTLSClientConfig(source, source, tlsconfig.AuthorizeAny()))),
+		},
+		TokenGenerator: spiffejwt.TokenGeneratorFunc(source, TokenLifetime),
+		source:         source,
+	}, nil
+}
+
+// AuthzServer - returns the authorization server checking the default policies, it allows everything if insecure
+func (c *Credentials) AuthzServer() networkservice.NetworkServiceServer {
+	if c.source == nil {
+		return authorize.NewServer()
+	}
+	return authorize.NewServer(authorize.WithDefaultPolicies())
+}
+
+// Close - closes the X509 source
+func (c *Credentials) Close() error {
+	if c.source == nil {
+		return nil
+	}
+	return c.source.Close()
+}
+
+func insecureTokenGenerator(_ credentials.AuthInfo) (tok string, expireTime time.Time, err error) {
+	return "", time.Now().Add(TokenLifetime), nil
+}
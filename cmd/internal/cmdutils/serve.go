@@ -0,0 +1,106 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutils
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/endpoint"
+	"github.com/networkservicemesh/sdk/pkg/tools/flags"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// URL - returns the URL set for the key, nil if it is not set
+func URL(v *viper.Viper, key string) (*url.URL, error) {
+	s := v.GetString(key)
+	if s == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s: %s", key, s)
+	}
+	return u, nil
+}
+
+// Dial - dials the URL set for the key with the credentials, returns nil if the URL is not set
+func Dial(ctx context.Context, v *viper.Viper, key string, creds *Credentials) (*grpc.ClientConn, error) {
+	u, err := URL(v, key)
+	if err != nil || u == nil {
+		return nil, err
+	}
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(u), creds.DialOptions...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial %s", u)
+	}
+	return cc, nil
+}
+
+// ListenAndServe - listens on the listen-on-url and serves server until ctx is done or serving fails. Once the server
+//                  is listening, it calls start (if not nil), e.g. to register the served URL; if start fails, the
+//                  server is stopped. Then it calls stop (if not nil) with the new context limited by
+//                  endpoint.DrainTimeout, e.g. to drain or unregister, and stops the server
+func ListenAndServe(ctx context.Context, v *viper.Viper, server *grpc.Server, start, stop func(ctx context.Context) error) error {
+	listenOn, err := URL(v, flags.ListenOnURLKey)
+	if err != nil {
+		return err
+	}
+	if listenOn == nil {
+		return errors.Errorf("%s is not set", flags.ListenOnURLKey)
+	}
+
+	// Listener is opened synchronously, so start is called only when the server is actually reachable
+	ln, err := net.Listen(grpcutils.TargetToNetAddr(grpcutils.URLToTarget(listenOn)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %s", listenOn)
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(ln)
+	}()
+
+	var serveErr error
+	if start != nil {
+		if serveErr = start(ctx); serveErr != nil {
+			server.Stop()
+			return serveErr
+		}
+	}
+
+	select {
+	case serveErr = <-errCh:
+		serveErr = errors.Wrapf(serveErr, "failed to serve on %s", listenOn)
+	case <-ctx.Done():
+	}
+
+	if stop != nil {
+		stopCtx, cancel := context.WithTimeout(context.Background(), endpoint.DrainTimeout)
+		defer cancel()
+		if err := stop(stopCtx); err != nil {
+			log.FromContext(ctx).Errorf("Failed to stop: %+v", err)
+		}
+	}
+	server.Stop()
+	return serveErr
+}
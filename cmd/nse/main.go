@@ -0,0 +1,190 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nse is a Network Service Endpoint allocating point-to-point IP addresses and providing DNS configuration
+package main
+
+import (
+	"context"
+	"net"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/cmd/internal/cmdutils"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/endpoint"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/connectioncontext/dnscontext"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/ipam/point2pointipam"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/refresh"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/flags"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	nameDefault             = "nse"
+	networkServicesKey      = "network-services"
+	labelsKey               = "labels"
+	cidrPrefixesKey         = "cidr-prefixes"
+	cidrPrefixDefault       = "169.254.0.0/24"
+	dnsServerIPsKey         = "dns-server-ips"
+	dnsSearchDomainsKey     = "dns-search-domains"
+	registerExpiryPeriodKey = "register-expiry-period"
+)
+
+func main() {
+	cmdutils.Execute(newCommand())
+}
+
+func newCommand() *cobra.Command {
+	cmd := cmdutils.NewCommand("nse", "Network Service Endpoint allocating point-to-point IP addresses and providing DNS configuration", run)
+
+	f := cmd.Flags()
+	f.StringP(flags.NameKey, flags.NameShortHand, nameDefault, flags.NameUsageDefault)
+	f.StringP(flags.ListenOnURLKey, flags.ListenOnURLShortHand,
+		flags.ListenOnURLSchemeDefault+"://"+flags.ListenOnURLPathDefault,
+		"URL to listen for incoming networkservicemesh RPC calls, it is registered as the URL of the endpoint")
+	f.StringP(flags.ConnectToURLKey, flags.ConnectToURLShortHand,
+		flags.ConnectToURLSchemeDefault+"://"+flags.ConnectToURLPathDefault,
+		"URL of the Network Service Manager or registry to register the endpoint in")
+	f.StringSlice(networkServicesKey, nil, "Names of the provided Network Services")
+	f.StringToString(labelsKey, nil, "Labels of the endpoint for every provided Network Service, e.g. app=nse,color=red")
+	f.StringSlice(cidrPrefixesKey, []string{cidrPrefixDefault}, "CIDR prefixes to allocate the point-to-point IP addresses from")
+	f.StringSlice(dnsServerIPsKey, nil, "IP addresses of the DNS servers provided to the clients, DNS context is not set if empty")
+	f.StringSlice(dnsSearchDomainsKey, nil, "DNS search domains provided to the clients")
+	f.Duration(registerExpiryPeriodKey, 0, "Expiration period of the registration refreshed by the endpoint, default is used if not set")
+	return cmd
+}
+
+func run(ctx context.Context, v *viper.Viper) error {
+	networkServices := v.GetStringSlice(networkServicesKey)
+	if len(networkServices) == 0 {
+		return errors.Errorf("%s is not set", networkServicesKey)
+	}
+
+	creds, err := cmdutils.NewCredentials(ctx, v)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = creds.Close() }()
+
+	ep, err := newEndpoint(ctx, v, creds)
+	if err != nil {
+		return err
+	}
+	server := grpc.NewServer(creds.ServerOptions...)
+	ep.Register(server)
+
+	cc, err := cmdutils.Dial(ctx, v, flags.ConnectToURLKey, creds)
+	if err != nil {
+		return err
+	}
+	if cc == nil {
+		return errors.Errorf("%s is not set", flags.ConnectToURLKey)
+	}
+	defer func() { _ = cc.Close() }()
+
+	// Endpoint is registered only after it starts listening, so the clients never get the URL nobody listens on
+	var unregister func(ctx context.Context) error
+	return cmdutils.ListenAndServe(ctx, v, server, func(ctx context.Context) (err error) {
+		unregister, err = register(ctx, v, cc)
+		return err
+	}, func(ctx context.Context) error {
+		// Stop accepting new Requests, unregister and only then close the active connections
+		ep.Stop()
+		if err := unregister(ctx); err != nil {
+			log.FromContext(ctx).Errorf("Failed to unregister: %+v", err)
+		}
+		return ep.Drain(ctx)
+	})
+}
+
+func newEndpoint(ctx context.Context, v *viper.Viper, creds *cmdutils.Credentials) (endpoint.Endpoint, error) {
+	var prefixes []*net.IPNet
+	for _, prefix := range v.GetStringSlice(cidrPrefixesKey) {
+		_, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s: %s", cidrPrefixesKey, prefix)
+		}
+		prefixes = append(prefixes, ipNet)
+	}
+
+	var getDNSConfigs dnscontext.GetDNSConfigsFunc
+	if dnsServerIPs := v.GetStringSlice(dnsServerIPsKey); len(dnsServerIPs) > 0 {
+		dnsConfigs := []*networkservice.DNSConfig{{
+			DnsServerIps:  dnsServerIPs,
+			SearchDomains: v.GetStringSlice(dnsSearchDomainsKey),
+		}}
+		getDNSConfigs = func() []*networkservice.DNSConfig {
+			return dnsConfigs
+		}
+	}
+
+	return endpoint.NewServer(ctx,
+		v.GetString(flags.NameKey),
+		creds.AuthzServer(),
+		creds.TokenGenerator,
+		point2pointipam.NewServer(prefixes...),
+		dnscontext.NewServer(getDNSConfigs),
+	), nil
+}
+
+// register - registers the provided Network Services and the endpoint, returns the function unregistering the endpoint
+func register(ctx context.Context, v *viper.Viper, cc grpc.ClientConnInterface) (unregister func(ctx context.Context) error, err error) {
+	networkServices := v.GetStringSlice(networkServicesKey)
+
+	nsClient := registryapi.NewNetworkServiceRegistryClient(cc)
+	for _, name := range networkServices {
+		if _, err = nsClient.Register(ctx, &registryapi.NetworkService{Name: name}); err != nil {
+			return nil, errors.Wrapf(err, "failed to register Network Service %s", name)
+		}
+	}
+
+	nse := &registryapi.NetworkServiceEndpoint{
+		Name:                v.GetString(flags.NameKey),
+		NetworkServiceNames: networkServices,
+		Url:                 v.GetString(flags.ListenOnURLKey),
+	}
+	if labels := v.GetStringMapString(labelsKey); len(labels) > 0 {
+		nse.NetworkServiceLabels = make(map[string]*registryapi.NetworkServiceLabels)
+		for _, name := range networkServices {
+			nse.NetworkServiceLabels[name] = &registryapi.NetworkServiceLabels{Labels: labels}
+		}
+	}
+
+	var refreshOptions []refresh.Option
+	if expiryPeriod := v.GetDuration(registerExpiryPeriodKey); expiryPeriod > 0 {
+		refreshOptions = append(refreshOptions, refresh.WithDefaultExpiryDuration(expiryPeriod))
+	}
+	nseClient := registryapi.NewNetworkServiceEndpointRegistryClient(cc)
+	nseClient = chain.NewNetworkServiceEndpointRegistryClient(
+		refresh.NewNetworkServiceEndpointRegistryClient(nseClient, refreshOptions...),
+		nseClient,
+	)
+	resp, err := nseClient.Register(ctx, nse)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to register Network Service Endpoint %s", nse.Name)
+	}
+
+	return func(ctx context.Context) error {
+		_, err := nseClient.Unregister(ctx, resp)
+		return errors.Wrapf(err, "failed to unregister Network Service Endpoint %s", resp.Name)
+	}, nil
+}
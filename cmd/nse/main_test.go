@@ -0,0 +1,130 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+const configYAML = `
+network-services: [ns-1]
+labels:
+  app: nse
+cidr-prefixes: [10.0.0.0/30]
+dns-server-ips: [10.0.0.53]
+dns-search-domains: [example.org]
+`
+
+func tokenGenerator(_ credentials.AuthInfo) (tok string, expireTime time.Time, err error) {
+	return "", time.Now().Add(time.Hour), nil
+}
+
+func findNSEs(ctx context.Context, cc grpc.ClientConnInterface) []*registry.NetworkServiceEndpoint {
+	stream, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+	})
+	if err != nil {
+		return nil
+	}
+	return registry.ReadNetworkServiceEndpointList(stream)
+}
+
+func TestNSE(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nse")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	configFile := filepath.Join(dir, "nse.yaml")
+	require.NoError(t, ioutil.WriteFile(configFile, []byte(configYAML), 0600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	registryURL := "unix://" + filepath.Join(dir, "registry.sock")
+	registryServer := grpc.NewServer()
+	registry.RegisterNetworkServiceRegistryServer(registryServer, memory.NewNetworkServiceRegistryServer())
+	registry.RegisterNetworkServiceEndpointRegistryServer(registryServer, memory.NewNetworkServiceEndpointRegistryServer())
+	registryListenOn, err := url.Parse(registryURL)
+	require.NoError(t, err)
+	_ = grpcutils.ListenAndServe(ctx, registryListenOn, registryServer)
+	defer registryServer.Stop()
+
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	listenOn := "unix://" + filepath.Join(dir, "nse.sock")
+	cmd := newCommand()
+	cmd.SetArgs([]string{"--insecure", "--config", configFile, "-n", "nse-1", "-l", listenOn, "-c", registryURL})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cmd.ExecuteContext(runCtx)
+	}()
+
+	registryCC, err := grpc.DialContext(ctx, registryURL, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() { _ = registryCC.Close() }()
+
+	require.Eventually(t, func() bool {
+		return len(findNSEs(ctx, registryCC)) == 1
+	}, time.Second, 10*time.Millisecond)
+	nse := findNSEs(ctx, registryCC)[0]
+	require.Equal(t, "nse-1", nse.Name)
+	require.Equal(t, listenOn, nse.Url)
+	require.Equal(t, []string{"ns-1"}, nse.NetworkServiceNames)
+	require.Equal(t, map[string]string{"app": "nse"}, nse.NetworkServiceLabels["ns-1"].Labels)
+
+	nseCC, err := grpc.DialContext(ctx, listenOn, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() { _ = nseCC.Close() }()
+
+	nsc := client.NewClient(ctx, "nsc-1", nil, tokenGenerator, nseCC)
+	conn, err := nsc.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "conn-1",
+			NetworkService: "ns-1",
+			Context:        &networkservice.ConnectionContext{},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.0/32", conn.GetContext().GetIpContext().GetDstIpAddr())
+	require.Equal(t, "10.0.0.1/32", conn.GetContext().GetIpContext().GetSrcIpAddr())
+	require.Len(t, conn.GetContext().GetDnsContext().GetConfigs(), 1)
+	require.Equal(t, []string{"10.0.0.53"}, conn.GetContext().GetDnsContext().GetConfigs()[0].DnsServerIps)
+	require.Equal(t, []string{"example.org"}, conn.GetContext().GetDnsContext().GetConfigs()[0].SearchDomains)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+
+	stop()
+	require.NoError(t, <-errCh)
+	require.Empty(t, findNSEs(ctx, registryCC))
+}
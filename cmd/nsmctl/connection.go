@@ -0,0 +1,137 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
+	"github.com/networkservicemesh/sdk/pkg/tools/flags"
+)
+
+const (
+	pathSegmentKey    = "path-segment"
+	networkServiceKey = "network-service"
+	labelsKey         = "labels"
+	mechanismKey      = "mechanism"
+	keepKey           = "keep"
+	clientNameDefault = "nsmctl"
+)
+
+func newMonitorCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "monitor",
+		Short: "Stream the connection events from the Network Service Manager",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cc, err := o.dial(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() { _ = cc.Close() }()
+
+			selector := &networkservice.MonitorScopeSelector{}
+			for _, name := range o.v.GetStringSlice(pathSegmentKey) {
+				selector.PathSegments = append(selector.PathSegments, &networkservice.PathSegment{Name: name})
+			}
+
+			ctx := cmd.Context()
+			stream, err := networkservice.NewMonitorConnectionClient(cc).MonitorConnections(ctx, selector)
+			if err != nil {
+				return errors.Wrap(err, "failed to monitor connections")
+			}
+			for {
+				event, err := stream.Recv()
+				if err != nil {
+					return recvError(ctx, err)
+				}
+				if err := o.print(cmd.OutOrStdout(), event); err != nil {
+					return err
+				}
+			}
+		},
+	}
+	cmd.Flags().StringSlice(pathSegmentKey, nil, "Names of the path segments to monitor the connections for, all the connections are monitored if not set")
+	return cmd
+}
+
+func newRequestCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "request",
+		Short: "Request a connection to the Network Service using the client chain and Close it",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			networkService := o.v.GetString(networkServiceKey)
+			if networkService == "" {
+				return errors.Errorf("--%s is required", networkServiceKey)
+			}
+
+			cc, err := o.dial(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() { _ = cc.Close() }()
+
+			// Client context is not canceled by the signal, so the connection can be closed after that
+			clientCtx, cancelClient := context.WithCancel(context.Background())
+			defer cancelClient()
+			c := client.NewClient(clientCtx, o.v.GetString(flags.NameKey), nil, cc.tokenGenerator, cc)
+
+			requestCtx, cancelRequest := context.WithTimeout(cmd.Context(), o.timeout())
+			defer cancelRequest()
+			conn, err := c.Request(requestCtx, &networkservice.NetworkServiceRequest{
+				Connection: &networkservice.Connection{
+					NetworkService: networkService,
+					Labels:         o.v.GetStringMapString(labelsKey),
+				},
+				MechanismPreferences: []*networkservice.Mechanism{
+					{Cls: cls.LOCAL, Type: o.v.GetString(mechanismKey)},
+				},
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to request %s", networkService)
+			}
+			if err := o.print(cmd.OutOrStdout(), conn); err != nil {
+				return err
+			}
+
+			if o.v.GetBool(keepKey) {
+				<-cmd.Context().Done()
+			}
+
+			closeCtx, cancelClose := context.WithTimeout(clientCtx, o.timeout())
+			defer cancelClose()
+			if _, err := c.Close(closeCtx, conn); err != nil {
+				return errors.Wrapf(err, "failed to close %s", conn.GetId())
+			}
+			return nil
+		},
+	}
+	f := cmd.Flags()
+	f.String(networkServiceKey, "", "Network Service to request")
+	f.StringToString(labelsKey, nil, "Labels of the requested connection, e.g. app=nsmctl,color=red")
+	f.String(mechanismKey, kernel.MECHANISM, "Type of the preferred local mechanism")
+	f.StringP(flags.NameKey, flags.NameShortHand, clientNameDefault, "Name of the client")
+	f.Bool(keepKey, false, "Keep the connection until interrupted, it is refreshed and healed by the client chain")
+	return cmd
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"regexp"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const fileKey = "filename"
+
+var yamlSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// readFile - reads the entries from the YAML or JSON file. The file can contain a single entry, a list of entries or
+//            several YAML documents separated with "---". newMsg is called to create every read entry
+func readFile(filename string, newMsg func() proto.Message) ([]proto.Message, error) {
+	data, err := ioutil.ReadFile(filename) // #nosec
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", filename)
+	}
+
+	var rv []proto.Message
+	for _, doc := range yamlSeparator.Split(string(data), -1) {
+		var jsonData []byte
+		if jsonData, err = yaml.YAMLToJSON([]byte(doc)); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", filename)
+		}
+		jsonData = bytes.TrimSpace(jsonData)
+		if len(jsonData) == 0 || bytes.Equal(jsonData, []byte("null")) {
+			continue
+		}
+
+		items := []json.RawMessage{jsonData}
+		if jsonData[0] == '[' {
+			if err = json.Unmarshal(jsonData, &items); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse %s", filename)
+			}
+		}
+		for _, item := range items {
+			msg := newMsg()
+			if err = jsonpb.Unmarshal(bytes.NewReader(item), msg); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse %s", filename)
+			}
+			rv = append(rv, msg)
+		}
+	}
+	return rv, nil
+}
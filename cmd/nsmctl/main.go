@@ -0,0 +1,78 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nsmctl is a command-line tool for debugging Network Service Mesh: it works with the registry entries, monitors and
+// requests the connections
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/networkservicemesh/sdk/cmd/internal/cmdutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/flags"
+	"github.com/networkservicemesh/sdk/pkg/tools/signalctx"
+)
+
+func main() {
+	ctx := signalctx.WithSignals(context.Background())
+
+	if err := newRootCommand().ExecuteContext(ctx); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	v := viper.New()
+	v.SetEnvPrefix(flags.EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	v.AutomaticEnv()
+
+	cmd := &cobra.Command{
+		Use:           "nsmctl",
+		Short:         "Command-line tool for the Network Service Mesh registry and connections",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return v.BindPFlags(cmd.Flags())
+		},
+	}
+
+	pf := cmd.PersistentFlags()
+	pf.StringP(flags.ConnectToURLKey, flags.ConnectToURLShortHand,
+		flags.ConnectToURLSchemeDefault+"://"+flags.ConnectToURLPathDefault,
+		"URL of the registry or Network Service Manager to connect to")
+	pf.StringP(flags.SpiffeAgentURLKey, flags.SpiffeAgentURLShortHand,
+		flags.SpiffeAgentURLSchemeDefault+"://"+flags.SpiffeAgentURLPathDefault, flags.SpiffeAgentURLUsageDefault)
+	pf.Bool(cmdutils.InsecureKey, false, "Connect without TLS, the SPIFFE agent is not used")
+	pf.Duration(timeoutKey, defaultTimeout, "Timeout for the dial and the non-streaming calls")
+	pf.StringP(outputKey, outputShortHand, outputYAML, "Output format: yaml or json")
+
+	o := &options{v: v}
+	cmd.AddCommand(
+		newNSCommand(o),
+		newNSECommand(o),
+		newMonitorCommand(o),
+		newRequestCommand(o),
+	)
+	return cmd
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+)
+
+const nsesYAML = `
+name: nse-1
+network_service_names: [ns-1]
+url: tcp://127.0.0.1:5001
+---
+- name: nse-2
+  network_service_names: [ns-1]
+- name: nse-3
+`
+
+func startRegistry(t *testing.T) (registryURL string, stop func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	registry.RegisterNetworkServiceRegistryServer(server, memory.NewNetworkServiceRegistryServer())
+	registry.RegisterNetworkServiceEndpointRegistryServer(server, memory.NewNetworkServiceEndpointRegistryServer())
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	return "tcp://" + listener.Addr().String(), server.Stop
+}
+
+func writeFile(t *testing.T, data string) (filename string, remove func()) {
+	dir, err := ioutil.TempDir("", "nsmctl")
+	require.NoError(t, err)
+
+	filename = filepath.Join(dir, "nses.yaml")
+	require.NoError(t, ioutil.WriteFile(filename, []byte(data), 0600))
+
+	return filename, func() { _ = os.RemoveAll(dir) }
+}
+
+func run(t *testing.T, args ...string) string {
+	out := &bytes.Buffer{}
+	cmd := newRootCommand()
+	cmd.SetOut(out)
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.ExecuteContext(context.Background()))
+	return out.String()
+}
+
+func TestReadFile(t *testing.T) {
+	filename, remove := writeFile(t, nsesYAML)
+	defer remove()
+
+	msgs, err := readFile(filename, newNSE)
+	require.NoError(t, err)
+	require.Len(t, msgs, 3)
+	require.True(t, proto.Equal(&registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		Url:                 "tcp://127.0.0.1:5001",
+	}, msgs[0]))
+	require.Equal(t, "nse-3", msgs[2].(*registry.NetworkServiceEndpoint).Name)
+}
+
+func TestNSE_RegisterListUnregister(t *testing.T) {
+	registryURL, stop := startRegistry(t)
+	defer stop()
+
+	filename, remove := writeFile(t, nsesYAML)
+	defer remove()
+
+	run(t, "nse", "register", "-f", filename, "-c", registryURL, "--insecure")
+
+	out := run(t, "nse", "list", "-c", registryURL, "--insecure", "-o", "json")
+	require.Contains(t, out, `"name": "nse-1"`)
+	require.Contains(t, out, `"name": "nse-2"`)
+	require.Contains(t, out, `"name": "nse-3"`)
+
+	out = run(t, "nse", "list", "--name", "nse-2", "-c", registryURL, "--insecure")
+	require.Contains(t, out, "name: nse-2")
+	require.NotContains(t, out, "name: nse-1")
+
+	run(t, "nse", "unregister", "-f", filename, "-c", registryURL, "--insecure")
+
+	out = run(t, "nse", "list", "-c", registryURL, "--insecure")
+	require.Empty(t, out)
+}
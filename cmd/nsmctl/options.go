@@ -0,0 +1,115 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/cmd/internal/cmdutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/flags"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+const (
+	timeoutKey      = "timeout"
+	defaultTimeout  = 15 * time.Second
+	outputKey       = "output"
+	outputShortHand = "o"
+	outputYAML      = "yaml"
+	outputJSON      = "json"
+)
+
+// options - common options of the nsmctl commands, bound to the flags and NSM_* environment variables
+type options struct {
+	v *viper.Viper
+}
+
+func (o *options) timeout() time.Duration {
+	return o.v.GetDuration(timeoutKey)
+}
+
+// connection - dialed grpc.ClientConn with the token generator for the Requests
+type connection struct {
+	*grpc.ClientConn
+	tokenGenerator token.GeneratorFunc
+	creds          *cmdutils.Credentials
+}
+
+func (c *connection) Close() error {
+	err := c.ClientConn.Close()
+	_ = c.creds.Close()
+	return err
+}
+
+// dial - dials the connect-to-url using the TLS credentials from the SPIFFE agent, unless insecure is set
+func (o *options) dial(ctx context.Context) (*connection, error) {
+	connectTo, err := url.Parse(o.v.GetString(flags.ConnectToURLKey))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", flags.ConnectToURLKey)
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, o.timeout())
+	defer cancel()
+
+	creds, err := cmdutils.NewCredentials(dialCtx, o.v)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := grpc.DialContext(dialCtx, grpcutils.URLToTarget(connectTo), append(creds.DialOptions, grpc.WithBlock())...)
+	if err != nil {
+		_ = creds.Close()
+		return nil, errors.Wrapf(err, "failed to dial %s", connectTo)
+	}
+	return &connection{
+		ClientConn:     cc,
+		tokenGenerator: creds.TokenGenerator,
+		creds:          creds,
+	}, nil
+}
+
+// print - writes msg to out in the output format, YAML documents are separated with "---"
+func (o *options) print(out io.Writer, msg proto.Message) error {
+	data, err := (&jsonpb.Marshaler{OrigName: true, Indent: "  "}).MarshalToString(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal")
+	}
+	switch format := o.v.GetString(outputKey); format {
+	case outputJSON:
+		_, err = fmt.Fprintln(out, data)
+	case outputYAML:
+		var yamlData []byte
+		if yamlData, err = yaml.JSONToYAML([]byte(data)); err != nil {
+			return errors.Wrap(err, "failed to convert to YAML")
+		}
+		_, err = fmt.Fprintf(out, "---\n%s", yamlData)
+	default:
+		return errors.Errorf("unknown output format: %s", format)
+	}
+	return err
+}
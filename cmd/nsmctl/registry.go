@@ -0,0 +1,210 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const nameKey = "name"
+
+func newNSCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ns",
+		Aliases: []string{"networkservice"},
+		Short:   "Network Services in the registry",
+	}
+	cmd.AddCommand(
+		newFindCommand("list", "List the Network Services", false, o, findNS),
+		newFindCommand("watch", "Watch the Network Services updates", true, o, findNS),
+		newFileCommand("register", "Register the Network Services from the file", o, newNS, registerNS),
+		newFileCommand("unregister", "Unregister the Network Services from the file", o, newNS, unregisterNS),
+	)
+	return cmd
+}
+
+func newNSECommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "nse",
+		Aliases: []string{"endpoint"},
+		Short:   "Network Service Endpoints in the registry",
+	}
+	cmd.AddCommand(
+		newFindCommand("list", "List the Network Service Endpoints", false, o, findNSE),
+		newFindCommand("watch", "Watch the Network Service Endpoints updates", true, o, findNSE),
+		newFileCommand("register", "Register the Network Service Endpoints from the file", o, newNSE, registerNSE),
+		newFileCommand("unregister", "Unregister the Network Service Endpoints from the file", o, newNSE, unregisterNSE),
+	)
+	return cmd
+}
+
+// findFunc - finds the entries with the name (all if empty) and calls onRecv for every received entry
+type findFunc func(ctx context.Context, cc *connection, name string, watch bool, onRecv func(proto.Message) error) error
+
+func newFindCommand(use, short string, watch bool, o *options, find findFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cc, err := o.dial(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() { _ = cc.Close() }()
+
+			var ctx context.Context
+			var cancel context.CancelFunc
+			if watch {
+				ctx, cancel = context.WithCancel(cmd.Context())
+			} else {
+				ctx, cancel = context.WithTimeout(cmd.Context(), o.timeout())
+			}
+			defer cancel()
+
+			return find(ctx, cc, o.v.GetString(nameKey), watch, func(msg proto.Message) error {
+				return o.print(cmd.OutOrStdout(), msg)
+			})
+		},
+	}
+	cmd.Flags().String(nameKey, "", "Name of the entry to find, all the entries are found if not set")
+	return cmd
+}
+
+// fileFunc - calls the registry for the entry read from the file
+type fileFunc func(ctx context.Context, cc *connection, msg proto.Message) (proto.Message, error)
+
+func newFileCommand(use, short string, o *options, newMsg func() proto.Message, call fileFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			filename := o.v.GetString(fileKey)
+			if filename == "" {
+				return errors.Errorf("--%s is required", fileKey)
+			}
+			msgs, err := readFile(filename, newMsg)
+			if err != nil {
+				return err
+			}
+
+			cc, err := o.dial(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() { _ = cc.Close() }()
+
+			for _, msg := range msgs {
+				ctx, cancel := context.WithTimeout(cmd.Context(), o.timeout())
+				resp, err := call(ctx, cc, msg)
+				cancel()
+				if err != nil {
+					return err
+				}
+				if resp != nil {
+					if err := o.print(cmd.OutOrStdout(), resp); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringP(fileKey, "f", "", "YAML or JSON file with the entries")
+	return cmd
+}
+
+func newNS() proto.Message {
+	return new(registry.NetworkService)
+}
+
+func newNSE() proto.Message {
+	return new(registry.NetworkServiceEndpoint)
+}
+
+func findNS(ctx context.Context, cc *connection, name string, watch bool, onRecv func(proto.Message) error) error {
+	stream, err := registry.NewNetworkServiceRegistryClient(cc).Find(ctx, &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{Name: name},
+		Watch:          watch,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to find Network Services")
+	}
+	for {
+		ns, err := stream.Recv()
+		if err != nil {
+			return recvError(ctx, err)
+		}
+		if err := onRecv(ns); err != nil {
+			return err
+		}
+	}
+}
+
+func findNSE(ctx context.Context, cc *connection, name string, watch bool, onRecv func(proto.Message) error) error {
+	stream, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: name},
+		Watch:                  watch,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to find Network Service Endpoints")
+	}
+	for {
+		nse, err := stream.Recv()
+		if err != nil {
+			return recvError(ctx, err)
+		}
+		if err := onRecv(nse); err != nil {
+			return err
+		}
+	}
+}
+
+// recvError - returns nil if the stream is over or has been canceled by the user
+func recvError(ctx context.Context, err error) error {
+	if err == io.EOF || ctx.Err() == context.Canceled {
+		return nil
+	}
+	return errors.Wrap(err, "failed to receive")
+}
+
+func registerNS(ctx context.Context, cc *connection, msg proto.Message) (proto.Message, error) {
+	resp, err := registry.NewNetworkServiceRegistryClient(cc).Register(ctx, msg.(*registry.NetworkService))
+	return resp, errors.Wrap(err, "failed to register Network Service")
+}
+
+func unregisterNS(ctx context.Context, cc *connection, msg proto.Message) (proto.Message, error) {
+	_, err := registry.NewNetworkServiceRegistryClient(cc).Unregister(ctx, msg.(*registry.NetworkService))
+	return nil, errors.Wrap(err, "failed to unregister Network Service")
+}
+
+func registerNSE(ctx context.Context, cc *connection, msg proto.Message) (proto.Message, error) {
+	resp, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Register(ctx, msg.(*registry.NetworkServiceEndpoint))
+	return resp, errors.Wrap(err, "failed to register Network Service Endpoint")
+}
+
+func unregisterNSE(ctx context.Context, cc *connection, msg proto.Message) (proto.Message, error) {
+	_, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Unregister(ctx, msg.(*registry.NetworkServiceEndpoint))
+	return nil, errors.Wrap(err, "failed to unregister Network Service Endpoint")
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nsmgr is a Network Service Manager registering the local endpoints in the registry
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/cmd/internal/cmdutils"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/tools/chainmetrics"
+	"github.com/networkservicemesh/sdk/pkg/tools/flags"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const nameDefault = "nsmgr"
+
+func main() {
+	cmdutils.Execute(newCommand())
+}
+
+func newCommand() *cobra.Command {
+	cmd := cmdutils.NewCommand("nsmgr", "Network Service Manager", run)
+
+	f := cmd.Flags()
+	f.StringP(flags.NameKey, flags.NameShortHand, nameDefault, "Name of the Network Service Manager")
+	f.StringP(flags.ListenOnURLKey, flags.ListenOnURLShortHand,
+		flags.ListenOnURLSchemeDefault+"://"+flags.ListenOnURLPathDefault,
+		"URL to listen for incoming networkservicemesh RPC calls, it is registered as the URL of the local endpoints")
+	f.StringP(flags.ConnectToURLKey, flags.ConnectToURLShortHand, "",
+		"URL of the registry, the registrations are kept in memory if not set")
+	f.String(flags.AdminListenOnURLKey, "", flags.AdminListenOnURLUsageDefault)
+	f.String(flags.MetricsListenOnURLKey, "", flags.MetricsListenOnURLUsageDefault)
+	return cmd
+}
+
+func run(ctx context.Context, v *viper.Viper) error {
+	creds, err := cmdutils.NewCredentials(ctx, v)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = creds.Close() }()
+
+	registryCC, err := cmdutils.Dial(ctx, v, flags.ConnectToURLKey, creds)
+	if err != nil {
+		return err
+	}
+	var registryClientConn grpc.ClientConnInterface
+	if registryCC != nil {
+		defer func() { _ = registryCC.Close() }()
+		registryClientConn = registryCC
+	}
+
+	metricsURL, err := cmdutils.URL(v, flags.MetricsListenOnURLKey)
+	if err != nil {
+		return err
+	}
+	newServer := nsmgr.NewServer
+	if metricsURL != nil {
+		metricsRegistry := prometheus.NewRegistry()
+		if err := serveMetrics(ctx, metricsURL, metricsRegistry); err != nil {
+			return err
+		}
+		newServer = nsmgr.WithMetrics(metricsRegistry).NewServer
+	}
+
+	mgr := newServer(ctx,
+		&registryapi.NetworkServiceEndpoint{
+			Name: v.GetString(flags.NameKey),
+			Url:  v.GetString(flags.ListenOnURLKey),
+		},
+		creds.AuthzServer(),
+		creds.TokenGenerator,
+		registryClientConn,
+		creds.DialOptions...)
+
+	adminURL, err := cmdutils.URL(v, flags.AdminListenOnURLKey)
+	if err != nil {
+		return err
+	}
+	if adminURL != nil {
+		adminServer := grpc.NewServer()
+		mgr.RegisterAdmin(adminServer)
+		defer adminServer.Stop()
+		adminErrCh := grpcutils.ListenAndServe(ctx, adminURL, adminServer)
+		go func() {
+			select {
+			case err := <-adminErrCh:
+				log.FromContext(ctx).Errorf("Failed to serve admin on %s: %+v", adminURL, err)
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	server := grpc.NewServer(creds.ServerOptions...)
+	mgr.Register(server)
+	return cmdutils.ListenAndServe(ctx, v, server, nil, mgr.Drain)
+}
+
+// serveMetrics - serves the metrics gathered by gatherer on u until ctx is done
+func serveMetrics(ctx context.Context, u *url.URL, gatherer prometheus.Gatherer) error {
+	ln, err := net.Listen(grpcutils.TargetToNetAddr(grpcutils.URLToTarget(u)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %s", u)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", chainmetrics.Handler(gatherer))
+	server := &http.Server{Handler: mux}
+	go func() {
+		if err := server.Serve(ln); err != http.ErrServerClosed {
+			log.FromContext(ctx).Errorf("Failed to serve metrics on %s: %+v", u, err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	return nil
+}
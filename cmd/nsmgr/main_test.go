@@ -0,0 +1,123 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestNSMgr(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nsmgr")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	listenOn := "unix://" + filepath.Join(dir, "nsmgr.sock")
+	cmd := newCommand()
+	cmd.SetArgs([]string{"--insecure", "-l", listenOn})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cmd.ExecuteContext(runCtx)
+	}()
+
+	cc, err := grpc.DialContext(ctx, listenOn, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	_, err = registry.NewNetworkServiceRegistryClient(cc).Register(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.NoError(t, err)
+
+	nseClient := registry.NewNetworkServiceEndpointRegistryClient(cc)
+	nse, err := nseClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		Url:                 "unix://" + filepath.Join(dir, "nse.sock"),
+	})
+	require.NoError(t, err)
+
+	stream, err := nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: nse.Name},
+	})
+	require.NoError(t, err)
+	found, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, listenOn, found.Url)
+
+	stop()
+	require.NoError(t, <-errCh)
+}
+
+func TestNSMgr_Metrics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nsmgr")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	listenOn := "unix://" + filepath.Join(dir, "nsmgr.sock")
+	metricsSocket := filepath.Join(dir, "metrics.sock")
+	cmd := newCommand()
+	cmd.SetArgs([]string{"--insecure", "-l", listenOn, "--metrics-listen-on-url", "unix://" + metricsSocket})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cmd.ExecuteContext(runCtx)
+	}()
+
+	cc, err := grpc.DialContext(ctx, listenOn, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	_, err = registry.NewNetworkServiceRegistryClient(cc).Register(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.NoError(t, err)
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return new(net.Dialer).DialContext(ctx, "unix", metricsSocket)
+			},
+		},
+	}
+	resp, err := httpClient.Get("http://nsmgr/metrics")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, string(body),
+		`nsm_chain_element_duration_seconds_count{chain="registry_ns_server",element="memory.networkServiceRegistryServer",method="Register"} 1`)
+
+	stop()
+	require.NoError(t, <-errCh)
+}
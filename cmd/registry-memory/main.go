@@ -0,0 +1,79 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// registry-memory is a Network Service Mesh registry keeping the registrations in memory
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/cmd/internal/cmdutils"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/registry"
+	"github.com/networkservicemesh/sdk/pkg/tools/flags"
+)
+
+const (
+	expirePeriodKey     = "expire-period"
+	proxyRegistryURLKey = "proxy-registry-url"
+)
+
+func main() {
+	cmdutils.Execute(newCommand())
+}
+
+func newCommand() *cobra.Command {
+	cmd := cmdutils.NewCommand("registry-memory", "Network Service Mesh registry keeping the registrations in memory", run)
+
+	f := cmd.Flags()
+	f.StringP(flags.ListenOnURLKey, flags.ListenOnURLShortHand,
+		flags.ListenOnURLSchemeDefault+"://"+flags.ListenOnURLPathDefault, flags.ListenOnURLUsageDefault)
+	f.Duration(expirePeriodKey, 0, "Period of checking the Network Service Endpoints expiration, default is used if not set")
+	f.String(proxyRegistryURLKey, "", "URL of the proxy registry to forward the interdomain calls to")
+	return cmd
+}
+
+func run(ctx context.Context, v *viper.Viper) error {
+	creds, err := cmdutils.NewCredentials(ctx, v)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = creds.Close() }()
+
+	proxyRegistryURL, err := cmdutils.URL(v, proxyRegistryURLKey)
+	if err != nil {
+		return err
+	}
+
+	options := []registry.Option{registry.WithDialOptions(creds.DialOptions...)}
+	if proxyRegistryURL != nil {
+		options = append(options, registry.WithProxyRegistryURL(proxyRegistryURL))
+	}
+	if expirePeriod := v.GetDuration(expirePeriodKey); expirePeriod > 0 {
+		options = append(options, registry.WithExpirePeriod(expirePeriod))
+	}
+	r, err := registry.NewLocalServer(ctx, options...)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer(creds.ServerOptions...)
+	r.Register(server)
+	return cmdutils.ListenAndServe(ctx, v, server, nil, nil)
+}
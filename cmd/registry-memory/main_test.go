@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestRegistryMemory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry-memory")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	listenOn := "unix://" + filepath.Join(dir, "registry.sock")
+	cmd := newCommand()
+	cmd.SetArgs([]string{"--insecure", "-l", listenOn})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cmd.ExecuteContext(runCtx)
+	}()
+
+	cc, err := grpc.DialContext(ctx, listenOn, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	nseClient := registry.NewNetworkServiceEndpointRegistryClient(cc)
+	nse, err := nseClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"ns-1"},
+		Url:                 "tcp://127.0.0.1:5001",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, nse.Name)
+
+	stream, err := nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: nse.Name},
+	})
+	require.NoError(t, err)
+	found, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, nse.Url, found.Url)
+
+	stop()
+	require.NoError(t, <-errCh)
+}
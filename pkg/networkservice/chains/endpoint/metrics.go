@@ -0,0 +1,53 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/metrics"
+	"github.com/networkservicemesh/sdk/pkg/tools/chainmetrics"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+// Metrics - creates the endpoints collecting Prometheus metrics of their chain elements and active connections
+type Metrics struct {
+	chain   *chain.Metrics
+	metrics *chainmetrics.Metrics
+}
+
+// WithMetrics - returns Metrics creating the endpoints collecting the metrics registered on registerer, e.g.
+//   endpoint.WithMetrics(registry).NewServer(ctx, name, authzServer, tokenGenerator, additionalFunctionality...)
+func WithMetrics(registerer prometheus.Registerer) *Metrics {
+	return &Metrics{
+		chain:   chain.WithMetrics(registerer),
+		metrics: chainmetrics.New(registerer),
+	}
+}
+
+// NewServer - same as endpoint.NewServer, but the chain elements collect the metrics and the active connections are
+//             counted
+func (m *Metrics) NewServer(ctx context.Context, name string, authzServer networkservice.NetworkServiceServer, tokenGenerator token.GeneratorFunc, additionalFunctionality ...networkservice.NetworkServiceServer) Endpoint {
+	return newServer(m.chain.NewNetworkServiceServer, name, authzServer, tokenGenerator,
+		append([]networkservice.NetworkServiceServer{
+			metrics.NewConnectionsServer(m.metrics),
+		}, additionalFunctionality...)...)
+}
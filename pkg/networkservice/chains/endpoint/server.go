@@ -20,16 +20,24 @@
 package endpoint
 
 import (
+	"context"
+	"time"
+
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/drain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/panicrecover"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/serialize"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/setid"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/timeout"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/updatepath"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 )
 
@@ -41,33 +49,78 @@ type Endpoint interface {
 	networkservice.MonitorConnectionServer
 	// Register - register the endpoint with *grpc.Server s
 	Register(s *grpc.Server)
+	// Stop - stops accepting new Requests, the active connections are kept until Drain. Can be used to unregister the
+	//        endpoint after it doesn't accept new Requests and before its connections are closed.
+	Stop()
+	// Drain - stops accepting new Requests and closes all the active connections notifying the monitor subscribers.
+	//         Returns when all the connections are closed or ctx is done.
+	//         Endpoint is not drained automatically when its context is done, the owner of the endpoint should call
+	//         Drain (or use WithAutoDrain) to order it with the other shutdown steps, e.g. unregistering.
+	Drain(ctx context.Context) error
 }
 
+// DrainTimeout - time given to close the active connections when the endpoint is drained on shutdown
+const DrainTimeout = 15 * time.Second
+
 type endpoint struct {
 	networkservice.NetworkServiceServer
 	networkservice.MonitorConnectionServer
+	drain drain.Server
 }
 
 // NewServer - returns a NetworkServiceMesh client as a chain of the standard Client pieces plus whatever
 //             additional functionality is specified
+//             - ctx - context of the endpoint
 //             - name - name of the NetworkServiceServer
 //             - authzServer authorization server chain element
 //             - tokenGenerator - token.GeneratorFunc - generates tokens for use in Path
 //             - additionalFunctionality - any additional NetworkServiceServer chain elements to be included in the chain
-func NewServer(name string, authzServer networkservice.NetworkServiceServer, tokenGenerator token.GeneratorFunc, additionalFunctionality ...networkservice.NetworkServiceServer) Endpoint {
+func NewServer(ctx context.Context, name string, authzServer networkservice.NetworkServiceServer, tokenGenerator token.GeneratorFunc, additionalFunctionality ...networkservice.NetworkServiceServer) Endpoint {
+	return newServer(chain.NewNetworkServiceServer, name, authzServer, tokenGenerator, additionalFunctionality...)
+}
+
+func newServer(newChain func(...networkservice.NetworkServiceServer) networkservice.NetworkServiceServer, name string, authzServer networkservice.NetworkServiceServer, tokenGenerator token.GeneratorFunc, additionalFunctionality ...networkservice.NetworkServiceServer) Endpoint {
 	rv := &endpoint{}
 	var ns networkservice.NetworkServiceServer = rv
-	rv.NetworkServiceServer = chain.NewNetworkServiceServer(
+	rv.drain = drain.NewServer(&ns)
+	rv.NetworkServiceServer = newChain(
 		append([]networkservice.NetworkServiceServer{
+			panicrecover.NewServer(),
 			authzServer,
 			setid.NewServer(name),
+			serialize.NewServer(),
+			metadata.NewServer(),
+			rv.drain,
 			monitor.NewServer(&rv.MonitorConnectionServer),
 			timeout.NewServer(&ns),
 			updatepath.NewServer(name, tokenGenerator),
 		}, additionalFunctionality...)...)
+
 	return rv
 }
 
+// WithAutoDrain - drains e within DrainTimeout when ctx is done and returns e. Should be used only if nothing else
+//                 drains e on shutdown, otherwise the shutdown steps race with each other.
+func WithAutoDrain(ctx context.Context, e Endpoint) Endpoint {
+	go func() {
+		<-ctx.Done()
+		drainCtx, cancel := context.WithTimeout(context.Background(), DrainTimeout)
+		defer cancel()
+		if err := e.Drain(drainCtx); err != nil {
+			trace.Log(drainCtx).Errorf("Failed to drain: %+v", err)
+		}
+	}()
+	return e
+}
+
+func (e *endpoint) Stop() {
+	e.drain.Stop()
+}
+
+func (e *endpoint) Drain(ctx context.Context) error {
+	return e.drain.Drain(ctx)
+}
+
 func (e *endpoint) Register(s *grpc.Server) {
 	grpcutils.RegisterHealthServices(s, e)
 	networkservice.RegisterNetworkServiceServer(s, e)
@@ -0,0 +1,53 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmgr
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/endpoint"
+	chain_registry "github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+// Metrics - creates the Network Service Managers collecting Prometheus metrics
+type Metrics struct {
+	endpoint *endpoint.Metrics
+	registry *chain_registry.Metrics
+}
+
+// WithMetrics - returns Metrics creating the Network Service Managers collecting the metrics registered on registerer,
+// e.g.
+//   nsmgr.WithMetrics(registry).NewServer(ctx, nsmRegistration, authzServer, tokenGenerator, registryCC)
+// Use chainmetrics.Handler(registry) to expose the metrics.
+func WithMetrics(registerer prometheus.Registerer) *Metrics {
+	return &Metrics{
+		endpoint: endpoint.WithMetrics(registerer),
+		registry: chain_registry.WithMetrics(registerer),
+	}
+}
+
+// NewServer - same as nsmgr.NewServer, but the Network Service Manager collects the metrics of its networkservice and
+//             registry chain elements and counts the active connections
+func (m *Metrics) NewServer(ctx context.Context, nsmRegistration *registryapi.NetworkServiceEndpoint, authzServer networkservice.NetworkServiceServer, tokenGenerator token.GeneratorFunc, registryCC grpc.ClientConnInterface, clientDialOptions ...grpc.DialOption) Nsmgr {
+	return newServer(ctx, m, nsmRegistration, authzServer, tokenGenerator, registryCC, clientDialOptions...)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peertracker
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type configurable interface {
+	setGracePeriod(time.Duration)
+	setClock(clock.Clock)
+}
+
+// Option is peer tracker configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithGracePeriod sets how long to wait after a peer has disconnected before closing its connections, by default
+// 30 seconds. If a connection is requested again within the grace period (e.g. the peer has reconnected), it is
+// not closed.
+func WithGracePeriod(gracePeriod time.Duration) Option {
+	return applierFunc(func(c configurable) {
+		c.setGracePeriod(gracePeriod)
+	})
+}
+
+// WithClock sets the clock used to wait for the grace period, by default the real clock is used
+func WithClock(c clock.Clock) Option {
+	return applierFunc(func(cfg configurable) {
+		cfg.setClock(c)
+	})
+}
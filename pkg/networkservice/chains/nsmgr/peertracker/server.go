@@ -14,67 +14,117 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package peertracker provides a wrapper for a Nsmgr that tracks connections received from local Clients
-// Its designed to be used in a DevicePlugin to allow us to properly Close connections on re-Allocate
+// Package peertracker provides a wrapper for a Nsmgr that tracks connections received from the peers and closes them
+// when the peer disconnects. Peers are tracked with a grpc stats.Handler, so it works for any network type
 package peertracker
 
 import (
 	"context"
-	"net/url"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	"google.golang.org/grpc/peer"
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/serialize"
 )
 
 const (
-	unixScheme = "unix"
+	defaultGracePeriod = 30 * time.Second
 )
 
+// Server - Nsmgr that tracks connections received from the peers. It should be passed to the grpc.Server with
+//          grpc.StatsHandler option to be notified when the peers disconnect
+type Server interface {
+	nsmgr.Nsmgr
+	stats.Handler
+}
+
+type peerKey struct{}
+
+// peerConn - transport connection of some peer
+type peerConn struct {
+	disconnected bool
+}
+
+type connectionInfo struct {
+	ctx   context.Context
+	conn  *networkservice.Connection
+	owner *peerConn
+	timer clock.Timer
+}
+
 type peerTrackerServer struct {
 	nsmgr.Nsmgr
-	executor serialize.Executor
-	// Outer map is peer url.URL.String(), inner map key is Connection.Id
-	connections map[string]map[string]*networkservice.Connection
+	gracePeriod time.Duration
+	clock       clock.Clock
+	executor    serialize.Executor
+	// key is Connection.Id
+	connections map[string]*connectionInfo
 }
 
 // NewServer - Creates a new peer tracker Server
 //           inner - Nsmgr being wrapped
-//           closeAll - pointer to memory location to which you should write a pointer to the function to be called
-//                      to close all connections for the provided url (presuming a unix URL)
-func NewServer(inner nsmgr.Nsmgr, closeAll *func(ctx context.Context, u *url.URL)) nsmgr.Nsmgr {
+//           options - configuration options
+func NewServer(inner nsmgr.Nsmgr, options ...Option) Server {
 	rv := &peerTrackerServer{
-		connections: make(map[string]map[string]*networkservice.Connection),
 		Nsmgr:       inner,
+		gracePeriod: defaultGracePeriod,
+		clock:       clock.New(),
+		connections: make(map[string]*connectionInfo),
+	}
+	for _, o := range options {
+		o.apply(rv)
 	}
-	*closeAll = rv.closeAllConnectionsForPeer
 	return rv
 }
 
+func (p *peerTrackerServer) setGracePeriod(gracePeriod time.Duration) {
+	p.gracePeriod = gracePeriod
+}
+
+func (p *peerTrackerServer) setClock(c clock.Clock) {
+	p.clock = c
+}
+
 func (p *peerTrackerServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	index := request.GetConnection().GetPath().GetIndex()
 	conn, err := p.Nsmgr.Request(ctx, request)
 	if err != nil {
 		return nil, err
 	}
-	mypeer, ok := peer.FromContext(ctx)
-	if ok {
-		if mypeer.Addr.Network() == unixScheme {
-			u := &url.URL{
-				Scheme: mypeer.Addr.Network(),
-				Path:   mypeer.Addr.String(),
-			}
-			p.executor.AsyncExec(func() {
-				_, ok := p.connections[u.String()]
-				if !ok {
-					p.connections[u.String()] = make(map[string]*networkservice.Connection)
-				}
-				p.connections[u.String()][conn.GetId()] = conn
-			})
-		}
+	owner, ok := ctx.Value(peerKey{}).(*peerConn)
+	if !ok {
+		return conn, nil
 	}
+
+	info := &connectionInfo{
+		ctx:   extend.WithValuesFromContext(context.Background(), ctx),
+		conn:  conn.Clone(),
+		owner: owner,
+	}
+	// Connection should be closed the same way as the peer closes it
+	if info.conn.GetPath() != nil {
+		info.conn.GetPath().Index = index
+	}
+
+	p.executor.AsyncExec(func() {
+		// Connection is owned by the peer which has requested it last, so the pending close is canceled
+		if prev, ok := p.connections[conn.GetId()]; ok && prev.timer != nil {
+			prev.timer.Stop()
+		}
+		p.connections[conn.GetId()] = info
+		if owner.disconnected {
+			p.closeAfterGracePeriod(conn.GetId(), info)
+		}
+	})
 	return conn, nil
 }
 
@@ -83,29 +133,72 @@ func (p *peerTrackerServer) Close(ctx context.Context, conn *networkservice.Conn
 	if err != nil {
 		return nil, err
 	}
-	mypeer, ok := peer.FromContext(ctx)
-	if ok {
-		if mypeer.Addr.Network() == unixScheme {
-			u := &url.URL{
-				Scheme: mypeer.Addr.Network(),
-				Path:   mypeer.Addr.String(),
+	p.executor.AsyncExec(func() {
+		if info, ok := p.connections[conn.GetId()]; ok {
+			if info.timer != nil {
+				info.timer.Stop()
 			}
-			p.executor.AsyncExec(func() {
-				delete(p.connections[u.String()], conn.GetId())
-			})
+			delete(p.connections, conn.GetId())
 		}
-	}
+	})
 	return &empty.Empty{}, nil
 }
 
-func (p *peerTrackerServer) closeAllConnectionsForPeer(ctx context.Context, u *url.URL) {
-	finishedChan := make(chan struct{})
-	<-p.executor.AsyncExec(func() {
-		if connMap, ok := p.connections[u.String()]; ok {
-			for _, conn := range connMap {
-				_, _ = p.Close(ctx, conn)
+// Register - registers p instead of the wrapped Nsmgr, so the Requests are tracked. Admin service is registered
+//            separately by RegisterAdmin of the wrapped Nsmgr.
+func (p *peerTrackerServer) Register(s *grpc.Server) {
+	grpcutils.RegisterHealthServices(s, p, p.NetworkServiceEndpointRegistryServer(), p.NetworkServiceRegistryServer())
+	networkservice.RegisterNetworkServiceServer(s, p)
+	networkservice.RegisterMonitorConnectionServer(s, p)
+	registryapi.RegisterNetworkServiceRegistryServer(s, p.NetworkServiceRegistryServer())
+	registryapi.RegisterNetworkServiceEndpointRegistryServer(s, p.NetworkServiceEndpointRegistryServer())
+}
+
+func (p *peerTrackerServer) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (p *peerTrackerServer) HandleRPC(context.Context, stats.RPCStats) {}
+
+func (p *peerTrackerServer) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, peerKey{}, &peerConn{})
+}
+
+func (p *peerTrackerServer) HandleConn(ctx context.Context, connStats stats.ConnStats) {
+	if _, ok := connStats.(*stats.ConnEnd); !ok {
+		return
+	}
+	owner, ok := ctx.Value(peerKey{}).(*peerConn)
+	if !ok {
+		return
+	}
+	p.executor.AsyncExec(func() {
+		owner.disconnected = true
+		for id, info := range p.connections {
+			if info.owner == owner {
+				p.closeAfterGracePeriod(id, info)
+			}
+		}
+	})
+}
+
+// closeAfterGracePeriod - should be called in the executor
+func (p *peerTrackerServer) closeAfterGracePeriod(id string, info *connectionInfo) {
+	info.timer = p.clock.AfterFunc(p.gracePeriod, func() {
+		var expired bool
+		<-p.executor.AsyncExec(func() {
+			if p.connections[id] == info {
+				delete(p.connections, id)
+				expired = true
 			}
+		})
+		if !expired {
+			return
+		}
+		if _, err := p.Nsmgr.Close(info.ctx, info.conn); err != nil {
+			trace.Log(info.ctx).Errorf("Error attempting to close connection of the disconnected peer: %s: %+v", id, err)
 		}
-		close(finishedChan)
 	})
 }
+
+var _ Server = &peerTrackerServer{}
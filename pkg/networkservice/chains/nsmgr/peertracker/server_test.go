@@ -0,0 +1,154 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peertracker_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr/peertracker"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+const (
+	gracePeriod          = time.Minute
+	expectAbsenceTimeout = 100 * time.Millisecond
+)
+
+type closeRecorder struct {
+	nsmgr.Nsmgr
+	closed chan string
+}
+
+func (r *closeRecorder) Request(_ context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return request.GetConnection(), nil
+}
+
+func (r *closeRecorder) Close(_ context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	r.closed <- conn.GetId()
+	return &empty.Empty{}, nil
+}
+
+func serve(ctx context.Context, t *testing.T, clk clock.Clock) (*url.URL, *closeRecorder) {
+	recorder := &closeRecorder{closed: make(chan string, 10)}
+	server := peertracker.NewServer(recorder, peertracker.WithGracePeriod(gracePeriod), peertracker.WithClock(clk))
+
+	s := grpc.NewServer(grpc.StatsHandler(server))
+	networkservice.RegisterNetworkServiceServer(s, server)
+
+	u := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	require.NotNil(t, grpcutils.ListenAndServe(ctx, u, s))
+	return u, recorder
+}
+
+func request(ctx context.Context, t *testing.T, u *url.URL, id string) *grpc.ClientConn {
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(u), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	_, err = networkservice.NewNetworkServiceClient(cc).Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: id,
+		},
+	})
+	require.NoError(t, err)
+	return cc
+}
+
+func TestPeerTracker_Disconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	clk := clock.NewFake(time.Now())
+	u, recorder := serve(ctx, t, clk)
+
+	cc := request(ctx, t, u, "1")
+	require.NoError(t, cc.Close())
+
+	clk.BlockUntil(1)
+	clk.Add(gracePeriod - time.Millisecond)
+	select {
+	case id := <-recorder.closed:
+		require.FailNow(t, "connection is closed before the grace period", id)
+	case <-time.After(expectAbsenceTimeout):
+	}
+
+	clk.Add(time.Millisecond)
+	select {
+	case id := <-recorder.closed:
+		require.Equal(t, "1", id)
+	case <-ctx.Done():
+		require.FailNow(t, "connection of the disconnected peer is not closed")
+	}
+}
+
+func TestPeerTracker_Reconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	clk := clock.NewFake(time.Now())
+	u, recorder := serve(ctx, t, clk)
+
+	cc := request(ctx, t, u, "1")
+	require.NoError(t, cc.Close())
+	clk.BlockUntil(1)
+
+	// Peer reconnects and refreshes the connection within the grace period
+	cc = request(ctx, t, u, "1")
+	defer func() { _ = cc.Close() }()
+
+	clk.Add(gracePeriod)
+	select {
+	case id := <-recorder.closed:
+		require.FailNow(t, "connection of the reconnected peer is closed", id)
+	case <-time.After(expectAbsenceTimeout):
+	}
+
+	_, err := networkservice.NewNetworkServiceClient(cc).Close(ctx, &networkservice.Connection{Id: "1"})
+	require.NoError(t, err)
+	require.Equal(t, "1", <-recorder.closed)
+}
+
+func TestPeerTracker_RegisterAdmin(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tokenGenerator := func(credentials.AuthInfo) (string, time.Time, error) {
+		return "token", time.Now().Add(time.Hour), nil
+	}
+	server := peertracker.NewServer(nsmgr.NewServer(ctx, &registryapi.NetworkServiceEndpoint{Name: "nsmgr"},
+		authorize.NewServer(), tokenGenerator, nil))
+
+	// Admin service is served only on the separate server
+	s := grpc.NewServer()
+	server.Register(s)
+	require.NotContains(t, s.GetServiceInfo(), "admin.Admin")
+
+	adminServer := grpc.NewServer()
+	server.RegisterAdmin(adminServer)
+	require.Contains(t, adminServer.GetServiceInfo(), "admin.Admin")
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmgr
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+// registrations - registry.NetworkServiceEndpointRegistryServer chain element tracking the local endpoints
+// registrations, so they can be unregistered on drain
+type registrations struct {
+	nses sync.Map
+}
+
+func (r *registrations) Register(ctx context.Context, nse *registryapi.NetworkServiceEndpoint) (*registryapi.NetworkServiceEndpoint, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	r.nses.Store(resp.Name, proto.Clone(resp))
+	return resp, nil
+}
+
+func (r *registrations) Find(query *registryapi.NetworkServiceEndpointQuery, s registryapi.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(s.Context()).Find(query, s)
+}
+
+func (r *registrations) Unregister(ctx context.Context, nse *registryapi.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	r.nses.Delete(nse.Name)
+	return resp, nil
+}
+
+func (r *registrations) list() []*registryapi.NetworkServiceEndpoint {
+	var rv []*registryapi.NetworkServiceEndpoint
+	r.nses.Range(func(_, value interface{}) bool {
+		rv = append(rv, proto.Clone(value.(*registryapi.NetworkServiceEndpoint)).(*registryapi.NetworkServiceEndpoint))
+		return true
+	})
+	return rv
+}
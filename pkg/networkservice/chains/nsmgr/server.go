@@ -29,6 +29,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/registry"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 
+	"github.com/networkservicemesh/sdk/pkg/registry/common/panicrecover"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/setid"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/seturl"
 	chain_registry "github.com/networkservicemesh/sdk/pkg/registry/core/chain"
@@ -37,13 +38,19 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/endpoint"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/admin"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/connect"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/localbypass"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/roundrobin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/selectforwarder"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
 	adapter_registry "github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/tools/addressof"
+	"github.com/networkservicemesh/sdk/pkg/tools/circuitbreaker"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcpool"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 )
 
@@ -52,11 +59,20 @@ type Nsmgr interface {
 	networkservice.NetworkServiceServer
 	networkservice.MonitorConnectionServer
 	registry.Registry
+	// Drain - stops accepting new Requests, unregisters the local endpoints and closes all the active connections.
+	//         Returns when all the connections are closed or ctx is done.
+	Drain(ctx context.Context) error
+	// RegisterAdmin - registers the admin.AdminServer with *grpc.Server s. Admin service has no authorization, so s
+	//                 should listen separately from the server passed to Register and be reachable only by the
+	//                 administrators.
+	RegisterAdmin(s *grpc.Server)
 }
 
 type nsmgrServer struct {
 	endpoint.Endpoint
 	registry.Registry
+	registrations *registrations
+	admin         admin.Server
 }
 
 // NewServer - Creates a new Nsmgr
@@ -66,9 +82,36 @@ type nsmgrServer struct {
 //           registryCC - client connection to reach the upstream registry, could be nil, in this case only in memory storage will be used.
 // 			 clientDialOptions -  a grpc.DialOption's to be passed to GRPC connections.
 func NewServer(ctx context.Context, nsmRegistration *registryapi.NetworkServiceEndpoint, authzServer networkservice.NetworkServiceServer, tokenGenerator token.GeneratorFunc, registryCC grpc.ClientConnInterface, clientDialOptions ...grpc.DialOption) Nsmgr {
-	rv := &nsmgrServer{}
+	return newServer(ctx, nil, nsmRegistration, authzServer, tokenGenerator, registryCC, clientDialOptions...)
+}
+
+// newServer - creates a new Nsmgr collecting the metrics into m, if it is not nil
+func newServer(ctx context.Context, m *Metrics, nsmRegistration *registryapi.NetworkServiceEndpoint, authzServer networkservice.NetworkServiceServer, tokenGenerator token.GeneratorFunc, registryCC grpc.ClientConnInterface, clientDialOptions ...grpc.DialOption) Nsmgr {
+	newEndpoint := endpoint.NewServer
+	newNSChain := chain_registry.NewNetworkServiceRegistryServer
+	newNSEChain := chain_registry.NewNetworkServiceEndpointRegistryServer
+	if m != nil {
+		newEndpoint = m.endpoint.NewServer
+		newNSChain = m.registry.NewNetworkServiceRegistryServer
+		newNSEChain = m.registry.NewNetworkServiceEndpointRegistryServer
+	}
+
+	rv := &nsmgrServer{
+		registrations: &registrations{},
+	}
 
 	var localbypassRegistryServer registryapi.NetworkServiceEndpointRegistryServer
+	var selectForwarderRegistryServer registryapi.NetworkServiceEndpointRegistryServer
+	// Circuit breakers are shared by connect and roundrobin, so the endpoints with the open circuit are skipped
+	breakers := circuitbreaker.New()
+	// Pool is shared by connect, selectforwarder and admin, so the forwarders are probed with the same connections and
+	// the cached downstream clients can be listed
+	pool := grpcpool.New(ctx, grpcpool.WithDialOptions(clientDialOptions...))
+
+	var ns networkservice.NetworkServiceServer = rv
+	rv.admin = admin.NewServer(&ns,
+		admin.WithEndpoints(rv.registrations.list),
+		admin.WithPool(pool))
 
 	nsRegistry := newRemoteNSServer(registryCC)
 	if nsRegistry == nil {
@@ -85,25 +128,35 @@ func NewServer(ctx context.Context, nsmRegistration *registryapi.NetworkServiceE
 	}
 
 	// Construct Endpoint
-	rv.Endpoint = endpoint.NewServer(
+	rv.Endpoint = newEndpoint(
+		ctx,
 		nsmRegistration.Name,
-		authzServer,
+		// Admin tracks the Requests/Closes right after the authorization, so the ones waiting in serialize are listed
+		chain.NewNetworkServiceServer(authzServer, rv.admin),
 		tokenGenerator,
 		discover.NewServer(adapter_registry.NetworkServiceServerToClient(nsRegistry), adapter_registry.NetworkServiceEndpointServerToClient(nseRegistry)),
-		roundrobin.NewServer(),
+		roundrobin.NewServer(roundrobin.WithCircuitBreakers(breakers)),
 		localbypass.NewServer(&localbypassRegistryServer),
+		selectforwarder.NewServer(ctx, &selectForwarderRegistryServer, selectforwarder.WithPool(pool)),
 		connect.NewServer(
 			ctx,
 			client.NewClientFactory(nsmRegistration.Name,
 				addressof.NetworkServiceClient(
 					adapters.NewServerToClient(rv)),
 				tokenGenerator),
-			clientDialOptions...),
+			connect.WithPool(pool),
+			connect.WithCircuitBreakers(breakers)),
 	)
 
-	nsChain := chain_registry.NewNetworkServiceRegistryServer(nsRegistry)
-	nseChain := chain_registry.NewNetworkServiceEndpointRegistryServer(
-		localbypassRegistryServer, // Store endpoint Id to EndpointURL for local access.
+	nsChain := newNSChain(
+		panicrecover.NewNetworkServiceRegistryServer(), // Convert panics to errors
+		nsRegistry,
+	)
+	nseChain := newNSEChain(
+		panicrecover.NewNetworkServiceEndpointRegistryServer(),              // Convert panics to errors
+		localbypassRegistryServer,                                           // Store endpoint Id to EndpointURL for local access.
+		selectForwarderRegistryServer,                                       // Store local forwarders to select them for the connections.
+		rv.registrations,                                                    // Store local endpoints to unregister them on drain.
 		seturl.NewNetworkServiceEndpointRegistryServer(nsmRegistration.Url), // Remember endpoint URL
 		nseRegistry, // Register NSE inside Remote registry with ID assigned
 	)
@@ -112,6 +165,17 @@ func NewServer(ctx context.Context, nsmRegistration *registryapi.NetworkServiceE
 	return rv
 }
 
+// Drain - stops accepting new Requests, unregisters the local endpoints and drains the Network Service Manager endpoint
+func (n *nsmgrServer) Drain(ctx context.Context) error {
+	n.Endpoint.Stop()
+	for _, nse := range n.registrations.list() {
+		if _, err := n.Registry.NetworkServiceEndpointRegistryServer().Unregister(ctx, nse); err != nil {
+			trace.Log(ctx).Errorf("Failed to unregister %s on drain: %+v", nse.Name, err)
+		}
+	}
+	return n.Endpoint.Drain(ctx)
+}
+
 func newRemoteNSServer(cc grpc.ClientConnInterface) registryapi.NetworkServiceRegistryServer {
 	if cc != nil {
 		return adapter_registry.NetworkServiceClientToServer(
@@ -137,6 +201,10 @@ func (n *nsmgrServer) Register(s *grpc.Server) {
 	registryapi.RegisterNetworkServiceEndpointRegistryServer(s, n.Registry.NetworkServiceEndpointRegistryServer())
 }
 
+func (n *nsmgrServer) RegisterAdmin(s *grpc.Server) {
+	admin.RegisterAdminServer(s, n.admin)
+}
+
 var _ Nsmgr = &nsmgrServer{}
 var _ endpoint.Endpoint = &nsmgrServer{}
 var _ registry.Registry = &nsmgrServer{}
@@ -19,6 +19,9 @@ package nsmgr_test
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"testing"
@@ -28,6 +31,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -35,12 +39,21 @@ import (
 	"google.golang.org/grpc/grpclog"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/endpoint"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/connect"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/selectforwarder"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/testnse"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/tools/chainmetrics"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 )
 
+func init() {
+	grpclog.SetLoggerV2(grpclog.NewLoggerV2(os.Stdout, os.Stdout, os.Stderr))
+}
+
 func TokenGenerator(peerAuthInfo credentials.AuthInfo) (token string, expireTime time.Time, err error) {
 	return "TestToken", time.Date(3000, 1, 1, 1, 1, 1, 1, time.UTC), nil
 }
@@ -63,8 +76,6 @@ func newClient(ctx context.Context, u *url.URL) (*grpc.ClientConn, error) {
 }
 
 func TestNSmgrEndpointCallback(t *testing.T) {
-	grpclog.SetLoggerV2(grpclog.NewLoggerV2(os.Stdout, os.Stdout, os.Stderr))
-
 	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Second)
 	defer cancel()
 
@@ -131,3 +142,203 @@ func TestNSmgrEndpointCallback(t *testing.T) {
 	require.NotNil(t, connection)
 	require.Equal(t, 2, len(connection.Path.PathSegments))
 }
+
+func TestNSmgrForwarder(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Serve endpoint
+	nseURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	_, _, nseErrChan := testnse.NewNSE(ctx, nseURL, func(request *networkservice.NetworkServiceRequest) {})
+	require.NotNil(t, nseErrChan)
+
+	// Serve NSMGR without the WaitForReady, so the unavailable forwarder fails fast
+	nsmgrReg := &registry.NetworkServiceEndpoint{
+		Name: "nsmgr",
+		Url:  "tcp://127.0.0.1:5001",
+	}
+	mgr := nsmgr.NewServer(ctx, nsmgrReg, authorize.NewServer(), TokenGenerator, nil, grpc.WithInsecure())
+	nsmURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	_, mgrGrpcCancel, _ := serverNSM(ctx, nsmURL, mgr)
+	defer mgrGrpcCancel()
+	nsmgrReg.Url = nsmURL.String()
+
+	// Serve forwarder
+	fwd := endpoint.NewServer(ctx, "forwarder", authorize.NewServer(), TokenGenerator,
+		selectforwarder.NewNextHopServer(),
+		connect.NewServer(ctx, client.NewClientFactory("forwarder", nil, TokenGenerator), connect.WithClientDialOptions(grpc.WithInsecure())))
+	fwdGrpcServer := grpc.NewServer()
+	fwd.Register(fwdGrpcServer)
+	fwdURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	_ = grpcutils.ListenAndServe(ctx, fwdURL, fwdGrpcServer)
+
+	// Register forwarders, one of them is not available
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	deadURL := grpcutils.AddressToURL(l.Addr())
+	require.Nil(t, l.Close())
+
+	for name, u := range map[string]*url.URL{"forwarder": fwdURL, "forwarder-dead": deadURL} {
+		_, err = mgr.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{
+			Name:                name,
+			NetworkServiceNames: []string{selectforwarder.NetworkServiceName},
+			Url:                 u.String(),
+		})
+		require.Nil(t, err)
+	}
+
+	// Register network service and endpoint
+	_, err = mgr.NetworkServiceRegistryServer().Register(ctx, &registry.NetworkService{
+		Name: "my-service",
+	})
+	require.Nil(t, err)
+	_, err = mgr.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"my-service"},
+		Url:                 nseURL.String(),
+	})
+	require.Nil(t, err)
+
+	nsmClient, err := newClient(ctx, nsmURL)
+	require.Nil(t, err)
+	cl := client.NewClient(ctx, "nsc-1", nil, TokenGenerator, nsmClient)
+
+	for i := 0; i < 2; i++ {
+		connection, err := cl.Request(ctx, &networkservice.NetworkServiceRequest{
+			MechanismPreferences: []*networkservice.Mechanism{
+				{Cls: cls.LOCAL, Type: kernel.MECHANISM},
+			},
+			Connection: &networkservice.Connection{
+				Id:             fmt.Sprintf("%d", i),
+				NetworkService: "my-service",
+				Context:        &networkservice.ConnectionContext{},
+			},
+		})
+		require.Nil(t, err)
+		var names []string
+		for _, segment := range connection.GetPath().GetPathSegments() {
+			names = append(names, segment.Name)
+		}
+		require.Contains(t, names, "forwarder")
+
+		_, err = cl.Close(ctx, connection)
+		require.Nil(t, err)
+	}
+}
+
+func TestNSmgrDrain(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Serve endpoint
+	nseURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	_, _, nseErrChan := testnse.NewNSE(ctx, nseURL, func(request *networkservice.NetworkServiceRequest) {})
+	require.NotNil(t, nseErrChan)
+
+	nsmgrReg := &registry.NetworkServiceEndpoint{
+		Name: "nsmgr",
+		Url:  "tcp://127.0.0.1:5001",
+	}
+	mgr := nsmgr.NewServer(ctx, nsmgrReg, authorize.NewServer(), TokenGenerator, nil, grpc.WithInsecure(), grpc.WithDefaultCallOptions(grpc.WaitForReady(true)))
+	nsmURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	_, mgrGrpcCancel, _ := serverNSM(ctx, nsmURL, mgr)
+	defer mgrGrpcCancel()
+	nsmgrReg.Url = nsmURL.String()
+
+	_, err := mgr.NetworkServiceRegistryServer().Register(ctx, &registry.NetworkService{
+		Name: "my-service",
+	})
+	require.Nil(t, err)
+	_, err = mgr.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"my-service"},
+		Url:                 nseURL.String(),
+	})
+	require.Nil(t, err)
+
+	nsmClient, err := newClient(ctx, nsmURL)
+	require.Nil(t, err)
+	cl := client.NewClient(ctx, "nsc-1", nil, TokenGenerator, nsmClient)
+
+	request := func(id string) (*networkservice.Connection, error) {
+		return cl.Request(ctx, &networkservice.NetworkServiceRequest{
+			MechanismPreferences: []*networkservice.Mechanism{
+				{Cls: cls.LOCAL, Type: kernel.MECHANISM},
+			},
+			Connection: &networkservice.Connection{
+				Id:             id,
+				NetworkService: "my-service",
+				Context:        &networkservice.ConnectionContext{},
+			},
+		})
+	}
+
+	_, err = request("1")
+	require.Nil(t, err)
+
+	require.Nil(t, mgr.Drain(ctx))
+
+	// Local endpoints are unregistered
+	stream, err := adapters.NetworkServiceEndpointServerToClient(mgr.NetworkServiceEndpointRegistryServer()).Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			NetworkServiceNames: []string{"my-service"},
+		},
+	})
+	require.Nil(t, err)
+	require.Empty(t, registry.ReadNetworkServiceEndpointList(stream))
+
+	// New Requests are rejected
+	_, err = request("2")
+	require.NotNil(t, err)
+}
+
+func TestNSmgrMetrics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	nseURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	_, _, nseErrChan := testnse.NewNSE(ctx, nseURL, func(request *networkservice.NetworkServiceRequest) {})
+	require.NotNil(t, nseErrChan)
+
+	nsmgrReg := &registry.NetworkServiceEndpoint{
+		Name: "nsmgr",
+		Url:  "tcp://127.0.0.1:5001",
+	}
+	metricsRegistry := prometheus.NewRegistry()
+	mgr := nsmgr.WithMetrics(metricsRegistry).NewServer(ctx, nsmgrReg, authorize.NewServer(), TokenGenerator, nil,
+		grpc.WithInsecure(), grpc.WithDefaultCallOptions(grpc.WaitForReady(true)))
+	nsmURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	_, mgrGrpcCancel, _ := serverNSM(ctx, nsmURL, mgr)
+	defer mgrGrpcCancel()
+	nsmgrReg.Url = nsmURL.String()
+
+	_, err := mgr.NetworkServiceRegistryServer().Register(ctx, &registry.NetworkService{
+		Name: "my-service",
+	})
+	require.Nil(t, err)
+	nseReg, err := mgr.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"my-service"},
+		Url:                 nseURL.String(),
+	})
+	require.Nil(t, err)
+
+	nsmClient, err := newClient(ctx, nsmURL)
+	require.Nil(t, err)
+	cl := client.NewClient(ctx, "nsc-1", nil, TokenGenerator, nsmClient)
+	_, err = cl.Request(ctx, &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Cls: cls.LOCAL, Type: kernel.MECHANISM},
+		},
+		Connection: &networkservice.Connection{
+			Id:             "1",
+			NetworkService: "my-service",
+			Context:        &networkservice.ConnectionContext{},
+		},
+	})
+	require.Nil(t, err)
+
+	w := httptest.NewRecorder()
+	chainmetrics.Handler(metricsRegistry).ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+	require.Contains(t, body, fmt.Sprintf(`nsm_active_connections{network_service="my-service",network_service_endpoint=%q} 1`, nseReg.Name))
+	require.Contains(t, body, `nsm_chain_element_duration_seconds_count{chain="server",element="connect.connectServer",method="Request"} 1`)
+	require.Contains(t, body, `nsm_chain_element_duration_seconds_count{chain="registry_nse_server",element="seturl.setMgrServer",method="Register"} 1`)
+}
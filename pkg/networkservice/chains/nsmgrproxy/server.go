@@ -0,0 +1,69 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nsmgrproxy provides a proxy Network Service Manager routing interdomain requests between domains
+package nsmgrproxy
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/endpoint"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/connect"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/interdomainurl"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/tools/addressof"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+type nsmgrProxyServer struct {
+	endpoint.Endpoint
+}
+
+// NewServer - creates a new proxy Network Service Manager. Network service endpoints of the other domains are
+//             discovered by the local nsmgr via the proxy registry (see chains/registry.NewProxyServer), the swap
+//             registry element sets their URLs to the proxy nsmgr and their names to "nse@remote-nsmgr-URL".
+//             Proxy nsmgr forwards requests for such endpoints to the remote nsmgr, if they are found in the proxy
+//             registry, all other requests (incoming from the other domains) are forwarded to the local nsmgr.
+//           name - name of the proxy nsmgr
+//           authzServer - authorization server chain element
+//           tokenGenerator - authorization token generator
+//           localNSMgrURL - URL of the local domain nsmgr
+//           proxyRegistryCC - client connection to the proxy registry, to check the remote nsmgr URLs
+//           clientDialOptions - a grpc.DialOption's to be passed to GRPC connections.
+func NewServer(ctx context.Context, name string, authzServer networkservice.NetworkServiceServer, tokenGenerator token.GeneratorFunc, localNSMgrURL *url.URL, proxyRegistryCC grpc.ClientConnInterface, clientDialOptions ...grpc.DialOption) endpoint.Endpoint {
+	rv := &nsmgrProxyServer{}
+	rv.Endpoint = endpoint.NewServer(
+		ctx,
+		name,
+		authzServer,
+		tokenGenerator,
+		interdomainurl.NewServer(localNSMgrURL, registry.NewNetworkServiceEndpointRegistryClient(proxyRegistryCC)),
+		connect.NewServer(
+			ctx,
+			client.NewClientFactory(name,
+				addressof.NetworkServiceClient(
+					adapters.NewServerToClient(rv)),
+				tokenGenerator),
+			connect.WithClientDialOptions(clientDialOptions...)),
+	)
+	return rv
+}
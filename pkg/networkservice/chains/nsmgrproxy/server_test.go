@@ -0,0 +1,225 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmgrproxy_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/endpoint"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgrproxy"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/registry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+func tokenGenerator(_ credentials.AuthInfo) (token string, expireTime time.Time, err error) {
+	return "TestToken", time.Date(3000, 1, 1, 1, 1, 1, 1, time.UTC), nil
+}
+
+type testResolver map[string]*url.URL
+
+func (r testResolver) LookupSRV(_ context.Context, service, proto, domain string) (cname string, srvs []*net.SRV, err error) {
+	u, ok := r[domain]
+	if !ok {
+		return "", nil, errors.Errorf("%s not found", domain)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("_%v._%v.%v", service, proto, domain), []*net.SRV{{Target: domain, Port: uint16(port)}}, nil
+}
+
+func (r testResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	u, ok := r[host]
+	if !ok {
+		return nil, errors.Errorf("%s not found", host)
+	}
+	return []net.IPAddr{{IP: net.ParseIP(u.Hostname())}}, nil
+}
+
+type listener struct {
+	net.Listener
+	url *url.URL
+}
+
+func listen(t *testing.T) *listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return &listener{
+		Listener: l,
+		url:      grpcutils.AddressToURL(l.Addr()),
+	}
+}
+
+func serve(ctx context.Context, l *listener, register func(s *grpc.Server)) {
+	s := grpc.NewServer()
+	register(s)
+	go func() {
+		_ = s.Serve(l)
+	}()
+	go func() {
+		<-ctx.Done()
+		s.Stop()
+	}()
+}
+
+func dial(ctx context.Context, t *testing.T, u *url.URL) *grpc.ClientConn {
+	dialCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+	cc, err := grpc.DialContext(dialCtx, grpcutils.URLToTarget(u), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	go func() {
+		<-ctx.Done()
+		_ = cc.Close()
+	}()
+	return cc
+}
+
+type closeNotifierServer struct {
+	closeCh chan *networkservice.Connection
+}
+
+func (s *closeNotifierServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *closeNotifierServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.closeCh <- conn
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+/*
+	TestNSMgrProxy covers the next scenario:
+		1. NSE registers "ns" in domain2
+		2. NSC from domain1 requests "ns@domain2"
+		3. nsmgr1 discovers "nse-1@<nsmgr2 URL>" via proxy registry, its URL is swapped to the nsmgr-proxy1
+		4. nsmgr-proxy1 routes the request to nsmgr2
+	Expected: connection is established, NSE receives Close on NSC Close
+	domain1                                          domain2
+	 _____________________________________           _____________________
+	|                                     |         |                     |
+	| NSC --> nsmgr1 --> nsmgr-proxy1     | ------> | nsmgr2 --> NSE      |
+	|            |                        |         |   |                 |
+	|        registry1 --> proxy registry | ------> | registry2           |
+	|_____________________________________|         |_____________________|
+*/
+func TestNSMgrProxy(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	dialOptions := []grpc.DialOption{grpc.WithInsecure(), grpc.WithDefaultCallOptions(grpc.WaitForReady(true))}
+	resolver := testResolver{}
+
+	// Domain 2
+	registry2Listener := listen(t)
+	registry2, err := registry.NewLocalServer(ctx)
+	require.NoError(t, err)
+	serve(ctx, registry2Listener, registry2.Register)
+	resolver["domain2"] = registry2Listener.url
+
+	nsmgr2Listener := listen(t)
+	nsmgr2 := nsmgr.NewServer(ctx, &registryapi.NetworkServiceEndpoint{Name: "nsmgr2", Url: nsmgr2Listener.url.String()},
+		authorize.NewServer(), tokenGenerator, dial(ctx, t, registry2Listener.url), dialOptions...)
+	serve(ctx, nsmgr2Listener, nsmgr2.Register)
+
+	closeNotifier := &closeNotifierServer{closeCh: make(chan *networkservice.Connection, 1)}
+	nseListener := listen(t)
+	nse := endpoint.NewServer(ctx, "nse-1", authorize.NewServer(), tokenGenerator, closeNotifier)
+	serve(ctx, nseListener, nse.Register)
+
+	_, err = nsmgr2.NetworkServiceRegistryServer().Register(ctx, &registryapi.NetworkService{Name: "ns"})
+	require.NoError(t, err)
+	_, err = nsmgr2.NetworkServiceEndpointRegistryServer().Register(ctx, &registryapi.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns"},
+		Url:                 nseListener.url.String(),
+	})
+	require.NoError(t, err)
+
+	// Domain 1
+	nsmgrProxy1Listener := listen(t)
+	proxyRegistry1Listener := listen(t)
+	proxyRegistry1 := registry.NewProxyServer("domain1", nsmgrProxy1Listener.url, nsmgrProxy1Listener.url,
+		registry.WithResolver(resolver),
+		registry.WithDialOptions(dialOptions...))
+	serve(ctx, proxyRegistry1Listener, proxyRegistry1.Register)
+
+	registry1Listener := listen(t)
+	registry1, err := registry.NewLocalServer(ctx,
+		registry.WithProxyRegistryURL(proxyRegistry1Listener.url),
+		registry.WithDialOptions(dialOptions...))
+	require.NoError(t, err)
+	serve(ctx, registry1Listener, registry1.Register)
+
+	nsmgr1Listener := listen(t)
+	nsmgr1 := nsmgr.NewServer(ctx, &registryapi.NetworkServiceEndpoint{Name: "nsmgr1", Url: nsmgr1Listener.url.String()},
+		authorize.NewServer(), tokenGenerator, dial(ctx, t, registry1Listener.url), dialOptions...)
+	serve(ctx, nsmgr1Listener, nsmgr1.Register)
+
+	nsmgrProxy1 := nsmgrproxy.NewServer(ctx, "nsmgr-proxy1", authorize.NewServer(), tokenGenerator, nsmgr1Listener.url,
+		dial(ctx, t, proxyRegistry1Listener.url), dialOptions...)
+	serve(ctx, nsmgrProxy1Listener, nsmgrProxy1.Register)
+
+	// NSC
+	nsc := client.NewClient(ctx, "nsc", nil, tokenGenerator, dial(ctx, t, nsmgr1Listener.url))
+
+	conn, err := nsc.Request(ctx, &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Cls: cls.LOCAL, Type: kernel.MECHANISM},
+		},
+		Connection: &networkservice.Connection{
+			Id:             "1",
+			NetworkService: "ns@domain2",
+			Context:        &networkservice.ConnectionContext{},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, conn)
+	require.Equal(t, "ns@domain2", conn.NetworkService)
+	require.Equal(t, "nse-1@"+nsmgr2Listener.url.String(), conn.NetworkServiceEndpointName)
+	// nsc, nsmgr1, nsmgr-proxy1, nsmgr2, nse-1
+	require.Len(t, conn.Path.PathSegments, 5)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+	select {
+	case closed := <-closeNotifier.closeCh:
+		require.Equal(t, "ns", closed.NetworkService)
+	case <-ctx.Done():
+		require.FailNow(t, "NSE has not received Close")
+	}
+}
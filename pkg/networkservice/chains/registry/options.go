@@ -0,0 +1,169 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/dnsresolve"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/replicate"
+)
+
+type configurable interface {
+	setStorage(registry.NetworkServiceRegistryServer, registry.NetworkServiceEndpointRegistryServer)
+	setPersistence(dir string)
+	setReplicas([]*url.URL, []replicate.Option)
+	setAuthorization(registry.NetworkServiceRegistryServer, registry.NetworkServiceEndpointRegistryServer)
+	setProxyRegistryURL(*url.URL)
+	setExpirePeriod(time.Duration)
+	setResolver(dnsresolve.Resolver)
+	setDialOptions([]grpc.DialOption)
+}
+
+// Option is registry chain configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithStorage sets specific storage elements, by default memory storage is used
+func WithStorage(nsStorage registry.NetworkServiceRegistryServer, nseStorage registry.NetworkServiceEndpointRegistryServer) Option {
+	return applierFunc(func(c configurable) {
+		c.setStorage(nsStorage, nseStorage)
+	})
+}
+
+// WithPersistence sets persistent storage keeping its log files in the dir
+func WithPersistence(dir string) Option {
+	return applierFunc(func(c configurable) {
+		c.setPersistence(dir)
+	})
+}
+
+// WithReplicas sets URLs of the other registries in the cluster to replicate registrations to. The replicated calls
+// from them are rejected unless a peer authorizer is set with WithReplicasOptions.
+func WithReplicas(replicas ...*url.URL) Option {
+	return applierFunc(func(c configurable) {
+		c.setReplicas(replicas, nil)
+	})
+}
+
+// WithReplicasOptions sets URLs of the other registries in the cluster to replicate registrations to and the
+// replication options, e.g. replicate.WithPeerAuthorizer to accept the replicated calls from them
+func WithReplicasOptions(replicas []*url.URL, options ...replicate.Option) Option {
+	return applierFunc(func(c configurable) {
+		c.setReplicas(replicas, options)
+	})
+}
+
+// WithAuthorization sets elements authorizing the incoming calls, they are called first in the chains
+func WithAuthorization(nsAuthorization registry.NetworkServiceRegistryServer, nseAuthorization registry.NetworkServiceEndpointRegistryServer) Option {
+	return applierFunc(func(c configurable) {
+		c.setAuthorization(nsAuthorization, nseAuthorization)
+	})
+}
+
+// WithProxyRegistryURL sets URL of the proxy registry to forward interdomain calls to
+func WithProxyRegistryURL(u *url.URL) Option {
+	return applierFunc(func(c configurable) {
+		c.setProxyRegistryURL(u)
+	})
+}
+
+// WithExpirePeriod sets period of checking Network service endpoints expiration
+func WithExpirePeriod(period time.Duration) Option {
+	return applierFunc(func(c configurable) {
+		c.setExpirePeriod(period)
+	})
+}
+
+// WithResolver sets DNS resolver for the proxy registry, by default net.DefaultResolver is used
+func WithResolver(r dnsresolve.Resolver) Option {
+	return applierFunc(func(c configurable) {
+		c.setResolver(r)
+	})
+}
+
+// WithDialOptions sets gRPC dial options for the connections to the other registries
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return applierFunc(func(c configurable) {
+		c.setDialOptions(dialOptions)
+	})
+}
+
+type config struct {
+	nsStorage        registry.NetworkServiceRegistryServer
+	nseStorage       registry.NetworkServiceEndpointRegistryServer
+	persistenceDir   string
+	replicas         []*url.URL
+	replicateOptions []replicate.Option
+	nsAuthorization  registry.NetworkServiceRegistryServer
+	nseAuthorization registry.NetworkServiceEndpointRegistryServer
+	proxyRegistryURL *url.URL
+	expirePeriod     time.Duration
+	resolver         dnsresolve.Resolver
+	dialOptions      []grpc.DialOption
+}
+
+func (c *config) setStorage(nsStorage registry.NetworkServiceRegistryServer, nseStorage registry.NetworkServiceEndpointRegistryServer) {
+	c.nsStorage, c.nseStorage = nsStorage, nseStorage
+}
+
+func (c *config) setPersistence(dir string) {
+	c.persistenceDir = dir
+}
+
+func (c *config) setReplicas(replicas []*url.URL, options []replicate.Option) {
+	c.replicas, c.replicateOptions = replicas, options
+}
+
+func (c *config) setAuthorization(nsAuthorization registry.NetworkServiceRegistryServer, nseAuthorization registry.NetworkServiceEndpointRegistryServer) {
+	c.nsAuthorization, c.nseAuthorization = nsAuthorization, nseAuthorization
+}
+
+func (c *config) setProxyRegistryURL(u *url.URL) {
+	c.proxyRegistryURL = u
+}
+
+func (c *config) setExpirePeriod(period time.Duration) {
+	c.expirePeriod = period
+}
+
+func (c *config) setResolver(r dnsresolve.Resolver) {
+	c.resolver = r
+}
+
+func (c *config) setDialOptions(dialOptions []grpc.DialOption) {
+	c.dialOptions = dialOptions
+}
+
+func newConfig(options []Option) *config {
+	c := new(config)
+	for _, o := range options {
+		o.apply(c)
+	}
+	return c
+}
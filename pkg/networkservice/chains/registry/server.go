@@ -18,12 +18,32 @@
 package registry
 
 import (
+	"context"
+	"net/url"
+	"path/filepath"
+
 	"github.com/networkservicemesh/api/pkg/api/registry"
 	"google.golang.org/grpc"
 
+	"github.com/networkservicemesh/sdk/pkg/registry/common/connect"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/dnsresolve"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/proxy"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/replicate"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/setid"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/swap"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/persistent"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 )
 
+const (
+	nsLogFile  = "ns.log"
+	nseLogFile = "nse.log"
+)
+
 // Registry - aggregates the APIs:
 //            - registry.NetworkServiceRegistryServer
 //            - registry.NetworkServiceEndpointRegistryServer
@@ -61,3 +81,124 @@ func NewServer(nsChain registry.NetworkServiceRegistryServer, nseChain registry.
 		nsChain:  nsChain,
 	}
 }
+
+// NewLocalServer creates new local domain Registry:
+//
+//	NSE chain: [authorization] -> setid -> [replicate] -> expire -> storage -> proxy -> connect
+//	NS chain:  [authorization] -> [replicate] -> expire -> storage -> proxy -> connect
+//
+// Interdomain calls are forwarded by proxy and connect to the proxy registry set with WithProxyRegistryURL.
+// Storage is memory by default, persistent one with WithPersistence or any other with WithStorage.
+func NewLocalServer(ctx context.Context, options ...Option) (Registry, error) {
+	c := newConfig(options)
+
+	nsStorage, nseStorage, err := c.storage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var expireOptions []expire.Option
+	if c.expirePeriod > 0 {
+		expireOptions = append(expireOptions, expire.WithPeriod(c.expirePeriod))
+	}
+	nsStorage = expire.NewNetworkServiceServer(nsStorage, adapters.NetworkServiceEndpointServerToClient(nseStorage), expireOptions...)
+	nseStorage = expire.NewNetworkServiceEndpointRegistryServer(ctx, nseStorage, expireOptions...)
+
+	if len(c.replicas) > 0 {
+		replicateOptions := append([]replicate.Option{replicate.WithDialOptions(c.dialOptions...)}, c.replicateOptions...)
+		if nsStorage, err = replicate.NewNetworkServiceRegistryServer(ctx, nsStorage, c.replicas, replicateOptions...); err != nil {
+			return nil, err
+		}
+		if nseStorage, err = replicate.NewNetworkServiceEndpointRegistryServer(ctx, nseStorage, c.replicas, replicateOptions...); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewServer(
+		c.nsChain(
+			nsStorage,
+			proxy.NewNetworkServiceRegistryServer(c.proxyRegistryURL),
+			c.nsConnect(),
+		),
+		c.nseChain(
+			setid.NewNetworkServiceEndpointRegistryServer(),
+			nseStorage,
+			proxy.NewNetworkServiceEndpointRegistryServer(c.proxyRegistryURL),
+			c.nseConnect(),
+		),
+	), nil
+}
+
+// NewProxyServer creates new interdomain proxy Registry forwarding calls to the registries of the other domains:
+//
+//	NSE chain: [authorization] -> dnsresolve -> swap -> connect
+//	NS chain:  [authorization] -> dnsresolve -> swap -> connect
+//
+// domain is the current domain name, proxyNSMgrURL and publicNSMgrURL are URLs of the proxy NSMgr used by swap.
+func NewProxyServer(domain string, proxyNSMgrURL, publicNSMgrURL *url.URL, options ...Option) Registry {
+	c := newConfig(options)
+
+	var resolveOptions []dnsresolve.Option
+	if c.resolver != nil {
+		resolveOptions = append(resolveOptions, dnsresolve.WithResolver(c.resolver))
+	}
+
+	return NewServer(
+		c.nsChain(
+			dnsresolve.NewNetworkServiceRegistryServer(resolveOptions...),
+			swap.NewNetworkServiceRegistryServer(domain),
+			c.nsConnect(),
+		),
+		c.nseChain(
+			dnsresolve.NewNetworkServiceEndpointRegistryServer(resolveOptions...),
+			swap.NewNetworkServiceEndpointRegistryServer(domain, proxyNSMgrURL, publicNSMgrURL),
+			c.nseConnect(),
+		),
+	)
+}
+
+func (c *config) storage(ctx context.Context) (registry.NetworkServiceRegistryServer, registry.NetworkServiceEndpointRegistryServer, error) {
+	nsStorage, nseStorage := c.nsStorage, c.nseStorage
+	var err error
+	if nsStorage == nil {
+		if c.persistenceDir == "" {
+			nsStorage = memory.NewNetworkServiceRegistryServer()
+		} else if nsStorage, err = persistent.NewNetworkServiceRegistryServer(ctx, filepath.Join(c.persistenceDir, nsLogFile)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if nseStorage == nil {
+		if c.persistenceDir == "" {
+			nseStorage = memory.NewNetworkServiceEndpointRegistryServer()
+		} else if nseStorage, err = persistent.NewNetworkServiceEndpointRegistryServer(ctx, filepath.Join(c.persistenceDir, nseLogFile)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return nsStorage, nseStorage, nil
+}
+
+func (c *config) nsChain(servers ...registry.NetworkServiceRegistryServer) registry.NetworkServiceRegistryServer {
+	if c.nsAuthorization != nil {
+		servers = append([]registry.NetworkServiceRegistryServer{c.nsAuthorization}, servers...)
+	}
+	return chain.NewNetworkServiceRegistryServer(servers...)
+}
+
+func (c *config) nseChain(servers ...registry.NetworkServiceEndpointRegistryServer) registry.NetworkServiceEndpointRegistryServer {
+	if c.nseAuthorization != nil {
+		servers = append([]registry.NetworkServiceEndpointRegistryServer{c.nseAuthorization}, servers...)
+	}
+	return chain.NewNetworkServiceEndpointRegistryServer(servers...)
+}
+
+func (c *config) nsConnect() registry.NetworkServiceRegistryServer {
+	return connect.NewNetworkServiceRegistryServer(func(_ context.Context, cc grpc.ClientConnInterface) registry.NetworkServiceRegistryClient {
+		return registry.NewNetworkServiceRegistryClient(cc)
+	}, connect.WithClientDialOptions(c.dialOptions...))
+}
+
+func (c *config) nseConnect() registry.NetworkServiceEndpointRegistryServer {
+	return connect.NewNetworkServiceEndpointRegistryServer(func(_ context.Context, cc grpc.ClientConnInterface) registry.NetworkServiceEndpointRegistryClient {
+		return registry.NewNetworkServiceEndpointRegistryClient(cc)
+	}, connect.WithClientDialOptions(c.dialOptions...))
+}
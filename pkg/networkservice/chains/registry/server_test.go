@@ -0,0 +1,172 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	registrychain "github.com/networkservicemesh/sdk/pkg/networkservice/chains/registry"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+type testResolver map[string]*url.URL
+
+func (r testResolver) LookupSRV(_ context.Context, service, proto, domain string) (cname string, srvs []*net.SRV, err error) {
+	u, ok := r[domain]
+	if !ok {
+		return "", nil, errors.Errorf("%s not found", domain)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("_%v._%v.%v", service, proto, domain), []*net.SRV{{Target: domain, Port: uint16(port)}}, nil
+}
+
+func (r testResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	u, ok := r[host]
+	if !ok {
+		return nil, errors.Errorf("%s not found", host)
+	}
+	return []net.IPAddr{{IP: net.ParseIP(u.Hostname())}}, nil
+}
+
+func serve(ctx context.Context, t *testing.T, r registrychain.Registry) *url.URL {
+	s := grpc.NewServer()
+	r.Register(s)
+	u := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	require.Len(t, grpcutils.ListenAndServe(ctx, u, s), 0)
+	go func() {
+		<-ctx.Done()
+		s.Stop()
+	}()
+	return u
+}
+
+func dial(ctx context.Context, t *testing.T, u *url.URL) registry.NetworkServiceEndpointRegistryClient {
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(u), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	go func() {
+		<-ctx.Done()
+		_ = cc.Close()
+	}()
+	return registry.NewNetworkServiceEndpointRegistryClient(cc)
+}
+
+func TestNewServer_RegisterFindExpire(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, err := registrychain.NewLocalServer(ctx, registrychain.WithExpirePeriod(time.Millisecond*50))
+	require.NoError(t, err)
+	client := dial(ctx, t, serve(ctx, t, r))
+
+	expiration := time.Now().Add(time.Millisecond * 200)
+	nse, err := client.Register(ctx, &registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      &timestamp.Timestamp{Seconds: expiration.Unix(), Nanos: int32(expiration.Nanosecond())},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, nse.Name)
+
+	find := func() []*registry.NetworkServiceEndpoint {
+		stream, err := client.Find(ctx, &registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: nse.Name},
+		})
+		require.NoError(t, err)
+		return registry.ReadNetworkServiceEndpointList(stream)
+	}
+	require.Len(t, find(), 1)
+	require.Eventually(t, func() bool {
+		return len(find()) == 0
+	}, time.Second, time.Millisecond*50)
+}
+
+func TestNewServer_Persistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r, err := registrychain.NewLocalServer(ctx, registrychain.WithPersistence(dir))
+	require.NoError(t, err)
+	_, err = r.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	cancel()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	r, err = registrychain.NewLocalServer(ctx, registrychain.WithPersistence(dir))
+	require.NoError(t, err)
+	client := dial(ctx, t, serve(ctx, t, r))
+
+	stream, err := client.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse-1"},
+	})
+	require.NoError(t, err)
+	require.Len(t, registry.ReadNetworkServiceEndpointList(stream), 1)
+}
+
+func TestNewServer_Interdomain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const localDomain, remoteDomain = "domain1", "domain2"
+	resolver := testResolver{}
+
+	remote, err := registrychain.NewLocalServer(ctx)
+	require.NoError(t, err)
+	resolver[remoteDomain] = serve(ctx, t, remote)
+	_, err = remote.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+		Url:  "tcp://nsmgr.domain2",
+	})
+	require.NoError(t, err)
+
+	proxyRegistry := registrychain.NewProxyServer(localDomain,
+		&url.URL{Scheme: "tcp", Host: "proxy.nsmgr.domain1"},
+		&url.URL{Scheme: "tcp", Host: "public.nsmgr.domain1"},
+		registrychain.WithResolver(resolver),
+		registrychain.WithDialOptions(grpc.WithInsecure()))
+	local, err := registrychain.NewLocalServer(ctx,
+		registrychain.WithProxyRegistryURL(serve(ctx, t, proxyRegistry)),
+		registrychain.WithDialOptions(grpc.WithInsecure()))
+	require.NoError(t, err)
+	client := dial(ctx, t, serve(ctx, t, local))
+
+	stream, err := client.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse-1@" + remoteDomain},
+	})
+	require.NoError(t, err)
+	list := registry.ReadNetworkServiceEndpointList(stream)
+	require.Len(t, list, 1)
+	require.Equal(t, "nse-1@tcp://nsmgr.domain2", list[0].Name)
+}
@@ -0,0 +1,675 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: admin.proto
+
+package admin
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	networkservice "github.com/networkservicemesh/api/pkg/api/networkservice"
+	registry "github.com/networkservicemesh/api/pkg/api/registry"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Active connection
+type ConnectionInfo struct {
+	Connection *networkservice.Connection `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
+	// Chain element currently processing the Request/Close, empty if there is no Request/Close in progress
+	ChainElement string `protobuf:"bytes,2,opt,name=chain_element,json=chainElement,proto3" json:"chain_element,omitempty"`
+	// Time the chain element has been entered
+	ChainElementSince    *timestamp.Timestamp `protobuf:"bytes,3,opt,name=chain_element_since,json=chainElementSince,proto3" json:"chain_element_since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ConnectionInfo) Reset()         { *m = ConnectionInfo{} }
+func (m *ConnectionInfo) String() string { return proto.CompactTextString(m) }
+func (*ConnectionInfo) ProtoMessage()    {}
+func (*ConnectionInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{0}
+}
+
+func (m *ConnectionInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectionInfo.Unmarshal(m, b)
+}
+func (m *ConnectionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectionInfo.Marshal(b, m, deterministic)
+}
+func (m *ConnectionInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionInfo.Merge(m, src)
+}
+func (m *ConnectionInfo) XXX_Size() int {
+	return xxx_messageInfo_ConnectionInfo.Size(m)
+}
+func (m *ConnectionInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectionInfo proto.InternalMessageInfo
+
+func (m *ConnectionInfo) GetConnection() *networkservice.Connection {
+	if m != nil {
+		return m.Connection
+	}
+	return nil
+}
+
+func (m *ConnectionInfo) GetChainElement() string {
+	if m != nil {
+		return m.ChainElement
+	}
+	return ""
+}
+
+func (m *ConnectionInfo) GetChainElementSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.ChainElementSince
+	}
+	return nil
+}
+
+type ConnectionInfoList struct {
+	Connections          []*ConnectionInfo `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ConnectionInfoList) Reset()         { *m = ConnectionInfoList{} }
+func (m *ConnectionInfoList) String() string { return proto.CompactTextString(m) }
+func (*ConnectionInfoList) ProtoMessage()    {}
+func (*ConnectionInfoList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{1}
+}
+
+func (m *ConnectionInfoList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectionInfoList.Unmarshal(m, b)
+}
+func (m *ConnectionInfoList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectionInfoList.Marshal(b, m, deterministic)
+}
+func (m *ConnectionInfoList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionInfoList.Merge(m, src)
+}
+func (m *ConnectionInfoList) XXX_Size() int {
+	return xxx_messageInfo_ConnectionInfoList.Size(m)
+}
+func (m *ConnectionInfoList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionInfoList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectionInfoList proto.InternalMessageInfo
+
+func (m *ConnectionInfoList) GetConnections() []*ConnectionInfo {
+	if m != nil {
+		return m.Connections
+	}
+	return nil
+}
+
+type EndpointList struct {
+	Endpoints            []*registry.NetworkServiceEndpoint `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *EndpointList) Reset()         { *m = EndpointList{} }
+func (m *EndpointList) String() string { return proto.CompactTextString(m) }
+func (*EndpointList) ProtoMessage()    {}
+func (*EndpointList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{2}
+}
+
+func (m *EndpointList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndpointList.Unmarshal(m, b)
+}
+func (m *EndpointList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndpointList.Marshal(b, m, deterministic)
+}
+func (m *EndpointList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndpointList.Merge(m, src)
+}
+func (m *EndpointList) XXX_Size() int {
+	return xxx_messageInfo_EndpointList.Size(m)
+}
+func (m *EndpointList) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndpointList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndpointList proto.InternalMessageInfo
+
+func (m *EndpointList) GetEndpoints() []*registry.NetworkServiceEndpoint {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+// Cached downstream client
+type ClientInfo struct {
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// gRPC connectivity state of the client connection
+	State string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// Number of the connections using the client
+	Refs int32 `protobuf:"varint,3,opt,name=refs,proto3" json:"refs,omitempty"`
+	// Time the client has become idle, not set if the client is in use
+	IdleSince            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=idle_since,json=idleSince,proto3" json:"idle_since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ClientInfo) Reset()         { *m = ClientInfo{} }
+func (m *ClientInfo) String() string { return proto.CompactTextString(m) }
+func (*ClientInfo) ProtoMessage()    {}
+func (*ClientInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{3}
+}
+
+func (m *ClientInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientInfo.Unmarshal(m, b)
+}
+func (m *ClientInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientInfo.Marshal(b, m, deterministic)
+}
+func (m *ClientInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientInfo.Merge(m, src)
+}
+func (m *ClientInfo) XXX_Size() int {
+	return xxx_messageInfo_ClientInfo.Size(m)
+}
+func (m *ClientInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientInfo proto.InternalMessageInfo
+
+func (m *ClientInfo) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *ClientInfo) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ClientInfo) GetRefs() int32 {
+	if m != nil {
+		return m.Refs
+	}
+	return 0
+}
+
+func (m *ClientInfo) GetIdleSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.IdleSince
+	}
+	return nil
+}
+
+type ClientInfoList struct {
+	Clients              []*ClientInfo `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ClientInfoList) Reset()         { *m = ClientInfoList{} }
+func (m *ClientInfoList) String() string { return proto.CompactTextString(m) }
+func (*ClientInfoList) ProtoMessage()    {}
+func (*ClientInfoList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{4}
+}
+
+func (m *ClientInfoList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientInfoList.Unmarshal(m, b)
+}
+func (m *ClientInfoList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientInfoList.Marshal(b, m, deterministic)
+}
+func (m *ClientInfoList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientInfoList.Merge(m, src)
+}
+func (m *ClientInfoList) XXX_Size() int {
+	return xxx_messageInfo_ClientInfoList.Size(m)
+}
+func (m *ClientInfoList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientInfoList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientInfoList proto.InternalMessageInfo
+
+func (m *ClientInfoList) GetClients() []*ClientInfo {
+	if m != nil {
+		return m.Clients
+	}
+	return nil
+}
+
+// Connection expiration timer
+type TimerInfo struct {
+	ConnectionId         string               `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Expires              *timestamp.Timestamp `protobuf:"bytes,2,opt,name=expires,proto3" json:"expires,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TimerInfo) Reset()         { *m = TimerInfo{} }
+func (m *TimerInfo) String() string { return proto.CompactTextString(m) }
+func (*TimerInfo) ProtoMessage()    {}
+func (*TimerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{5}
+}
+
+func (m *TimerInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimerInfo.Unmarshal(m, b)
+}
+func (m *TimerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimerInfo.Marshal(b, m, deterministic)
+}
+func (m *TimerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimerInfo.Merge(m, src)
+}
+func (m *TimerInfo) XXX_Size() int {
+	return xxx_messageInfo_TimerInfo.Size(m)
+}
+func (m *TimerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimerInfo proto.InternalMessageInfo
+
+func (m *TimerInfo) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
+	}
+	return ""
+}
+
+func (m *TimerInfo) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+type TimerInfoList struct {
+	Timers               []*TimerInfo `protobuf:"bytes,1,rep,name=timers,proto3" json:"timers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *TimerInfoList) Reset()         { *m = TimerInfoList{} }
+func (m *TimerInfoList) String() string { return proto.CompactTextString(m) }
+func (*TimerInfoList) ProtoMessage()    {}
+func (*TimerInfoList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{6}
+}
+
+func (m *TimerInfoList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimerInfoList.Unmarshal(m, b)
+}
+func (m *TimerInfoList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimerInfoList.Marshal(b, m, deterministic)
+}
+func (m *TimerInfoList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimerInfoList.Merge(m, src)
+}
+func (m *TimerInfoList) XXX_Size() int {
+	return xxx_messageInfo_TimerInfoList.Size(m)
+}
+func (m *TimerInfoList) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimerInfoList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimerInfoList proto.InternalMessageInfo
+
+func (m *TimerInfoList) GetTimers() []*TimerInfo {
+	if m != nil {
+		return m.Timers
+	}
+	return nil
+}
+
+type CloseConnectionRequest struct {
+	ConnectionId         string   `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseConnectionRequest) Reset()         { *m = CloseConnectionRequest{} }
+func (m *CloseConnectionRequest) String() string { return proto.CompactTextString(m) }
+func (*CloseConnectionRequest) ProtoMessage()    {}
+func (*CloseConnectionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{7}
+}
+
+func (m *CloseConnectionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseConnectionRequest.Unmarshal(m, b)
+}
+func (m *CloseConnectionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseConnectionRequest.Marshal(b, m, deterministic)
+}
+func (m *CloseConnectionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseConnectionRequest.Merge(m, src)
+}
+func (m *CloseConnectionRequest) XXX_Size() int {
+	return xxx_messageInfo_CloseConnectionRequest.Size(m)
+}
+func (m *CloseConnectionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseConnectionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloseConnectionRequest proto.InternalMessageInfo
+
+func (m *CloseConnectionRequest) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ConnectionInfo)(nil), "admin.ConnectionInfo")
+	proto.RegisterType((*ConnectionInfoList)(nil), "admin.ConnectionInfoList")
+	proto.RegisterType((*EndpointList)(nil), "admin.EndpointList")
+	proto.RegisterType((*ClientInfo)(nil), "admin.ClientInfo")
+	proto.RegisterType((*ClientInfoList)(nil), "admin.ClientInfoList")
+	proto.RegisterType((*TimerInfo)(nil), "admin.TimerInfo")
+	proto.RegisterType((*TimerInfoList)(nil), "admin.TimerInfoList")
+	proto.RegisterType((*CloseConnectionRequest)(nil), "admin.CloseConnectionRequest")
+}
+
+func init() {
+	proto.RegisterFile("admin.proto", fileDescriptor_73a7fc70dcc2027c)
+}
+
+var fileDescriptor_73a7fc70dcc2027c = []byte{
+	// 520 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x5d, 0x6b, 0x13, 0x41,
+	0x14, 0x65, 0x93, 0xa6, 0x25, 0x37, 0x1f, 0x4d, 0xa7, 0x6d, 0x58, 0x03, 0xc2, 0xb2, 0xbe, 0x04,
+	0x84, 0x15, 0xa2, 0x28, 0x95, 0x56, 0x90, 0x18, 0xb0, 0xa2, 0x7d, 0xd8, 0xfa, 0x1e, 0xd2, 0xdd,
+	0x9b, 0x38, 0xb8, 0x3b, 0xb3, 0xce, 0x4c, 0xd4, 0x3c, 0xfb, 0xab, 0xfc, 0x15, 0xfe, 0x25, 0x99,
+	0xd9, 0xd9, 0x8f, 0xb4, 0x0d, 0xed, 0x4b, 0x76, 0xee, 0xdc, 0x73, 0xcf, 0x3d, 0xf7, 0xcc, 0x0d,
+	0x74, 0x16, 0x71, 0x4a, 0x59, 0x90, 0x09, 0xae, 0x38, 0x69, 0x99, 0x60, 0x34, 0x88, 0x38, 0x63,
+	0x18, 0x29, 0xca, 0x6d, 0x62, 0xd4, 0x17, 0xb8, 0xa2, 0x52, 0x89, 0x8d, 0x8d, 0xdd, 0x4c, 0x6d,
+	0x32, 0x94, 0x2f, 0x30, 0xcd, 0xd4, 0x26, 0xff, 0xb5, 0x19, 0xcf, 0x66, 0x14, 0x4d, 0x51, 0xaa,
+	0x45, 0x9a, 0x55, 0xa7, 0x1c, 0xe1, 0xff, 0x75, 0xa0, 0x3f, 0x2d, 0x1b, 0x5c, 0xb2, 0x25, 0x27,
+	0xaf, 0x01, 0xaa, 0x96, 0xae, 0xe3, 0x39, 0xe3, 0xce, 0x64, 0x18, 0xd4, 0x54, 0x54, 0xf8, 0xb0,
+	0x86, 0x24, 0xcf, 0xa0, 0x17, 0x7d, 0x5b, 0x50, 0x36, 0xc7, 0x04, 0x53, 0x64, 0xca, 0x6d, 0x78,
+	0xce, 0xb8, 0x1d, 0x76, 0xcd, 0xe5, 0x2c, 0xbf, 0x23, 0x9f, 0xe0, 0x78, 0x0b, 0x34, 0x97, 0x94,
+	0x45, 0xe8, 0x36, 0x4d, 0x97, 0x51, 0xb0, 0xe2, 0x7c, 0x95, 0x60, 0xae, 0xed, 0x66, 0xbd, 0x0c,
+	0xbe, 0x16, 0x72, 0xc3, 0xa3, 0x3a, 0xcd, 0xb5, 0x2e, 0xf2, 0xbf, 0x00, 0xd9, 0x96, 0xfe, 0x99,
+	0x4a, 0x45, 0xde, 0x40, 0xa7, 0x12, 0x25, 0x5d, 0xc7, 0x6b, 0x8e, 0x3b, 0x93, 0xd3, 0x20, 0x77,
+	0x76, 0x1b, 0x1f, 0xd6, 0x91, 0xfe, 0x15, 0x74, 0x67, 0x2c, 0xce, 0x38, 0x65, 0xca, 0x10, 0xbd,
+	0x83, 0x36, 0xda, 0xb8, 0xa0, 0xf1, 0x82, 0xd2, 0xfa, 0x2b, 0x54, 0xbf, 0xb8, 0xf8, 0x7e, 0x8d,
+	0xe2, 0x27, 0x8d, 0xb0, 0x28, 0x0c, 0xab, 0x12, 0xff, 0x8f, 0x03, 0x30, 0x4d, 0x28, 0x32, 0x65,
+	0x6c, 0x1d, 0x40, 0x73, 0x2d, 0x12, 0xe3, 0x67, 0x3b, 0xd4, 0x47, 0x72, 0x02, 0x2d, 0xa9, 0x16,
+	0x0a, 0xad, 0x51, 0x79, 0x40, 0x08, 0xec, 0x09, 0x5c, 0x4a, 0x63, 0x49, 0x2b, 0x34, 0x67, 0x72,
+	0x06, 0x40, 0xe3, 0x04, 0xad, 0x59, 0x7b, 0x0f, 0x9a, 0xd5, 0xd6, 0xe8, 0xdc, 0xa4, 0x0b, 0xe8,
+	0x57, 0x22, 0xcc, 0x5c, 0xcf, 0xe1, 0x20, 0x32, 0x37, 0xc5, 0x54, 0x47, 0x85, 0x39, 0x25, 0x2e,
+	0x2c, 0x10, 0xfe, 0x12, 0xda, 0x9a, 0x56, 0x98, 0x11, 0xf4, 0x0b, 0x97, 0x86, 0xcd, 0x69, 0x6c,
+	0x87, 0xe9, 0x56, 0x97, 0x97, 0x31, 0x79, 0x05, 0x07, 0xf8, 0x3b, 0xa3, 0x02, 0xa5, 0xdb, 0x78,
+	0x50, 0x68, 0x01, 0xf5, 0xcf, 0xa0, 0x57, 0xf6, 0x31, 0x2a, 0xc7, 0xb0, 0xaf, 0x77, 0x55, 0x14,
+	0x22, 0x07, 0x56, 0x64, 0x89, 0x0a, 0x6d, 0xde, 0xbf, 0x80, 0xe1, 0x34, 0xe1, 0x12, 0x6b, 0x6b,
+	0x89, 0x3f, 0xd6, 0x28, 0xd5, 0xa3, 0xf4, 0x4e, 0xfe, 0x35, 0xa0, 0xf5, 0x5e, 0x53, 0x93, 0x0f,
+	0x70, 0xa8, 0x5b, 0x57, 0x3c, 0x92, 0x0c, 0xef, 0x68, 0x9f, 0xe9, 0xbf, 0xd7, 0xe8, 0xc9, 0xbd,
+	0xfb, 0x64, 0x84, 0x9f, 0x43, 0x4f, 0x7f, 0x8b, 0x8d, 0xd8, 0xcd, 0x71, 0x6c, 0x39, 0xb6, 0x96,
+	0xee, 0x1c, 0x3a, 0x46, 0x43, 0x6e, 0xff, 0xce, 0xda, 0xd3, 0x3b, 0x4f, 0x66, 0xaa, 0xdf, 0x02,
+	0xe8, 0xaf, 0xf1, 0x68, 0x77, 0xf1, 0xc9, 0x6d, 0x2b, 0x4d, 0xed, 0x47, 0x38, 0xbc, 0x65, 0x23,
+	0x79, 0x5a, 0x76, 0xb9, 0xcf, 0xde, 0xd1, 0x0e, 0xfe, 0x9b, 0x7d, 0x13, 0xbf, 0xfc, 0x3f, 0x00,
+	0xbd, 0xc3, 0xf0, 0xbf, 0xce, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminClient interface {
+	ListConnections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ConnectionInfoList, error)
+	ListEndpoints(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*EndpointList, error)
+	ListClients(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ClientInfoList, error)
+	ListTimers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*TimerInfoList, error)
+	// Closes the connection with the given ID going through the whole Network Service Manager chain
+	CloseConnection(ctx context.Context, in *CloseConnectionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) ListConnections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ConnectionInfoList, error) {
+	out := new(ConnectionInfoList)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListConnections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListEndpoints(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*EndpointList, error) {
+	out := new(EndpointList)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListEndpoints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListClients(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ClientInfoList, error) {
+	out := new(ClientInfoList)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListTimers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*TimerInfoList, error) {
+	out := new(TimerInfoList)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListTimers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CloseConnection(ctx context.Context, in *CloseConnectionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/admin.Admin/CloseConnection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+type AdminServer interface {
+	ListConnections(context.Context, *empty.Empty) (*ConnectionInfoList, error)
+	ListEndpoints(context.Context, *empty.Empty) (*EndpointList, error)
+	ListClients(context.Context, *empty.Empty) (*ClientInfoList, error)
+	ListTimers(context.Context, *empty.Empty) (*TimerInfoList, error)
+	// Closes the connection with the given ID going through the whole Network Service Manager chain
+	CloseConnection(context.Context, *CloseConnectionRequest) (*empty.Empty, error)
+}
+
+// UnimplementedAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (*UnimplementedAdminServer) ListConnections(ctx context.Context, req *empty.Empty) (*ConnectionInfoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConnections not implemented")
+}
+func (*UnimplementedAdminServer) ListEndpoints(ctx context.Context, req *empty.Empty) (*EndpointList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEndpoints not implemented")
+}
+func (*UnimplementedAdminServer) ListClients(ctx context.Context, req *empty.Empty) (*ClientInfoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClients not implemented")
+}
+func (*UnimplementedAdminServer) ListTimers(ctx context.Context, req *empty.Empty) (*TimerInfoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTimers not implemented")
+}
+func (*UnimplementedAdminServer) CloseConnection(ctx context.Context, req *CloseConnectionRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseConnection not implemented")
+}
+
+func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
+	s.RegisterService(&_Admin_serviceDesc, srv)
+}
+
+func _Admin_ListConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListConnections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListConnections(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListEndpoints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListEndpoints(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListClients(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListTimers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListTimers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListTimers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListTimers(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CloseConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CloseConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/CloseConnection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CloseConnection(ctx, req.(*CloseConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Admin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListConnections",
+			Handler:    _Admin_ListConnections_Handler,
+		},
+		{
+			MethodName: "ListEndpoints",
+			Handler:    _Admin_ListEndpoints_Handler,
+		},
+		{
+			MethodName: "ListClients",
+			Handler:    _Admin_ListClients_Handler,
+		},
+		{
+			MethodName: "ListTimers",
+			Handler:    _Admin_ListTimers_Handler,
+		},
+		{
+			MethodName: "CloseConnection",
+			Handler:    _Admin_CloseConnection_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
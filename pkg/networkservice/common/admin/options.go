@@ -0,0 +1,54 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcpool"
+)
+
+type configurable interface {
+	setEndpoints(func() []*registry.NetworkServiceEndpoint)
+	setPool(*grpcpool.Pool)
+}
+
+// Option is admin server configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithEndpoints sets the function listing the registered local endpoints, by default there are no endpoints listed
+func WithEndpoints(endpoints func() []*registry.NetworkServiceEndpoint) Option {
+	return applierFunc(func(c configurable) {
+		c.setEndpoints(endpoints)
+	})
+}
+
+// WithPool sets the pool of grpc.ClientConns used by connect to reach the downstream clients, by default there are no
+// clients listed
+func WithPool(pool *grpcpool.Pool) Option {
+	return applierFunc(func(c configurable) {
+		c.setPool(pool)
+	})
+}
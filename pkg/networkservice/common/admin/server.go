@@ -0,0 +1,260 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin provides a chain element tracking the active connections and implementing the Admin gRPC service for
+// the live introspection of the Network Service Manager
+package admin
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/stage"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcpool"
+)
+
+// Server - chain element tracking the active connections and AdminServer exposing them
+type Server interface {
+	networkservice.NetworkServiceServer
+	AdminServer
+}
+
+type connectionInfo struct {
+	conn        *networkservice.Connection
+	expires     *timestamp.Timestamp
+	established bool
+	tracker     *stage.Tracker
+}
+
+type adminServer struct {
+	onClose     *networkservice.NetworkServiceServer
+	endpoints   func() []*registry.NetworkServiceEndpoint
+	pool        *grpcpool.Pool
+	connections map[string]*connectionInfo
+	mutex       sync.Mutex
+}
+
+// NewServer - creates a new admin Server. The chain elements following it record their stage of the connection
+//             Request/Close (see stage.Tracker), so ListConnections shows where the Request/Close is stuck. It can be
+//             placed before setid: the connections are tracked by the ID returned from the next chain elements.
+//             AdminServer has no authorization of its own, so it should be served on a separate listener reachable
+//             only by the administrators.
+//             - onClose - *networkservice.NetworkServiceServer used by CloseConnection. Since the admin Server is
+//                         usually a part of the chain it should close, it is a pointer to the place the chain will be
+//                         stored. If onClose is nil, CloseConnection only makes the admin Server forget the
+//                         connection
+//             - options - configuration options
+func NewServer(onClose *networkservice.NetworkServiceServer, options ...Option) Server {
+	rv := &adminServer{
+		onClose:     onClose,
+		connections: make(map[string]*connectionInfo),
+	}
+	for _, o := range options {
+		o.apply(rv)
+	}
+	if rv.onClose == nil {
+		var actualOnClose networkservice.NetworkServiceServer = rv
+		rv.onClose = &actualOnClose
+	}
+	return rv
+}
+
+func (s *adminServer) setEndpoints(endpoints func() []*registry.NetworkServiceEndpoint) {
+	s.endpoints = endpoints
+}
+
+func (s *adminServer) setPool(pool *grpcpool.Pool) {
+	s.pool = pool
+}
+
+func (s *adminServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	connID := request.GetConnection().GetId()
+
+	s.mutex.Lock()
+	info, ok := s.connections[connID]
+	if !ok {
+		info = &connectionInfo{
+			conn:    request.GetConnection().Clone(),
+			tracker: new(stage.Tracker),
+		}
+		s.connections[connID] = info
+	}
+	wasEstablished := info.established
+	s.mutex.Unlock()
+
+	ctx = stage.WithTracker(ctx, info.tracker)
+	conn, err := next.Server(ctx).Request(ctx, request)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err != nil {
+		// Forget the connection only if it has never been established, failed refresh doesn't close it
+		if !info.established && s.connections[connID] == info {
+			delete(s.connections, connID)
+		}
+		return nil, err
+	}
+	// Connection ID can be assigned by the next chain elements (setid), so the connection is tracked by the returned ID.
+	// Established connection is never re-keyed: if the Request with its ID has returned another ID, it is a new
+	// connection tracked separately.
+	if conn.GetId() != connID {
+		if wasEstablished {
+			info = &connectionInfo{
+				tracker: new(stage.Tracker),
+			}
+		} else if s.connections[connID] == info {
+			delete(s.connections, connID)
+		}
+		s.connections[conn.GetId()] = info
+	}
+	info.conn = conn.Clone()
+	info.expires = expires(conn)
+	info.established = true
+
+	return conn, nil
+}
+
+// expires - returns the expiration time of the path segment of conn
+func expires(conn *networkservice.Connection) *timestamp.Timestamp {
+	for _, segment := range conn.GetPath().GetPathSegments() {
+		if segment.GetId() == conn.GetId() {
+			return segment.GetExpires()
+		}
+	}
+	return nil
+}
+
+func (s *adminServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.mutex.Lock()
+	info, ok := s.connections[conn.GetId()]
+	s.mutex.Unlock()
+
+	if ok {
+		ctx = stage.WithTracker(ctx, info.tracker)
+		defer func() {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+
+			if s.connections[conn.GetId()] == info {
+				delete(s.connections, conn.GetId())
+			}
+		}()
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *adminServer) ListConnections(context.Context, *empty.Empty) (*ConnectionInfoList, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	rv := &ConnectionInfoList{}
+	for _, info := range s.connections {
+		connInfo := &ConnectionInfo{
+			Connection: info.conn.Clone(),
+		}
+		if st := info.tracker.Load(); st.ChainElement != "" {
+			connInfo.ChainElement = st.ChainElement
+			connInfo.ChainElementSince, _ = ptypes.TimestampProto(st.Since)
+		}
+		rv.Connections = append(rv.Connections, connInfo)
+	}
+	sort.Slice(rv.Connections, func(i, j int) bool {
+		return rv.Connections[i].GetConnection().GetId() < rv.Connections[j].GetConnection().GetId()
+	})
+	return rv, nil
+}
+
+func (s *adminServer) ListEndpoints(context.Context, *empty.Empty) (*EndpointList, error) {
+	rv := &EndpointList{}
+	if s.endpoints != nil {
+		rv.Endpoints = s.endpoints()
+	}
+	sort.Slice(rv.Endpoints, func(i, j int) bool {
+		return rv.Endpoints[i].GetName() < rv.Endpoints[j].GetName()
+	})
+	return rv, nil
+}
+
+func (s *adminServer) ListClients(context.Context, *empty.Empty) (*ClientInfoList, error) {
+	rv := &ClientInfoList{}
+	if s.pool == nil {
+		return rv, nil
+	}
+	for _, cc := range s.pool.List() {
+		clientInfo := &ClientInfo{
+			Url:   cc.URL,
+			State: cc.State.String(),
+			Refs:  int32(cc.Refs),
+		}
+		if !cc.IdleSince.IsZero() {
+			clientInfo.IdleSince, _ = ptypes.TimestampProto(cc.IdleSince)
+		}
+		rv.Clients = append(rv.Clients, clientInfo)
+	}
+	sort.Slice(rv.Clients, func(i, j int) bool {
+		return rv.Clients[i].GetUrl() < rv.Clients[j].GetUrl()
+	})
+	return rv, nil
+}
+
+func (s *adminServer) ListTimers(context.Context, *empty.Empty) (*TimerInfoList, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	rv := &TimerInfoList{}
+	for connID, info := range s.connections {
+		if info.expires == nil {
+			continue
+		}
+		rv.Timers = append(rv.Timers, &TimerInfo{
+			ConnectionId: connID,
+			Expires:      info.expires,
+		})
+	}
+	sort.Slice(rv.Timers, func(i, j int) bool {
+		return rv.Timers[i].GetConnectionId() < rv.Timers[j].GetConnectionId()
+	})
+	return rv, nil
+}
+
+func (s *adminServer) CloseConnection(ctx context.Context, request *CloseConnectionRequest) (*empty.Empty, error) {
+	s.mutex.Lock()
+	info, ok := s.connections[request.GetConnectionId()]
+	var conn *networkservice.Connection
+	if ok {
+		conn = info.conn.Clone()
+	}
+	s.mutex.Unlock()
+
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "connection not found: %s", request.GetConnectionId())
+	}
+	if _, err := (*s.onClose).Close(ctx, conn); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
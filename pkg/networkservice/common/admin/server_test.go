@@ -0,0 +1,256 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/admin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/setid"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/updatepath"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type blockingServer struct {
+	blocked chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if s.blocked != nil {
+		close(s.blocked)
+		<-s.release
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *blockingServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+type closeCounterServer struct {
+	closes int
+}
+
+func (s *closeCounterServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *closeCounterServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.closes++
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func request(id string, expires time.Time) *networkservice.NetworkServiceRequest {
+	ts, _ := ptypes.TimestampProto(expires)
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: id,
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: "nsmgr", Id: id, Expires: ts}},
+			},
+		},
+	}
+}
+
+func TestAdminServer_ChainElement(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	blocking := &blockingServer{
+		blocked: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	adminServer := admin.NewServer(nil)
+	server := chain.NewNetworkServiceServer(adminServer, blocking)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := server.Request(context.Background(), request("id", time.Now().Add(time.Minute)))
+		errCh <- err
+	}()
+	<-blocking.blocked
+
+	list, err := adminServer.ListConnections(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Len(t, list.Connections, 1)
+	require.Equal(t, "id", list.Connections[0].GetConnection().GetId())
+	require.Contains(t, list.Connections[0].GetChainElement(), "blockingServer.Request")
+	require.NotNil(t, list.Connections[0].GetChainElementSince())
+
+	close(blocking.release)
+	require.NoError(t, <-errCh)
+
+	list, err = adminServer.ListConnections(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Len(t, list.Connections, 1)
+	require.Empty(t, list.Connections[0].GetChainElement())
+}
+
+func TestAdminServer_Timers(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	adminServer := admin.NewServer(nil)
+	server := chain.NewNetworkServiceServer(adminServer)
+
+	expires := time.Now().Add(time.Minute)
+	_, err := server.Request(context.Background(), request("id", expires))
+	require.NoError(t, err)
+
+	timers, err := adminServer.ListTimers(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Len(t, timers.Timers, 1)
+	require.Equal(t, "id", timers.Timers[0].GetConnectionId())
+
+	actual, err := ptypes.Timestamp(timers.Timers[0].GetExpires())
+	require.NoError(t, err)
+	require.True(t, expires.Equal(actual))
+}
+
+func tokenGenerator(credentials.AuthInfo) (token string, expireTime time.Time, err error) {
+	return "token", time.Now().Add(time.Hour), nil
+}
+
+func TestAdminServer_BeforeSetID(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	adminServer := admin.NewServer(nil)
+	server := chain.NewNetworkServiceServer(
+		adminServer,
+		setid.NewServer("nsmgr"),
+		updatepath.NewServer("nsmgr", tokenGenerator),
+	)
+
+	conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "nsc-id",
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: "nsc", Id: "nsc-id"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, "nsc-id", conn.GetId())
+
+	// Refresh
+	conn, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+
+	list, err := adminServer.ListConnections(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Len(t, list.Connections, 1)
+	require.Equal(t, conn.GetId(), list.Connections[0].GetConnection().GetId())
+
+	timers, err := adminServer.ListTimers(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Len(t, timers.Timers, 1)
+	require.Equal(t, conn.GetId(), timers.Timers[0].GetConnectionId())
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	list, err = adminServer.ListConnections(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Empty(t, list.Connections)
+}
+
+// renameServer - assigns a new ID to every Request
+type renameServer struct {
+	count int
+}
+
+func (s *renameServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	s.count++
+	request.GetConnection().Id = fmt.Sprintf("id-%d", s.count)
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *renameServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func TestAdminServer_EstablishedNotRekeyed(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	adminServer := admin.NewServer(nil)
+	server := chain.NewNetworkServiceServer(adminServer, &renameServer{})
+
+	conn, err := server.Request(context.Background(), request("nsc-id", time.Now().Add(time.Minute)))
+	require.NoError(t, err)
+	require.Equal(t, "id-1", conn.GetId())
+
+	// Request with the ID of the established connection has got another ID, so it is another connection
+	conn, err = server.Request(context.Background(), request("id-1", time.Now().Add(time.Minute)))
+	require.NoError(t, err)
+	require.Equal(t, "id-2", conn.GetId())
+
+	list, err := adminServer.ListConnections(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Len(t, list.Connections, 2)
+	require.Equal(t, "id-1", list.Connections[0].GetConnection().GetId())
+	require.Equal(t, "id-2", list.Connections[1].GetConnection().GetId())
+}
+
+func TestAdminServer_Endpoints(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	adminServer := admin.NewServer(nil, admin.WithEndpoints(func() []*registry.NetworkServiceEndpoint {
+		return []*registry.NetworkServiceEndpoint{{Name: "nse-2"}, {Name: "nse-1"}}
+	}))
+
+	list, err := adminServer.ListEndpoints(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Len(t, list.Endpoints, 2)
+	require.Equal(t, "nse-1", list.Endpoints[0].GetName())
+	require.Equal(t, "nse-2", list.Endpoints[1].GetName())
+}
+
+func TestAdminServer_CloseConnection(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	counter := &closeCounterServer{}
+	var server networkservice.NetworkServiceServer
+	adminServer := admin.NewServer(&server)
+	server = chain.NewNetworkServiceServer(adminServer, counter)
+
+	_, err := server.Request(context.Background(), request("id", time.Now().Add(time.Minute)))
+	require.NoError(t, err)
+
+	_, err = adminServer.CloseConnection(context.Background(), &admin.CloseConnectionRequest{ConnectionId: "id"})
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.closes)
+
+	list, err := adminServer.ListConnections(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Empty(t, list.Connections)
+
+	_, err = adminServer.CloseConnection(context.Background(), &admin.CloseConnectionRequest{ConnectionId: "id"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connect
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/circuitbreaker"
+)
+
+// breakerClient - fails fast on Request if the circuit for the breakerURL is open, Close is always passed through
+type breakerClient struct {
+	breakers   *circuitbreaker.Breakers
+	breakerURL *url.URL
+}
+
+func newBreakerClient(breakers *circuitbreaker.Breakers, breakerURL *url.URL) networkservice.NetworkServiceClient {
+	return &breakerClient{
+		breakers:   breakers,
+		breakerURL: breakerURL,
+	}
+}
+
+func (b *breakerClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if err := b.breakers.Allow(b.breakerURL); err != nil {
+		return nil, err
+	}
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	b.breakers.Report(b.breakerURL, err)
+	return conn, err
+}
+
+func (b *breakerClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
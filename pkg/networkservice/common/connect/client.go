@@ -35,6 +35,7 @@ type connectClient struct {
 
 // NewClient - client chain element for use with single incoming connection, translates from incoming server connection to
 //             outgoing client connection
+//             - cancel - called when the client connection is closed or the initial Request fails
 func NewClient(cancel context.CancelFunc) networkservice.NetworkServiceClient {
 	return &connectClient{
 		cancel: cancel,
@@ -47,12 +48,21 @@ func (c *connectClient) Request(ctx context.Context, request *networkservice.Net
 	clientRequest := &networkservice.NetworkServiceRequest{Connection: request.GetConnection().Clone()}
 	clientRequest.GetConnection().Mechanism = nil
 	if c.connection != nil {
-		clientRequest.Connection = c.connection
+		// c.connection has been returned to the caller, so the chain elements should modify its copy
+		clientRequest.Connection = c.connection.Clone()
 	}
 	conn, err := next.Client(ctx).Request(ctx, clientRequest)
+	if err != nil {
+		if c.connection == nil {
+			// Initial Request has failed, so there is nothing to close
+			c.cancel()
+		}
+		// Keep the established connection, so it still can be closed
+		return nil, err
+	}
 	c.connection = conn
 
-	return conn, err
+	return conn, nil
 }
 
 func (c *connectClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
@@ -61,7 +71,7 @@ func (c *connectClient) Close(ctx context.Context, conn *networkservice.Connecti
 	if c.connection == nil {
 		return &empty.Empty{}, nil
 	}
-	_, err := next.Client(ctx).Close(ctx, c.connection)
+	_, err := next.Client(ctx).Close(ctx, c.connection.Clone())
 	c.cancel()
 	return &empty.Empty{}, err
 }
@@ -0,0 +1,65 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connect
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/circuitbreaker"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcpool"
+)
+
+type configurable interface {
+	setClientDialOptions([]grpc.DialOption)
+	setPool(*grpcpool.Pool)
+	setCircuitBreakers(*circuitbreaker.Breakers)
+}
+
+// Option configures connect server
+type Option interface {
+	apply(configurable)
+}
+
+type applyOptionFunc func(configurable)
+
+func (a applyOptionFunc) apply(c configurable) {
+	a(c)
+}
+
+// WithClientDialOptions sets specific dial options for each created client. Ignored if WithPool is used
+func WithClientDialOptions(opts ...grpc.DialOption) Option {
+	return applyOptionFunc(func(c configurable) {
+		c.setClientDialOptions(opts)
+	})
+}
+
+// WithPool sets the pool of grpc.ClientConns used to reach clientURLs, by default a new grpcpool.Pool is created with
+// the client dial options
+func WithPool(pool *grpcpool.Pool) Option {
+	return applyOptionFunc(func(c configurable) {
+		c.setPool(pool)
+	})
+}
+
+// WithCircuitBreakers sets the circuit breakers keyed by circuitbreaker.URL(ctx) or clientURL if it is not set, by
+// default new circuitbreaker.Breakers are created.
+// The same circuit breakers can be passed to the selection chain elements to skip the endpoints with the open circuit
+func WithCircuitBreakers(breakers *circuitbreaker.Breakers) Option {
+	return applyOptionFunc(func(c configurable) {
+		c.setCircuitBreakers(breakers)
+	})
+}
@@ -20,39 +20,84 @@ package connect
 
 import (
 	"context"
+	"net/url"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clienturl"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/networkservicemesh/sdk/pkg/tools/circuitbreaker"
 	"github.com/networkservicemesh/sdk/pkg/tools/clientmap"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcpool"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
 )
 
 type connectServer struct {
 	ctx               context.Context
 	clientFactory     func(ctx context.Context, cc grpc.ClientConnInterface) networkservice.NetworkServiceClient
 	clientDialOptions []grpc.DialOption
-	clientsByURL      clientmap.RefcountMap // key == clientURL.String()
+	pool              *grpcpool.Pool
+	breakers          *circuitbreaker.Breakers
+	clientsByURL      map[string]*urlClient // key == clientURL.String()
 	clientsByID       clientmap.Map         // key == client connection ID
+	mutex             sync.Mutex
 }
 
-// NewServer - chain element that
-func NewServer(ctx context.Context, clientFactory func(ctx context.Context, cc grpc.ClientConnInterface) networkservice.NetworkServiceClient, clientDialOptions ...grpc.DialOption) networkservice.NetworkServiceServer {
-	return &connectServer{
-		ctx:               ctx,
-		clientFactory:     clientFactory,
-		clientDialOptions: clientDialOptions,
+// urlClient - client created by the clientFactory for the pooled grpc.ClientConn, shared by the connections to the
+//             same clientURL
+type urlClient struct {
+	key    string
+	cc     *grpcpool.Conn
+	client networkservice.NetworkServiceClient
+	cancel context.CancelFunc
+	refs   int
+}
+
+// NewServer - chain element that connects to the clienturl.ClientURL(ctx) and passes the Request/Close to the client
+//             - ctx - context for the lifecycle of the server, all the pooled grpc.ClientConns are closed when it is done
+//             - clientFactory - creates a client chain for the dialed grpc.ClientConn
+//             - options - configuration options
+func NewServer(ctx context.Context, clientFactory func(ctx context.Context, cc grpc.ClientConnInterface) networkservice.NetworkServiceClient, options ...Option) networkservice.NetworkServiceServer {
+	rv := &connectServer{
+		ctx:           ctx,
+		clientFactory: clientFactory,
+		clientsByURL:  make(map[string]*urlClient),
+	}
+	for _, o := range options {
+		o.apply(rv)
+	}
+	if rv.pool == nil {
+		rv.pool = grpcpool.New(ctx, grpcpool.WithDialOptions(rv.clientDialOptions...))
 	}
+	if rv.breakers == nil {
+		rv.breakers = circuitbreaker.New()
+	}
+	return rv
+}
+
+func (c *connectServer) setClientDialOptions(opts []grpc.DialOption) {
+	c.clientDialOptions = opts
+}
+
+func (c *connectServer) setPool(pool *grpcpool.Pool) {
+	c.pool = pool
+}
+
+func (c *connectServer) setCircuitBreakers(breakers *circuitbreaker.Breakers) {
+	c.breakers = breakers
 }
 
 func (c *connectServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	clientConn, clientErr := c.client(ctx, request.GetConnection()).Request(ctx, request)
-
 	if clientErr != nil {
 		return nil, clientErr
 	}
@@ -90,50 +135,91 @@ func (c *connectServer) Close(ctx context.Context, conn *networkservice.Connecti
 func (c *connectServer) client(ctx context.Context, conn *networkservice.Connection) networkservice.NetworkServiceClient {
 	// Fast path, if we have a client for this conn.GetId(), use it
 	client, _ := c.clientsByID.Load(conn.GetId())
+	if client != nil {
+		return client
+	}
 
 	// If we didn't find a client, we fall back to clientURL
-	if client == nil {
-		clientURL := clienturl.ClientURL(ctx)
-		// If we don't have a clientURL, all we can do is return errors
-		if clientURL == nil {
-			clientErr := errors.Errorf("clientURL not found for incoming connection: %+v", conn)
-			return injecterror.NewClient(clientErr)
+	clientURL := clienturl.ClientURL(ctx)
+	// If we don't have a clientURL, all we can do is return errors
+	if clientURL == nil {
+		clientErr := errors.Errorf("clientURL not found for incoming connection: %+v", redact.Default().Value(conn))
+		return injecterror.NewClient(clientErr)
+	}
+	// Circuit breaker is keyed by the selected endpoint URL if it is set, clientURL can be the forwarder URL
+	breakerURL := circuitbreaker.URL(ctx)
+	if breakerURL == nil {
+		breakerURL = clientURL
+	}
+	// Fail fast without dialing if the circuit for the endpoint is open
+	if c.breakers.State(breakerURL) == circuitbreaker.Open {
+		return injecterror.NewClient(status.Errorf(codes.Unavailable, "circuit breaker is open for %s", breakerURL))
+	}
+	uc, err := c.acquireClient(clientURL)
+	if err != nil {
+		return injecterror.NewClient(err)
+	}
+	// Wrap the client in a per-connection connect.NewClient(...)
+	// when this client receive a 'Close' or fails the initial 'Request' it will call the cancelFunc provided deleting
+	// it from the clientsByID and releasing the urlClient.
+	client = chain.NewNetworkServiceClient(
+		NewClient(func() {
+			c.clientsByID.Delete(conn.GetId())
+			c.releaseClient(uc)
+		}),
+		newBreakerClient(c.breakers, breakerURL),
+		uc.client,
+	)
+	// Note: It is possible for multiple nearly simultaneous initial Requests to race the client == nil check.
+	// If loaded == true, then another Request for the same conn.GetId() was being processed in parallel, so
+	// release the urlClient acquired for this one.
+	var loaded bool
+	client, loaded = c.clientsByID.LoadOrStore(conn.GetId(), client)
+	if loaded {
+		c.releaseClient(uc)
+	}
+	return client
+}
+
+func (c *connectServer) acquireClient(clientURL *url.URL) (*urlClient, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	uc, ok := c.clientsByURL[clientURL.String()]
+	if !ok || uc.cc.Evicted() {
+		// grpc.ClientConn evicted from the pool is kept by the connections already using it, the new ones use
+		// a new grpc.ClientConn
+		cc, err := c.pool.Acquire(clientURL)
+		if err != nil {
+			return nil, err
 		}
-		// Fast path: load the client by URL.  In the unfortunate event someone has poorly chosen
-		// a clientFactory or dialOptions that take time, this will be faster than
-		// creating a new one and doing a LoadOrStore
-		client, _ = c.clientsByURL.Load(clientURL.String())
-		// If we still don't have a client, create one, and LoadOrStore it
-		// Note: It is possible for multiple nearly simultaneous initial Requests to race this client == nil check
-		// and both enter into the body of the if block.  However, if that occurs, when they call call clientByID.LoadAndStore
-		// only one of them will Store, the rest will Load.
-		// The return of load == true from the clientById.LoadAndStore(conn.GetId()) indicates that there has been
-		// a race, and those receiving it must then call clientByURL.Delete(clientURL) to clean up the Refcount, so
-		// we only get one refcount increment per conn.GetId().  In this way correctness is preserved, even in the
-		// unlikely case of multiple nearly simultaneous initial Requests racing this client == nil
-		if client == nil {
-			// Note: clienturl.NewClient(...) will get properly cleaned up when dereferences
-			client = clienturl.NewClient(clienturl.WithClientURL(c.ctx, clientURL), c.clientFactory, c.clientDialOptions...)
-			client, _ = c.clientsByURL.LoadOrStore(clientURL.String(), client)
-			// Wrap the client in a per-connection connect.NewClient(...)
-			// when this client receive a 'Close' it will call the cancelFunc provided deleting it from the various
-			// maps.
-			client = chain.NewNetworkServiceClient(
-				NewClient(func() {
-					c.clientsByID.Delete(conn.GetId())
-					c.clientsByURL.Delete(clientURL.String())
-				}),
-				client,
-			)
-			var loaded bool
-			client, loaded = c.clientsByID.LoadOrStore(conn.GetId(), client)
-			if loaded {
-				// If loaded == true, then another Request for the same conn.GetId() was being processed in parallel
-				// since both of those called c.clientsByURL.LoadOrStore, the refcount for this one conn.GetId()
-				// got incremented *twice*.  Correct for that here by decrementing
-				c.clientsByURL.Delete(conn.GetId())
-			}
+		ctx, cancel := context.WithCancel(clienturl.WithClientURL(c.ctx, clientURL))
+		uc = &urlClient{
+			key:    clientURL.String(),
+			cc:     cc,
+			client: c.clientFactory(ctx, cc),
+			cancel: cancel,
 		}
+		c.clientsByURL[uc.key] = uc
+	} else if uc.cc.GetState() == connectivity.TransientFailure {
+		// grpc.ClientConn is reconnecting within the pool grace period, new connection shouldn't wait for the backoff
+		uc.cc.ResetConnectBackoff()
 	}
-	return client
+	uc.refs++
+	return uc, nil
+}
+
+func (c *connectServer) releaseClient(uc *urlClient) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	uc.refs--
+	if uc.refs > 0 {
+		return
+	}
+	if c.clientsByURL[uc.key] == uc {
+		delete(c.clientsByURL, uc.key)
+	}
+	uc.cancel()
+	uc.cc.Release()
 }
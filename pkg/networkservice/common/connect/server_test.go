@@ -31,14 +31,20 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/testnse"
+	"github.com/networkservicemesh/sdk/pkg/tools/circuitbreaker"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcpool"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -91,7 +97,7 @@ func TestConnectServerShouldNotPanicOnRequest(t *testing.T) {
 			defer serverCancel()
 			s := NewServer(serverCtx, func(ctx context.Context, cc grpc.ClientConnInterface) networkservice.NetworkServiceClient {
 				return adapters.NewServerToClient(nseT.nse)
-			}, grpc.WithInsecure())
+			}, WithClientDialOptions(grpc.WithInsecure()))
 			clientURLCtx, clientCancel := context.WithTimeout(context.Background(), timeout)
 			defer clientCancel()
 			clientURLCtx = nseT.newNSEContext(clientURLCtx)
@@ -115,7 +121,7 @@ func TestConnectServerShouldNotPanicOnRequest(t *testing.T) {
 			defer serverCancel()
 			s := NewServer(serverCtx, func(ctx context.Context, cc grpc.ClientConnInterface) networkservice.NetworkServiceClient {
 				return adapters.NewServerToClient(nseT.nse)
-			}, grpc.WithInsecure())
+			}, WithClientDialOptions(grpc.WithInsecure()))
 			clientURLCtx, clientCancel := context.WithTimeout(context.Background(), timeout)
 			defer clientCancel()
 			clientURLCtx = nseT.newNSEContext(clientURLCtx)
@@ -141,7 +147,7 @@ func TestConnectServerShouldNotPanicOnRequest(t *testing.T) {
 			defer serverCancel()
 			s := NewServer(serverCtx, func(ctx context.Context, cc grpc.ClientConnInterface) networkservice.NetworkServiceClient {
 				return adapters.NewServerToClient(nseT.nse)
-			}, grpc.WithInsecure())
+			}, WithClientDialOptions(grpc.WithInsecure()))
 
 			conn, err := s.Request(context.Background(), &networkservice.NetworkServiceRequest{
 				Connection: &networkservice.Connection{
@@ -158,7 +164,7 @@ func TestConnectServerShouldNotPanicOnRequest(t *testing.T) {
 			defer serverCancel()
 			s := NewServer(serverCtx, func(ctx context.Context, cc grpc.ClientConnInterface) networkservice.NetworkServiceClient {
 				return adapters.NewServerToClient(nseT.nse)
-			}, grpc.WithInsecure())
+			}, WithClientDialOptions(grpc.WithInsecure()))
 			clientURLCtx, clientCancel := context.WithTimeout(context.Background(), timeout)
 			defer clientCancel()
 			clientURLCtx = nseT.newNSEContext(clientURLCtx)
@@ -201,7 +207,7 @@ func TestParallelDial(t *testing.T) {
 	serverCtx, serverCancel := context.WithCancel(context.Background())
 	defer serverCancel()
 
-	s := NewServer(serverCtx, client.NewClientFactory("nsc", nil, TokenGenerator), grpc.WithInsecure())
+	s := NewServer(serverCtx, client.NewClientFactory("nsc", nil, TokenGenerator), WithClientDialOptions(grpc.WithInsecure()))
 	var wg sync.WaitGroup
 	for i := 0; i < 10; i++ {
 		wg.Add(1)
@@ -231,3 +237,144 @@ func TestParallelDial(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestConnectServerRequestFailed(t *testing.T) {
+	nseT := &nseTest{}
+	nseT.Setup()
+
+	testConnectServerRequestFailed(t, nseT)
+
+	nseT.Stop()
+	goleak.VerifyNone(t)
+}
+
+func testConnectServerRequestFailed(t *testing.T, nseT *nseTest) {
+	serverCtx, serverCancel := context.WithCancel(context.Background())
+	defer serverCancel()
+
+	badURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:1"}
+	s := NewServer(serverCtx, func(ctx context.Context, cc grpc.ClientConnInterface) networkservice.NetworkServiceClient {
+		if clienturl.ClientURL(ctx).String() == badURL.String() {
+			return injecterror.NewClient(errors.New("failed to connect"))
+		}
+		return adapters.NewServerToClient(nseT.nse)
+	}, WithClientDialOptions(grpc.WithInsecure()))
+
+	clientURLCtx, clientCancel := context.WithTimeout(context.Background(), timeout)
+	defer clientCancel()
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "1",
+		},
+	}
+	_, err := s.Request(clienturl.WithClientURL(clientURLCtx, badURL), request.Clone())
+	require.NotNil(t, err)
+
+	// Failed client should be forgotten, so the next Request should reach the new clientURL
+	conn, err := s.Request(nseT.newNSEContext(clientURLCtx), request.Clone())
+	require.Nil(t, err)
+	require.Equal(t, "all is ok", conn.GetContext().GetExtraContext()["ok"])
+
+	_, err = s.Close(clientURLCtx, conn)
+	require.Nil(t, err)
+}
+
+func TestConnectServerReleasesClientConn(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	nseT := &nseTest{}
+	nseT.Setup()
+	defer nseT.Stop()
+
+	serverCtx, serverCancel := context.WithCancel(context.Background())
+	defer serverCancel()
+
+	pool := grpcpool.New(serverCtx, grpcpool.WithDialOptions(grpc.WithInsecure()))
+	s := NewServer(serverCtx, func(ctx context.Context, cc grpc.ClientConnInterface) networkservice.NetworkServiceClient {
+		return adapters.NewServerToClient(nseT.nse)
+	}, WithPool(pool))
+
+	clientURLCtx, clientCancel := context.WithTimeout(context.Background(), timeout)
+	defer clientCancel()
+	clientURLCtx = nseT.newNSEContext(clientURLCtx)
+
+	var conns []*networkservice.Connection
+	for i := 0; i < 2; i++ {
+		conn, err := s.Request(clientURLCtx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: fmt.Sprint(i),
+			},
+		})
+		require.Nil(t, err)
+		conns = append(conns, conn)
+	}
+	// Both connections share the same grpc.ClientConn
+	require.Equal(t, grpcpool.Metrics{Open: 1, Dials: 1}, pool.Metrics())
+
+	for _, conn := range conns {
+		_, err := s.Close(clientURLCtx, conn)
+		require.Nil(t, err)
+	}
+	require.Equal(t, grpcpool.Metrics{Open: 1, Idle: 1, Dials: 1}, pool.Metrics())
+}
+
+func TestConnectServerCircuitBreaker(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	serverCtx, serverCancel := context.WithCancel(context.Background())
+	defer serverCancel()
+
+	var dials int
+	breakers := circuitbreaker.New(circuitbreaker.WithFailureThreshold(2), circuitbreaker.WithOpenTimeout(time.Hour))
+	s := NewServer(serverCtx, func(ctx context.Context, cc grpc.ClientConnInterface) networkservice.NetworkServiceClient {
+		dials++
+		return injecterror.NewClient(status.Error(codes.Unavailable, "endpoint is not available"))
+	}, WithClientDialOptions(grpc.WithInsecure()), WithCircuitBreakers(breakers))
+
+	clientURLCtx, clientCancel := context.WithTimeout(context.Background(), timeout)
+	defer clientCancel()
+	u := &url.URL{Scheme: "tcp", Host: "127.0.0.1:1"}
+	clientURLCtx = clienturl.WithClientURL(clientURLCtx, u)
+
+	for i := 0; i < 3; i++ {
+		_, err := s.Request(clientURLCtx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: "1",
+			},
+		})
+		require.NotNil(t, err)
+		require.Equal(t, codes.Unavailable, status.Code(errors.Cause(err)))
+	}
+	// The last Request has failed fast
+	require.Equal(t, 2, dials)
+	require.Equal(t, circuitbreaker.Open, breakers.State(u))
+}
+
+func TestConnectServerCircuitBreakerURL(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	serverCtx, serverCancel := context.WithCancel(context.Background())
+	defer serverCancel()
+
+	breakers := circuitbreaker.New(circuitbreaker.WithFailureThreshold(1), circuitbreaker.WithOpenTimeout(time.Hour))
+	s := NewServer(serverCtx, func(ctx context.Context, cc grpc.ClientConnInterface) networkservice.NetworkServiceClient {
+		return injecterror.NewClient(status.Error(codes.Unavailable, "endpoint is not available"))
+	}, WithClientDialOptions(grpc.WithInsecure()), WithCircuitBreakers(breakers))
+
+	clientCtx, clientCancel := context.WithTimeout(context.Background(), timeout)
+	defer clientCancel()
+	// Request is sent to the endpoint via the forwarder
+	forwarderURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:1"}
+	endpointURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:2"}
+	clientCtx = circuitbreaker.WithURL(clienturl.WithClientURL(clientCtx, forwarderURL), endpointURL)
+
+	_, err := s.Request(clientCtx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "1",
+		},
+	})
+	require.Error(t, err)
+	require.Equal(t, circuitbreaker.Open, breakers.State(endpointURL))
+	require.Equal(t, circuitbreaker.Closed, breakers.State(forwarderURL))
+}
@@ -18,11 +18,12 @@ package discover
 
 import (
 	"bytes"
+	"context"
 	"text/template"
 
-	"github.com/sirupsen/logrus"
-
 	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 // isSubset checks if B is a subset of A. TODO: reconsider this as a part of "tools"
@@ -41,8 +42,8 @@ func isSubset(a, b, nsLabels map[string]string) bool {
 	return true
 }
 
-func matchEndpoint(nsLabels map[string]string, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
-	logrus.Infof("Matching endpoint for labels %v", nsLabels)
+func matchEndpoint(ctx context.Context, nsLabels map[string]string, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+	log.FromContext(ctx).Debugf("Matching endpoint for labels %v", nsLabels)
 
 	// Iterate through the matches
 	for _, match := range ns.GetMatches() {
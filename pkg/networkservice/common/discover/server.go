@@ -29,6 +29,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
 )
 
 type discoverCandidatesServer struct {
@@ -48,19 +49,7 @@ func NewServer(nsClient registry.NetworkServiceRegistryClient, nseClient registr
 func (d *discoverCandidatesServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	nseName := request.GetConnection().GetNetworkServiceEndpointName()
 	if nseName != "" {
-		nseStream, err := d.nseClient.Find(context.Background(), &registry.NetworkServiceEndpointQuery{
-			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
-				Name: nseName,
-			},
-		})
-		if err != nil {
-			return nil, err
-		}
-		nseList := registry.ReadNetworkServiceEndpointList(nseStream)
-		if len(nseList) == 0 {
-			return nil, errors.Errorf("network service endpoint %s is not found", nseName)
-		}
-		u, err := url.Parse(nseList[0].Url)
+		u, err := d.urlByNSEName(nseName)
 		if err != nil {
 			return nil, err
 		}
@@ -87,11 +76,39 @@ func (d *discoverCandidatesServer) Request(ctx context.Context, request *network
 	}
 
 	nsList := registry.ReadNetworkServiceList(nsStream)
-	nseList = matchEndpoint(request.GetConnection().GetLabels(), nsList[0], nseList)
+	nseList = matchEndpoint(ctx, request.GetConnection().GetLabels(), nsList[0], nseList)
 	ctx = WithCandidates(ctx, nseList, nsList[0])
 	return next.Server(ctx).Request(ctx, request)
 }
 
-func (d *discoverCandidatesServer) Close(context.Context, *networkservice.Connection) (*empty.Empty, error) {
-	return &empty.Empty{}, nil
+func (d *discoverCandidatesServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	nseName := conn.GetNetworkServiceEndpointName()
+	if nseName == "" {
+		// Endpoint has not been selected, so there is no URL to close it by
+		return next.Server(ctx).Close(ctx, conn)
+	}
+	u, err := d.urlByNSEName(nseName)
+	if err != nil {
+		// Endpoint may have already been unregistered or expired, the next elements still need to release the
+		// connection resources, e.g. connect finds its client by the connection ID
+		trace.Log(ctx).Warnf("failed to find URL for %s, closing %s without it: %v", nseName, conn.GetId(), err)
+		return next.Server(ctx).Close(ctx, conn)
+	}
+	return next.Server(ctx).Close(clienturl.WithClientURL(ctx, u), conn)
+}
+
+func (d *discoverCandidatesServer) urlByNSEName(nseName string) (*url.URL, error) {
+	nseStream, err := d.nseClient.Find(context.Background(), &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name: nseName,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	nseList := registry.ReadNetworkServiceEndpointList(nseStream)
+	if len(nseList) == 0 {
+		return nil, errors.Errorf("network service endpoint %s is not found", nseName)
+	}
+	return url.Parse(nseList[0].Url)
 }
@@ -301,3 +301,24 @@ func TestMatchSelectedNSE(t *testing.T) {
 	_, err = server.Request(context.Background(), request)
 	require.Nil(t, err)
 }
+
+func TestCloseUnregisteredNSE(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	nsServer := memory.NewNetworkServiceRegistryServer()
+	nseServer := memory.NewNetworkServiceEndpointRegistryServer()
+
+	closed := false
+	server := next.NewNetworkServiceServer(
+		discover.NewServer(adapters.NetworkServiceServerToClient(nsServer), adapters.NetworkServiceEndpointServerToClient(nseServer)),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			require.Nil(t, clienturl.ClientURL(ctx))
+			closed = true
+		}),
+	)
+	_, err := server.Close(context.Background(), &networkservice.Connection{
+		Id:                         "conn-1",
+		NetworkServiceEndpointName: "unregistered-nse",
+	})
+	require.NoError(t, err)
+	require.True(t, closed)
+}
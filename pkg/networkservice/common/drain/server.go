@@ -0,0 +1,191 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drain provides a NetworkServiceServer chain element that tracks active connections and allows to drain
+// them: stop accepting new Requests and close all the active connections.
+package drain
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+)
+
+// Server - NetworkServiceServer chain element which can be drained
+type Server interface {
+	networkservice.NetworkServiceServer
+	// Stop - stops accepting new Requests, the active connections are kept until Drain
+	Stop()
+	// Drain - stops accepting new Requests, waits for the in-flight Requests to complete and closes all the active
+	//         connections. Returns when all the connections are closed or ctx is done. Can be called multiple times,
+	//         each call waits for the connections to be closed.
+	Drain(ctx context.Context) error
+}
+
+type connectionInfo struct {
+	ctx    context.Context
+	conn   *networkservice.Connection
+	once   sync.Once
+	closed chan struct{}
+}
+
+func (c *connectionInfo) markClosed() {
+	c.once.Do(func() {
+		close(c.closed)
+	})
+}
+
+type drainServer struct {
+	onDrain     *networkservice.NetworkServiceServer
+	draining    bool
+	inFlight    sync.WaitGroup
+	connections map[string]*connectionInfo
+	closing     map[string]bool
+	mutex       sync.Mutex
+}
+
+// NewServer - creates a new drain Server
+//             - onDrain - *networkservice.NetworkServiceServer.  Since networkservice.NetworkServiceServer is an interface
+//                        (and thus a pointer) *networkservice.NetworkServiceServer is a double pointer.  Meaning it
+//                        points to a place that points to a place that implements networkservice.NetworkServiceServer
+//                        This is done because when we use drain.NewServer as part of a chain, we may not *have*
+//                        a pointer to this server used 'onDrain'.  The active connections are closed with onDrain.Close,
+//                        so all the chain elements (e.g. monitor) are notified.
+//                        If onDrain is nil, then we simply set onDrain to this server chain element
+func NewServer(onDrain *networkservice.NetworkServiceServer) Server {
+	rv := &drainServer{
+		onDrain:     onDrain,
+		connections: map[string]*connectionInfo{},
+		closing:     map[string]bool{},
+	}
+	if rv.onDrain == nil {
+		var actualOnDrain networkservice.NetworkServiceServer = rv
+		rv.onDrain = &actualOnDrain
+	}
+	return rv
+}
+
+func (d *drainServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	d.mutex.Lock()
+	if d.draining {
+		d.mutex.Unlock()
+		return nil, status.Error(codes.Unavailable, "server is draining")
+	}
+	d.inFlight.Add(1)
+	d.mutex.Unlock()
+	defer d.inFlight.Done()
+
+	index := request.GetConnection().GetPath().GetIndex()
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &connectionInfo{
+		ctx:    extend.WithValuesFromContext(context.Background(), next.ClearNext(ctx)),
+		conn:   conn.Clone(),
+		closed: make(chan struct{}),
+	}
+	// Connection should be closed the same way as the previous hop closes it
+	if info.conn.GetPath() != nil {
+		info.conn.GetPath().Index = index
+	}
+
+	d.mutex.Lock()
+	if prev, ok := d.connections[conn.GetId()]; ok {
+		info.closed = prev.closed
+	}
+	d.connections[conn.GetId()] = info
+	d.mutex.Unlock()
+
+	return conn, nil
+}
+
+func (d *drainServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+	d.forget(conn.GetId())
+	return rv, err
+}
+
+func (d *drainServer) forget(id string) {
+	d.mutex.Lock()
+	info, ok := d.connections[id]
+	delete(d.connections, id)
+	delete(d.closing, id)
+	d.mutex.Unlock()
+	if ok {
+		info.markClosed()
+	}
+}
+
+func (d *drainServer) Stop() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.draining = true
+}
+
+func (d *drainServer) Drain(ctx context.Context) error {
+	d.Stop()
+
+	// No new Requests are accepted, so wait for the in-flight ones to close the connections they establish too
+	inFlightDone := make(chan struct{})
+	go func() {
+		d.inFlight.Wait()
+		close(inFlightDone)
+	}()
+	select {
+	case <-inFlightDone:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	var infos []*connectionInfo
+	d.mutex.Lock()
+	for id, info := range d.connections {
+		infos = append(infos, info)
+		if d.closing[id] {
+			continue
+		}
+		d.closing[id] = true
+		go d.close(ctx, info)
+	}
+	d.mutex.Unlock()
+
+	for _, info := range infos {
+		select {
+		case <-info.closed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (d *drainServer) close(ctx context.Context, info *connectionInfo) {
+	ctx = extend.WithValuesFromContext(ctx, info.ctx)
+	if _, err := (*d.onDrain).Close(ctx, info.conn); err != nil {
+		trace.Log(ctx).Errorf("Error attempting to close connection on drain: %s: %+v", info.conn.GetId(), err)
+	}
+	d.forget(info.conn.GetId())
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/drain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type closeRecorderServer struct {
+	closed       chan string
+	block        chan struct{}
+	requested    chan struct{}
+	blockRequest chan struct{}
+}
+
+func (s *closeRecorderServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if s.blockRequest != nil && request.GetConnection().GetId() == "1" {
+		s.requested <- struct{}{}
+		<-s.blockRequest
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *closeRecorderServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if s.block != nil {
+		select {
+		case <-s.block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	s.closed <- conn.GetId()
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func newServer(recorder *closeRecorderServer) (networkservice.NetworkServiceServer, drain.Server) {
+	var server networkservice.NetworkServiceServer
+	drainServer := drain.NewServer(&server)
+	server = chain.NewNetworkServiceServer(drainServer, recorder)
+	return server, drainServer
+}
+
+func request(id string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: id,
+		},
+	}
+}
+
+func TestDrain(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	recorder := &closeRecorderServer{closed: make(chan string, 10)}
+	server, drainServer := newServer(recorder)
+
+	_, err := server.Request(context.Background(), request("1"))
+	require.NoError(t, err)
+	_, err = server.Request(context.Background(), request("2"))
+	require.NoError(t, err)
+	conn, err := server.Request(context.Background(), request("3"))
+	require.NoError(t, err)
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Equal(t, "3", <-recorder.closed)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, drainServer.Drain(ctx))
+
+	closed := []string{<-recorder.closed, <-recorder.closed}
+	require.ElementsMatch(t, []string{"1", "2"}, closed)
+	require.Empty(t, recorder.closed)
+
+	_, err = server.Request(context.Background(), request("4"))
+	require.Error(t, err)
+	require.Equal(t, codes.Unavailable, status.Code(errors.Cause(err)))
+
+	// Nothing left to close
+	require.NoError(t, drainServer.Drain(ctx))
+	require.Empty(t, recorder.closed)
+}
+
+func TestDrain_Timeout(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	recorder := &closeRecorderServer{closed: make(chan string, 10), block: make(chan struct{})}
+	server, drainServer := newServer(recorder)
+
+	_, err := server.Request(context.Background(), request("1"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.Equal(t, context.DeadlineExceeded, drainServer.Drain(ctx))
+
+	// The next Drain waits for the same connection to be closed
+	close(recorder.block)
+	require.NoError(t, drainServer.Drain(context.Background()))
+}
+
+func TestDrain_InFlightRequest(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	recorder := &closeRecorderServer{
+		closed:       make(chan string, 10),
+		requested:    make(chan struct{}),
+		blockRequest: make(chan struct{}),
+	}
+	server, drainServer := newServer(recorder)
+
+	requestErr := make(chan error, 1)
+	go func() {
+		_, err := server.Request(context.Background(), request("1"))
+		requestErr <- err
+	}()
+	<-recorder.requested
+
+	drainErr := make(chan error, 1)
+	go func() {
+		drainErr <- drainServer.Drain(context.Background())
+	}()
+
+	// New Requests are rejected while the in-flight one is completing
+	require.Eventually(t, func() bool {
+		_, err := server.Request(context.Background(), request("2"))
+		return status.Code(errors.Cause(err)) == codes.Unavailable
+	}, time.Second, 10*time.Millisecond)
+	require.Empty(t, drainErr)
+
+	// Connection established by the in-flight Request is closed by the Drain
+	close(recorder.blockRequest)
+	require.NoError(t, <-requestErr)
+	require.NoError(t, <-drainErr)
+	var closed []string
+	for len(recorder.closed) > 0 {
+		closed = append(closed, <-recorder.closed)
+	}
+	require.Contains(t, closed, "1")
+}
@@ -168,7 +168,8 @@ func (f *healClient) Request(ctx context.Context, request *networkservice.Networ
 			default:
 			}
 			// TODO wrap another span around this
-			_, err := (*f.onHeal).Request(healCtx, req, opts...)
+			// Clone the request, the chain elements modify it and req.Connection can still be in use by the caller
+			_, err := (*f.onHeal).Request(healCtx, req.Clone(), opts...)
 			if err != nil {
 				trace.Log(healCtx).Errorf("Attempt to heal connection %s resulted in error: %+v", req.GetConnection().GetId(), err)
 			}
@@ -0,0 +1,110 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interdomainurl
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clienturl"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/interdomain"
+)
+
+type interdomainURLServer struct {
+	defaultURL *url.URL
+	nseClient  registry.NetworkServiceEndpointRegistryClient
+}
+
+// NewServer - creates a NetworkServiceServer routing interdomain connections. If the Network service endpoint name is
+//             in form "nse@URL" (set by swap registry element), clienturl is set to the URL. Otherwise clienturl is
+//             set to the defaultURL, that is the local network service manager.
+//             The URL is used only if the Network service endpoint with the same name is found via nseClient, so
+//             only the URLs set by swap are dialed. Requests with any other URL are rejected.
+//             Domains are cut from the Network service endpoint name and Network service for the next hop and are
+//             restored for the response.
+func NewServer(defaultURL *url.URL, nseClient registry.NetworkServiceEndpointRegistryClient) networkservice.NetworkServiceServer {
+	return &interdomainURLServer{
+		defaultURL: defaultURL,
+		nseClient:  nseClient,
+	}
+}
+
+func (s *interdomainURLServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn := request.GetConnection()
+	nseName, nsName := conn.GetNetworkServiceEndpointName(), conn.GetNetworkService()
+
+	u, err := s.clientURL(ctx, nseName, nsName)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.NetworkServiceEndpointName, conn.NetworkService = interdomain.Target(nseName), interdomain.Target(nsName)
+	resp, err := next.Server(ctx).Request(clienturl.WithClientURL(ctx, u), request)
+	conn.NetworkServiceEndpointName, conn.NetworkService = nseName, nsName
+	if err != nil {
+		return nil, err
+	}
+
+	resp.NetworkServiceEndpointName, resp.NetworkService = nseName, nsName
+	return resp, nil
+}
+
+func (s *interdomainURLServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	nseName, nsName := conn.GetNetworkServiceEndpointName(), conn.GetNetworkService()
+
+	u, err := s.clientURL(ctx, nseName, nsName)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.NetworkServiceEndpointName, conn.NetworkService = interdomain.Target(nseName), interdomain.Target(nsName)
+	rv, err := next.Server(ctx).Close(clienturl.WithClientURL(ctx, u), conn)
+	conn.NetworkServiceEndpointName, conn.NetworkService = nseName, nsName
+	return rv, err
+}
+
+func (s *interdomainURLServer) clientURL(ctx context.Context, nseName, nsName string) (*url.URL, error) {
+	u := interdomain.URL(nseName)
+	if u == nil {
+		if s.defaultURL == nil {
+			return nil, errors.Errorf("no URL to route the network service endpoint %s", nseName)
+		}
+		return s.defaultURL, nil
+	}
+
+	stream, err := s.nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name:                interdomain.Target(nseName),
+			NetworkServiceNames: []string{nsName},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find the network service endpoint %s", nseName)
+	}
+	for _, nse := range registry.ReadNetworkServiceEndpointList(stream) {
+		if nse.Name == nseName {
+			return u, nil
+		}
+	}
+	return nil, errors.Errorf("network service endpoint %s is not found in the registry", nseName)
+}
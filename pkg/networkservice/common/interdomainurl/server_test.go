@@ -0,0 +1,121 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interdomainurl_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clienturl"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/interdomainurl"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/swap"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	registrynext "github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+)
+
+type urlServer struct {
+	urls []*url.URL
+}
+
+func (s *urlServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	s.urls = append(s.urls, clienturl.ClientURL(ctx))
+	return request.GetConnection(), nil
+}
+
+func (s *urlServer) Close(ctx context.Context, _ *networkservice.Connection) (*empty.Empty, error) {
+	s.urls = append(s.urls, clienturl.ClientURL(ctx))
+	return new(empty.Empty), nil
+}
+
+func newNSEClient(t *testing.T) registry.NetworkServiceEndpointRegistryClient {
+	mem := memory.NewNetworkServiceEndpointRegistryServer()
+	_, err := mem.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns"},
+		Url:                 "tcp://1.1.1.1:5000",
+	})
+	require.NoError(t, err)
+	return adapters.NetworkServiceEndpointServerToClient(registrynext.NewNetworkServiceEndpointRegistryServer(
+		swap.NewNetworkServiceEndpointRegistryServer("domain1", &url.URL{Scheme: "unix", Path: "/proxy.sock"}, nil),
+		mem,
+	))
+}
+
+func TestInterdomainURLServer(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	localURL := &url.URL{Scheme: "unix", Path: "/nsmgr.sock"}
+	captured := &urlServer{}
+	server := chain.NewNetworkServiceServer(interdomainurl.NewServer(localURL, newNSEClient(t)), captured)
+
+	conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			NetworkServiceEndpointName: "nse-1@tcp://1.1.1.1:5000",
+			NetworkService:             "ns@domain2",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "nse-1@tcp://1.1.1.1:5000", conn.GetNetworkServiceEndpointName())
+	require.Equal(t, "ns@domain2", conn.GetNetworkService())
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	_, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			NetworkServiceEndpointName: "nse-2",
+			NetworkService:             "ns",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, captured.urls, 3)
+	require.Equal(t, "tcp://1.1.1.1:5000", captured.urls[0].String())
+	require.Equal(t, "tcp://1.1.1.1:5000", captured.urls[1].String())
+	require.Equal(t, localURL, captured.urls[2])
+}
+
+func TestInterdomainURLServer_UnknownURL(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	captured := &urlServer{}
+	server := chain.NewNetworkServiceServer(
+		interdomainurl.NewServer(&url.URL{Scheme: "unix", Path: "/nsmgr.sock"}, newNSEClient(t)),
+		captured,
+	)
+
+	conn := &networkservice.Connection{
+		NetworkServiceEndpointName: "nse-1@tcp://2.2.2.2:5000",
+		NetworkService:             "ns@domain2",
+	}
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	require.Error(t, err)
+
+	_, err = server.Close(context.Background(), conn)
+	require.Error(t, err)
+
+	require.Empty(t, captured.urls)
+}
@@ -81,6 +81,8 @@ func (m *monitorServer) MonitorConnections(selector *networkservice.MonitorScope
 func (m *monitorServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	conn, err := next.Server(ctx).Request(ctx, request)
 	if err == nil {
+		// Connection can be changed by the previous chain elements after we return, so clone it
+		conn := conn.Clone()
 		m.executor.AsyncExec(func() {
 			m.connections[conn.GetId()] = conn
 			// Send update event
@@ -98,11 +100,12 @@ func (m *monitorServer) Request(ctx context.Context, request *networkservice.Net
 
 func (m *monitorServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
 	// Remove connection object we have and send DELETE
+	deleted := conn.Clone()
 	m.executor.AsyncExec(func() {
-		delete(m.connections, conn.GetId())
+		delete(m.connections, deleted.GetId())
 		event := &networkservice.ConnectionEvent{
 			Type:        networkservice.ConnectionEventType_DELETE,
-			Connections: map[string]*networkservice.Connection{conn.GetId(): conn},
+			Connections: map[string]*networkservice.Connection{deleted.GetId(): deleted},
 		}
 		if err := m.send(ctx, event); err != nil {
 			trace.Log(ctx).Errorf("Error during sending event: %v", err)
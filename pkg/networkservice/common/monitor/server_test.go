@@ -104,3 +104,44 @@ func TestMonitor(t *testing.T) {
 		assert.Equal(t, segmentName, event.GetConnections()[segmentName].GetPath().GetPathSegments()[0].GetName())
 	}
 }
+
+func TestMonitor_ChangedConnection(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var monitorServer networkservice.MonitorConnectionServer
+	server := monitor.NewServer(&monitorServer)
+	monitorClient := adapters.NewMonitorServerToClient(monitorServer)
+
+	conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "id",
+			NetworkService: "ns",
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: "nsm"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	// The previous chain elements can change the returned connection
+	conn.NetworkService = "changed"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	receiver, err := monitorClient.MonitorConnections(ctx, &networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{{Name: "nsm"}},
+	})
+	require.NoError(t, err)
+	event, err := receiver.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+	require.Equal(t, "ns", event.GetConnections()["id"].GetNetworkService())
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	conn.NetworkService = "closed"
+
+	event, err = receiver.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_DELETE, event.GetType())
+	require.Equal(t, "changed", event.GetConnections()["id"].GetNetworkService())
+}
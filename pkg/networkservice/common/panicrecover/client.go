@@ -0,0 +1,45 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panicrecover
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type recoverClient struct{}
+
+// NewClient - creates a new NetworkServiceClient chain element converting panics in the next chain elements to
+//             Internal errors. Stack trace of the panic is written to the trace log.
+func NewClient() networkservice.NetworkServiceClient {
+	return &recoverClient{}
+}
+
+func (r *recoverClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (conn *networkservice.Connection, err error) {
+	defer recoverPanic(ctx, "Request", &err)
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (r *recoverClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (_ *empty.Empty, err error) {
+	defer recoverPanic(ctx, "Close", &err)
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panicrecover_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/panicrecover"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+)
+
+type panicClient struct{}
+
+func (c *panicClient) Request(context.Context, *networkservice.NetworkServiceRequest, ...grpc.CallOption) (*networkservice.Connection, error) {
+	var conn *networkservice.Connection
+	// nil pointer dereference
+	conn.Id = "id"
+	return conn, nil
+}
+
+func (c *panicClient) Close(context.Context, *networkservice.Connection, ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func TestRecoverClient(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	client := chain.NewNetworkServiceClient(
+		panicrecover.NewClient(),
+		&panicClient{},
+	)
+
+	conn, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{})
+	require.Nil(t, conn)
+	require.Error(t, err)
+	require.Equal(t, codes.Internal, status.Code(errors.Cause(err)))
+
+	_, err = client.Close(context.Background(), &networkservice.Connection{})
+	require.NoError(t, err)
+}
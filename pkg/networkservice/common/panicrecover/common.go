@@ -0,0 +1,35 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panicrecover
+
+import (
+	"context"
+	"runtime/debug"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+)
+
+// recoverPanic - converts panic to Internal error, should be deferred directly in the recovered method
+func recoverPanic(ctx context.Context, operation string, err *error) {
+	if r := recover(); r != nil {
+		trace.Log(ctx).Errorf("Recovered from panic in %s: %v\n%s", operation, r, debug.Stack())
+		*err = status.Errorf(codes.Internal, "panic in %s: %v", operation, r)
+	}
+}
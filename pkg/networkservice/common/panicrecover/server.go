@@ -0,0 +1,45 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package panicrecover provides chain elements converting panics in the next chain elements to Internal errors
+package panicrecover
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type recoverServer struct{}
+
+// NewServer - creates a new NetworkServiceServer chain element converting panics in the next chain elements to
+//             Internal errors. Stack trace of the panic is written to the trace log.
+func NewServer() networkservice.NetworkServiceServer {
+	return &recoverServer{}
+}
+
+func (r *recoverServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (conn *networkservice.Connection, err error) {
+	defer recoverPanic(ctx, "Request", &err)
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (r *recoverServer) Close(ctx context.Context, conn *networkservice.Connection) (_ *empty.Empty, err error) {
+	defer recoverPanic(ctx, "Close", &err)
+	return next.Server(ctx).Close(ctx, conn)
+}
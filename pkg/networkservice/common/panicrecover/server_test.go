@@ -0,0 +1,63 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panicrecover_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/panicrecover"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+)
+
+type panicServer struct{}
+
+func (s *panicServer) Request(context.Context, *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	panic("request panic")
+}
+
+func (s *panicServer) Close(context.Context, *networkservice.Connection) (*empty.Empty, error) {
+	panic(errors.New("close panic"))
+}
+
+func TestRecoverServer(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	server := chain.NewNetworkServiceServer(
+		panicrecover.NewServer(),
+		&panicServer{},
+	)
+
+	conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{})
+	require.Nil(t, conn)
+	require.Error(t, err)
+	require.Equal(t, codes.Internal, status.Code(errors.Cause(err)))
+	require.Contains(t, err.Error(), "request panic")
+
+	_, err = server.Close(context.Background(), &networkservice.Connection{})
+	require.Error(t, err)
+	require.Equal(t, codes.Internal, status.Code(errors.Cause(err)))
+	require.Contains(t, err.Error(), "close panic")
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// KeyFunc - returns the key the limits are tracked for. Requests with the same key share the limits, so empty key
+//           is a valid key shared by all the Requests having no value for it
+type KeyFunc func(ctx context.Context, conn *networkservice.Connection) string
+
+// BySpiffeID - returns KeyFunc keying the limits by the SPIFFE ID of the peer certificate
+func BySpiffeID() KeyFunc {
+	return func(ctx context.Context, _ *networkservice.Connection) string {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return ""
+		}
+		var tlsInfo credentials.TLSInfo
+		switch v := p.AuthInfo.(type) {
+		case credentials.TLSInfo:
+			tlsInfo = v
+		case *credentials.TLSInfo:
+			tlsInfo = *v
+		default:
+			return ""
+		}
+		if len(tlsInfo.State.PeerCertificates) == 0 {
+			return ""
+		}
+		id, err := x509svid.IDFromCert(tlsInfo.State.PeerCertificates[0])
+		if err != nil {
+			return ""
+		}
+		return id.String()
+	}
+}
+
+// ByLabel - returns KeyFunc keying the limits by the value of the connection label
+func ByLabel(name string) KeyFunc {
+	return func(_ context.Context, conn *networkservice.Connection) string {
+		return conn.GetLabels()[name]
+	}
+}
+
+// ByNetworkService - returns KeyFunc keying the limits by the network service name
+func ByNetworkService() KeyFunc {
+	return func(_ context.Context, conn *networkservice.Connection) string {
+		return conn.GetNetworkService()
+	}
+}
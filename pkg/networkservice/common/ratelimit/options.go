@@ -0,0 +1,84 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type configurable interface {
+	setKeyFunc(KeyFunc)
+	setRate(rate float64, burst int)
+	setMaxConnections(int)
+	setIdleTimeout(time.Duration)
+	setClock(clock.Clock)
+}
+
+// Option is rate limiting server configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithKeyFunc sets the function computing the key the limits are tracked for, by default BySpiffeID()
+func WithKeyFunc(keyFunc KeyFunc) Option {
+	return applierFunc(func(c configurable) {
+		c.setKeyFunc(keyFunc)
+	})
+}
+
+// WithRate sets the token bucket rate limit of Requests (including refreshes) per key: rate Requests per second with
+// bursts of up to burst Requests, by default 10 per second with bursts of 50. Zero rate disables the rate limit
+func WithRate(rate float64, burst int) Option {
+	return applierFunc(func(c configurable) {
+		c.setRate(rate, burst)
+	})
+}
+
+// WithRatePer is the same as WithRate, but the rate is set as count Requests per interval
+func WithRatePer(count int, interval time.Duration, burst int) Option {
+	return WithRate(float64(count)/interval.Seconds(), burst)
+}
+
+// WithMaxConnections sets the maximum number of concurrent connections per key, by default 0 - unlimited
+func WithMaxConnections(maxConnections int) Option {
+	return applierFunc(func(c configurable) {
+		c.setMaxConnections(maxConnections)
+	})
+}
+
+// WithIdleTimeout sets the time after which the limits of the key without connections are forgotten, by default 10
+// minutes. It should be long enough for the key's bucket to refill, otherwise the forgotten key gets a full burst again
+func WithIdleTimeout(idleTimeout time.Duration) Option {
+	return applierFunc(func(c configurable) {
+		c.setIdleTimeout(idleTimeout)
+	})
+}
+
+// WithClock sets the clock used to refill the buckets and to forget the idle keys, by default the real clock is used
+func WithClock(c clock.Clock) Option {
+	return applierFunc(func(cfg configurable) {
+		cfg.setClock(c)
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides a chain element limiting the rate of Requests and the number of concurrent connections
+// per key (peer SPIFFE ID, connection label, network service name). It should be placed after setid, so the refreshes
+// and Closes have the same connection ID as the initial Request, e.g. passed to endpoint.NewServer as additional
+// functionality:
+//   endpoint.NewServer(ctx, name, authorize.NewServer(), tokenGenerator, ratelimit.NewServer(), ...)
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	defaultRate        = 10
+	defaultBurst       = 50
+	defaultIdleTimeout = 10 * time.Minute
+)
+
+type limit struct {
+	tokens      float64
+	updated     time.Time
+	used        time.Time
+	connections int
+}
+
+type rateLimitServer struct {
+	keyFunc        KeyFunc
+	rate           float64
+	burst          int
+	maxConnections int
+	idleTimeout    time.Duration
+	clock          clock.Clock
+
+	limits map[string]*limit
+	// keys - keys of the established connections by connection ID, so refreshes and Closes are accounted to the same key
+	keys      map[string]string
+	lastSweep time.Time
+	mutex     sync.Mutex
+}
+
+// NewServer - creates a new NetworkServiceServer chain element limiting the rate of Requests and the number of
+//             concurrent connections per key. Violations are returned as ResourceExhausted errors.
+//             - every Request (including refresh) takes a token from the key's bucket
+//             - new connection is counted against the key's connection quota until it is Closed or its initial
+//               Request fails; refreshes and Closes use the key computed on the initial Request
+//             - Close is never limited, so clients can always release their connections
+//             - limits of the key without connections are forgotten after being unused for the idle timeout
+//             Should be placed after setid and before the chain elements doing actual work.
+//             - options - configuration options
+func NewServer(options ...Option) networkservice.NetworkServiceServer {
+	s := &rateLimitServer{
+		keyFunc:     BySpiffeID(),
+		rate:        defaultRate,
+		burst:       defaultBurst,
+		idleTimeout: defaultIdleTimeout,
+		limits:      make(map[string]*limit),
+		keys:        make(map[string]string),
+		clock:       clock.New(),
+	}
+	for _, o := range options {
+		o.apply(s)
+	}
+	s.lastSweep = s.clock.Now()
+	return s
+}
+
+func (s *rateLimitServer) setKeyFunc(keyFunc KeyFunc) {
+	s.keyFunc = keyFunc
+}
+
+func (s *rateLimitServer) setRate(rate float64, burst int) {
+	s.rate = rate
+	s.burst = burst
+}
+
+func (s *rateLimitServer) setMaxConnections(maxConnections int) {
+	s.maxConnections = maxConnections
+}
+
+func (s *rateLimitServer) setIdleTimeout(idleTimeout time.Duration) {
+	s.idleTimeout = idleTimeout
+}
+
+func (s *rateLimitServer) setClock(c clock.Clock) {
+	s.clock = c
+}
+
+func (s *rateLimitServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	connID := request.GetConnection().GetId()
+	key, isNew, err := s.acquire(ctx, request.GetConnection())
+	if err != nil {
+		trace.Log(ctx).Warnf("Request %s rejected: %s", connID, err.Error())
+		return nil, err
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if isNew {
+			s.release(connID, key)
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (s *rateLimitServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.mutex.Lock()
+	key, ok := s.keys[conn.GetId()]
+	s.mutex.Unlock()
+	if ok {
+		defer s.release(conn.GetId(), key)
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// acquire - takes a token from the bucket of the connection key and reserves a connection quota for the new connection
+func (s *rateLimitServer) acquire(ctx context.Context, conn *networkservice.Connection) (key string, isNew bool, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.clock.Now()
+	s.sweep(now)
+
+	key, ok := s.keys[conn.GetId()]
+	if !ok {
+		key = s.keyFunc(ctx, conn)
+	}
+	l, ok := s.limits[key]
+	if !ok {
+		l = &limit{
+			tokens:  float64(s.burst),
+			updated: now,
+		}
+		s.limits[key] = l
+	}
+	l.used = now
+
+	_, exists := s.keys[conn.GetId()]
+	if !exists && s.maxConnections > 0 && l.connections >= s.maxConnections {
+		return "", false, status.Errorf(codes.ResourceExhausted, "connections limit %d is reached for %q", s.maxConnections, key)
+	}
+	if s.rate > 0 {
+		l.tokens = math.Min(float64(s.burst), l.tokens+now.Sub(l.updated).Seconds()*s.rate)
+		l.updated = now
+		if l.tokens < 1 {
+			return "", false, status.Errorf(codes.ResourceExhausted, "rate limit %v requests per second is exceeded for %q", s.rate, key)
+		}
+		l.tokens--
+	}
+	if !exists {
+		l.connections++
+		s.keys[conn.GetId()] = key
+	}
+	return key, !exists, nil
+}
+
+// release - releases the connection quota, forgets the key limits when it has no connections and its bucket is full
+func (s *rateLimitServer) release(connID, key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.keys[connID] != key {
+		return
+	}
+	delete(s.keys, connID)
+	l := s.limits[key]
+	l.connections--
+	if l.connections == 0 && (s.rate <= 0 || l.tokens+s.clock.Since(l.updated).Seconds()*s.rate >= float64(s.burst)) {
+		delete(s.limits, key)
+	}
+}
+
+// sweep - forgets the limits of the keys without connections unused for the idle timeout, so the keys rejected or
+// seen only once don't accumulate. Runs at most once per idle timeout.
+func (s *rateLimitServer) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.idleTimeout {
+		return
+	}
+	s.lastSweep = now
+	for key, l := range s.limits {
+		if l.connections == 0 && now.Sub(l.used) >= s.idleTimeout {
+			delete(s.limits, key)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/ratelimit"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+func request(id, tenant string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:     id,
+			Labels: map[string]string{"tenant": tenant},
+		},
+	}
+}
+
+func requireResourceExhausted(t *testing.T, err error) {
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestRateLimit(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	server := ratelimit.NewServer(
+		ratelimit.WithKeyFunc(ratelimit.ByLabel("tenant")),
+		ratelimit.WithRatePer(1, time.Hour, 2))
+
+	_, err := server.Request(context.Background(), request("1", "a"))
+	require.NoError(t, err)
+	// Refresh takes a token too
+	_, err = server.Request(context.Background(), request("1", "a"))
+	require.NoError(t, err)
+	_, err = server.Request(context.Background(), request("1", "a"))
+	requireResourceExhausted(t, err)
+	_, err = server.Request(context.Background(), request("2", "a"))
+	requireResourceExhausted(t, err)
+
+	// Other key has its own bucket
+	_, err = server.Request(context.Background(), request("3", "b"))
+	require.NoError(t, err)
+
+	// Close is never limited
+	_, err = server.Close(context.Background(), request("1", "a").GetConnection())
+	require.NoError(t, err)
+}
+
+func TestRateLimit_Refill(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	clk := clock.NewFake(time.Now())
+	server := ratelimit.NewServer(ratelimit.WithRatePer(1, time.Minute, 1), ratelimit.WithClock(clk))
+
+	_, err := server.Request(context.Background(), request("1", ""))
+	require.NoError(t, err)
+	_, err = server.Request(context.Background(), request("1", ""))
+	requireResourceExhausted(t, err)
+
+	clk.Add(time.Minute / 2)
+	_, err = server.Request(context.Background(), request("1", ""))
+	requireResourceExhausted(t, err)
+
+	clk.Add(time.Minute / 2)
+	_, err = server.Request(context.Background(), request("1", ""))
+	require.NoError(t, err)
+}
+
+func TestRateLimit_IdleTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	clk := clock.NewFake(time.Now())
+	server := ratelimit.NewServer(
+		ratelimit.WithKeyFunc(ratelimit.ByLabel("tenant")),
+		ratelimit.WithRatePer(1, time.Hour, 1),
+		ratelimit.WithIdleTimeout(time.Minute),
+		ratelimit.WithClock(clk))
+
+	_, err := server.Request(context.Background(), request("1", "a"))
+	require.NoError(t, err)
+	_, err = server.Close(context.Background(), request("1", "a").GetConnection())
+	require.NoError(t, err)
+
+	_, err = server.Request(context.Background(), request("2", "a"))
+	requireResourceExhausted(t, err)
+
+	// Limits of the idle key without connections are forgotten
+	clk.Add(time.Minute)
+
+	_, err = server.Request(context.Background(), request("3", "a"))
+	require.NoError(t, err)
+}
+
+func TestMaxConnections(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	server := ratelimit.NewServer(
+		ratelimit.WithKeyFunc(ratelimit.ByLabel("tenant")),
+		ratelimit.WithRate(0, 0),
+		ratelimit.WithMaxConnections(2))
+
+	_, err := server.Request(context.Background(), request("1", "a"))
+	require.NoError(t, err)
+	_, err = server.Request(context.Background(), request("2", "a"))
+	require.NoError(t, err)
+	_, err = server.Request(context.Background(), request("3", "a"))
+	requireResourceExhausted(t, err)
+
+	// Refresh is not a new connection, key is kept even if the label has changed
+	_, err = server.Request(context.Background(), request("1", "b"))
+	require.NoError(t, err)
+	_, err = server.Request(context.Background(), request("4", "b"))
+	require.NoError(t, err)
+
+	_, err = server.Close(context.Background(), request("1", "b").GetConnection())
+	require.NoError(t, err)
+	_, err = server.Request(context.Background(), request("3", "a"))
+	require.NoError(t, err)
+}
+
+func TestMaxConnections_FailedRequest(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	server := chain.NewNetworkServiceServer(
+		ratelimit.NewServer(
+			ratelimit.WithRate(0, 0),
+			ratelimit.WithMaxConnections(1)),
+		injecterror.NewServer(status.Error(codes.Unavailable, "unavailable")))
+
+	for i := 0; i < 3; i++ {
+		_, err := server.Request(context.Background(), request("1", ""))
+		require.Error(t, err)
+		require.Equal(t, codes.Unavailable, status.Code(errors.Cause(err)))
+	}
+}
@@ -20,7 +20,6 @@ package refresh
 
 import (
 	"context"
-	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -29,24 +28,30 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 	"github.com/networkservicemesh/sdk/pkg/tools/extend"
 	"github.com/networkservicemesh/sdk/pkg/tools/serialize"
 )
 
 type refreshClient struct {
 	ctx      context.Context
-	timers   map[string]*time.Timer        // key == request.GetConnection.GetId()
+	timers   map[string]clock.Timer        // key == request.GetConnection.GetId()
 	cancels  map[string]context.CancelFunc // key == request.GetConnection.GetId()
 	executor serialize.Executor
+	clock    clock.Clock
 }
 
 // NewClient - creates new NetworkServiceClient chain element for refreshing connections before they timeout at the
 // endpoint
-func NewClient(ctx context.Context) networkservice.NetworkServiceClient {
+func NewClient(ctx context.Context, options ...Option) networkservice.NetworkServiceClient {
 	rv := &refreshClient{
 		ctx:     ctx,
-		timers:  make(map[string]*time.Timer),
+		timers:  make(map[string]clock.Timer),
 		cancels: make(map[string]context.CancelFunc),
+		clock:   clock.New(),
+	}
+	for _, o := range options {
+		o.apply(rv)
 	}
 	return rv
 }
@@ -68,7 +73,8 @@ func (t *refreshClient) Request(ctx context.Context, request *networkservice.Net
 	refreshRequest.GetConnection().NetworkServiceEndpointName = rv.NetworkServiceEndpointName
 
 	// TODO - introduce random noise into duration avoid timer lock
-	duration := time.Until(expireTime) / 3
+	duration := t.clock.Until(expireTime) / 3
+	id := request.GetConnection().GetId()
 	t.executor.AsyncExec(func() {
 		// Create the refresh context
 		var cancel context.CancelFunc
@@ -78,16 +84,16 @@ func (t *refreshClient) Request(ctx context.Context, request *networkservice.Net
 		}
 
 		// Stop any existing timers
-		if timer, ok := t.timers[request.GetConnection().GetId()]; ok {
+		if timer, ok := t.timers[id]; ok {
 			timer.Stop()
 		}
 
 		// Set new timer
-		var timer *time.Timer
-		timer = time.AfterFunc(duration, func() {
+		var timer clock.Timer
+		timer = t.clock.AfterFunc(duration, func() {
 			<-t.executor.AsyncExec(func() {
 				// Check to see if we've been superseded by another timer, if so, do nothing
-				currentTimer, ok := t.timers[refreshRequest.GetConnection().GetId()]
+				currentTimer, ok := t.timers[id]
 				if ok && currentTimer != timer {
 					cancel()
 					return
@@ -100,14 +106,14 @@ func (t *refreshClient) Request(ctx context.Context, request *networkservice.Net
 			default:
 				if _, err := t.Request(refreshCtx, refreshRequest, opts...); err != nil {
 					// TODO - do we want to retry at 2/3 and 3/3 if we fail here?
-					trace.Log(refreshCtx).Errorf("Error while attempting to refresh connection %s: %+v", request.GetConnection().GetId(), err)
+					trace.Log(refreshCtx).Errorf("Error while attempting to refresh connection %s: %+v", id, err)
 				}
 			}
 			// Set timer to nil to be really really sure we don't have a circular reference that precludes garbage collection
 			timer = nil
 		})
-		t.timers[request.GetConnection().GetId()] = timer
-		t.cancels[request.GetConnection().GetId()] = cancel
+		t.timers[id] = timer
+		t.cancels[id] = cancel
 	})
 	return rv, nil
 }
@@ -26,20 +26,18 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/refresh"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 const (
-	expireTimeout        = 100 * time.Millisecond
-	waitForTimeout       = expireTimeout
-	tickTimeout          = 10 * time.Millisecond
+	expireTimeout        = time.Minute
 	refreshCount         = 5
-	expectAbsenceTimeout = 5 * expireTimeout
+	expectAbsenceTimeout = 100 * time.Millisecond
 
 	requestNumber contextKeyType = "RequestNumber"
 )
@@ -72,8 +70,8 @@ func (t *testRefresh) Close(context.Context, *networkservice.Connection, ...grpc
 	return &empty.Empty{}, nil
 }
 
-func setExpires(conn *networkservice.Connection, expireTimeout time.Duration) {
-	expireTime := time.Now().Add(expireTimeout)
+func setExpires(conn *networkservice.Connection, now time.Time, expireTimeout time.Duration) {
+	expireTime := now.Add(expireTimeout)
 	expires := &timestamp.Timestamp{
 		Seconds: expireTime.Unix(),
 		Nanos:   int32(expireTime.Nanosecond()),
@@ -88,68 +86,63 @@ func setExpires(conn *networkservice.Connection, expireTimeout time.Duration) {
 	}
 }
 
-func hasValue(c <-chan struct{}) bool {
-	select {
-	case <-c:
-		return true
-	default:
-		return false
+// requireRefreshes - advances clk to the refresh time refreshCount times, each time waiting for the refresh Request
+func requireRefreshes(clk *clock.Fake, requestCh <-chan struct{}) {
+	for i := 0; i < refreshCount; i++ {
+		clk.BlockUntil(1)
+		clk.Add(expireTimeout)
+		<-requestCh
 	}
 }
 
-func firstGetsValueEarlier(c1, c2 <-chan struct{}) bool {
+// requireNoRefreshes - advances clk past the refresh time and checks there is no refresh Request
+func requireNoRefreshes(t *testing.T, clk *clock.Fake, requestCh <-chan struct{}) {
+	clk.Add(expireTimeout)
 	select {
-	case <-c2:
-		return false
-	case <-c1:
-		return true
+	case <-requestCh:
+		require.FailNow(t, "unexpected refresh")
+	case <-time.After(expectAbsenceTimeout):
 	}
 }
 
 func TestNewClient_StopRefreshAtClose(t *testing.T) {
-	t.Skip("https://github.com/networkservicemesh/sdk/issues/237")
 	defer goleak.VerifyNone(t)
+	clk := clock.NewFake(time.Now())
 	requestCh := make(chan struct{}, 1)
 	testRefresh := &testRefresh{
 		RequestFunc: func(ctx context.Context, in *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (connection *networkservice.Connection, err error) {
-			setExpires(in.GetConnection(), expireTimeout)
+			setExpires(in.GetConnection(), clk.Now(), expireTimeout)
 			requestCh <- struct{}{}
 			return in.GetConnection(), nil
 		},
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	client := next.NewNetworkServiceClient(refresh.NewClient(ctx), testRefresh)
+	client := next.NewNetworkServiceClient(refresh.NewClient(ctx, refresh.WithClock(clk)), testRefresh)
 	request := &networkservice.NetworkServiceRequest{
 		Connection: &networkservice.Connection{
 			Id: "conn-1",
 		},
 	}
 	conn, err := client.Request(context.Background(), request)
-	assert.Nil(t, err)
+	require.NoError(t, err)
+	<-requestCh // receive value from initial request
 
-	assert.True(t, hasValue(requestCh)) // receive value from initial request
-	for i := 0; i < refreshCount; i++ {
-		require.Eventually(t, func() bool { return hasValue(requestCh) }, waitForTimeout, tickTimeout)
-	}
+	requireRefreshes(clk, requestCh)
 
 	_, err = client.Close(context.Background(), conn)
-	assert.Nil(t, err)
+	require.NoError(t, err)
 
-	absence := make(chan struct{})
-	time.AfterFunc(expectAbsenceTimeout, func() {
-		absence <- struct{}{}
-	})
-	assert.True(t, firstGetsValueEarlier(absence, requestCh))
+	requireNoRefreshes(t, clk, requestCh)
 }
 
 func TestNewClient_StopRefreshAtAnotherRequest(t *testing.T) {
-	t.Skip("https://github.com/networkservicemesh/sdk/issues/260")
 	defer goleak.VerifyNone(t)
+	clk := clock.NewFake(time.Now())
 	requestCh := make(chan struct{}, 1)
 	testRefresh := &testRefresh{
 		RequestFunc: func(ctx context.Context, in *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (connection *networkservice.Connection, err error) {
-			setExpires(in.GetConnection(), expireTimeout)
+			setExpires(in.GetConnection(), clk.Now(), expireTimeout)
 			if getRequestNumber(ctx) == 1 {
 				requestCh <- struct{}{}
 			}
@@ -158,7 +151,7 @@ func TestNewClient_StopRefreshAtAnotherRequest(t *testing.T) {
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	client := next.NewNetworkServiceClient(refresh.NewClient(ctx), testRefresh)
+	client := next.NewNetworkServiceClient(refresh.NewClient(ctx, refresh.WithClock(clk)), testRefresh)
 
 	request1 := &networkservice.NetworkServiceRequest{
 		Connection: &networkservice.Connection{
@@ -166,12 +159,10 @@ func TestNewClient_StopRefreshAtAnotherRequest(t *testing.T) {
 		},
 	}
 	_, err := client.Request(withRequestNumber(context.Background(), 1), request1)
-	assert.Nil(t, err)
+	require.NoError(t, err)
+	<-requestCh // receive value from initial request
 
-	assert.True(t, hasValue(requestCh)) // receive value from initial request
-	for i := 0; i < refreshCount; i++ {
-		require.Eventually(t, func() bool { return hasValue(requestCh) }, waitForTimeout, tickTimeout)
-	}
+	requireRefreshes(clk, requestCh)
 
 	request2 := &networkservice.NetworkServiceRequest{
 		Connection: &networkservice.Connection{
@@ -179,14 +170,42 @@ func TestNewClient_StopRefreshAtAnotherRequest(t *testing.T) {
 		},
 	}
 	conn, err := client.Request(withRequestNumber(context.Background(), 2), request2)
-	assert.Nil(t, err)
+	require.NoError(t, err)
+
+	requireNoRefreshes(t, clk, requestCh)
 
-	absence := make(chan struct{})
-	time.AfterFunc(expectAbsenceTimeout, func() {
-		absence <- struct{}{}
-	})
-	assert.True(t, firstGetsValueEarlier(absence, requestCh))
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+}
+
+func TestNewClient_ChangedRequestID(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	clk := clock.NewFake(time.Now())
+	requestCh := make(chan struct{}, 1)
+	testRefresh := &testRefresh{
+		RequestFunc: func(ctx context.Context, in *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (connection *networkservice.Connection, err error) {
+			setExpires(in.GetConnection(), clk.Now(), expireTimeout)
+			requestCh <- struct{}{}
+			return in.GetConnection().Clone(), nil
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := next.NewNetworkServiceClient(refresh.NewClient(ctx, refresh.WithClock(clk)), testRefresh)
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "conn-1",
+		},
+	}
+	conn, err := client.Request(context.Background(), request)
+	// The caller can reuse the request after it returns
+	request.GetConnection().Id = "conn-2"
+	require.NoError(t, err)
+	<-requestCh // receive value from initial request
 
+	clk.BlockUntil(1)
 	_, err = client.Close(context.Background(), conn)
-	assert.Nil(t, err)
+	require.NoError(t, err)
+
+	requireNoRefreshes(t, clk, requestCh)
 }
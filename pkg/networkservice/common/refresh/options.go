@@ -0,0 +1,37 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refresh
+
+import "github.com/networkservicemesh/sdk/pkg/tools/clock"
+
+// Option is refresh client configuration option
+type Option interface {
+	apply(*refreshClient)
+}
+
+type applierFunc func(*refreshClient)
+
+func (f applierFunc) apply(t *refreshClient) {
+	f(t)
+}
+
+// WithClock sets the clock used to schedule the refresh requests, by default the real clock is used
+func WithClock(c clock.Clock) Option {
+	return applierFunc(func(t *refreshClient) {
+		t.clock = c
+	})
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry provides chain elements retrying Request and Close on the transient gRPC errors with jittered
+// exponential backoff. retry.NewClient() can be passed to client.NewClient as additional functionality to retry
+// the calls to the endpoint
+package retry
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type retryClient struct {
+	*retrier
+}
+
+// NewClient - creates a new NetworkServiceClient chain element retrying Request and Close of the next chain elements.
+//             Request and Close have separate attempts budgets, each attempt is made with a copy of the original
+//             request or connection
+//             - options - configuration options
+func NewClient(options ...Option) networkservice.NetworkServiceClient {
+	return &retryClient{
+		retrier: newRetrier(options...),
+	}
+}
+
+func (r *retryClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (conn *networkservice.Connection, err error) {
+	err = r.do(ctx, "Request", func() (attemptErr error) {
+		conn, attemptErr = next.Client(ctx).Request(ctx, request.Clone(), opts...)
+		return attemptErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (r *retryClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (rv *empty.Empty, err error) {
+	err = r.do(ctx, "Close", func() (attemptErr error) {
+		rv, attemptErr = next.Client(ctx).Close(ctx, conn.Clone(), opts...)
+		return attemptErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/retry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+const backoff = 10 * time.Millisecond
+
+// failingClient - fails the first failures calls of Request and Close with err
+type failingClient struct {
+	err             error
+	failures        int
+	requestAttempts int
+	closeAttempts   int
+}
+
+func (c *failingClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	c.requestAttempts++
+	// Mutate the request to check that every attempt gets a clean copy
+	request.GetConnection().Id += "-mutated"
+	if c.requestAttempts <= c.failures {
+		return nil, c.err
+	}
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *failingClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.closeAttempts++
+	if c.closeAttempts <= c.failures {
+		return nil, c.err
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func request() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+		},
+	}
+}
+
+func TestRetryClient(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	failing := &failingClient{
+		err:      errors.Wrap(status.Error(codes.Unavailable, "unavailable"), "wrapped"),
+		failures: 2,
+	}
+	client := chain.NewNetworkServiceClient(retry.NewClient(retry.WithBackoff(backoff, backoff)), failing)
+
+	conn, err := client.Request(context.Background(), request())
+	require.NoError(t, err)
+	require.Equal(t, "id-mutated", conn.GetId())
+	require.Equal(t, 3, failing.requestAttempts)
+
+	// Close has its own attempts budget
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Equal(t, 3, failing.closeAttempts)
+}
+
+func TestRetryClient_NotRetryable(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	failing := &failingClient{
+		err:      status.Error(codes.PermissionDenied, "permission denied"),
+		failures: 1,
+	}
+	client := chain.NewNetworkServiceClient(retry.NewClient(retry.WithBackoff(backoff, backoff)), failing)
+
+	_, err := client.Request(context.Background(), request())
+	require.Error(t, err)
+	require.Equal(t, 1, failing.requestAttempts)
+
+	// Overloaded or rate limiting server is not retried by default
+	failing = &failingClient{
+		err:      status.Error(codes.ResourceExhausted, "rate limit exceeded"),
+		failures: 1,
+	}
+	client = chain.NewNetworkServiceClient(retry.NewClient(retry.WithBackoff(backoff, backoff)), failing)
+
+	_, err = client.Request(context.Background(), request())
+	require.Error(t, err)
+	require.Equal(t, 1, failing.requestAttempts)
+
+	// Code is configured to be retryable
+	failing = &failingClient{
+		err:      status.Error(codes.PermissionDenied, "permission denied"),
+		failures: 1,
+	}
+	client = chain.NewNetworkServiceClient(retry.NewClient(retry.WithBackoff(backoff, backoff), retry.WithCodes(codes.PermissionDenied)), failing)
+
+	_, err = client.Request(context.Background(), request())
+	require.NoError(t, err)
+	require.Equal(t, 2, failing.requestAttempts)
+}
+
+func TestRetryClient_MaxAttempts(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	failing := &failingClient{
+		err:      status.Error(codes.Unavailable, "unavailable"),
+		failures: 10,
+	}
+	client := chain.NewNetworkServiceClient(retry.NewClient(retry.WithBackoff(backoff, backoff), retry.WithMaxAttempts(3)), failing)
+
+	_, err := client.Request(context.Background(), request())
+	require.Error(t, err)
+	require.Equal(t, codes.Unavailable, status.Code(errors.Cause(err)))
+	require.Equal(t, 3, failing.requestAttempts)
+}
+
+func TestRetryClient_Deadline(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	failing := &failingClient{
+		err:      status.Error(codes.Unavailable, "unavailable"),
+		failures: 10,
+	}
+	client := chain.NewNetworkServiceClient(retry.NewClient(retry.WithBackoff(time.Second, time.Second)), failing)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Request(ctx, request())
+	require.Error(t, err)
+	require.Equal(t, 1, failing.requestAttempts)
+	require.Less(t, int64(time.Since(start)), int64(100*time.Millisecond))
+}
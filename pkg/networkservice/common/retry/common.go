@@ -0,0 +1,101 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+)
+
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+)
+
+type retrier struct {
+	codes          map[codes.Code]bool
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func newRetrier(options ...Option) *retrier {
+	r := &retrier{
+		maxAttempts:    defaultMaxAttempts,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+	}
+	r.setCodes([]codes.Code{codes.Unavailable, codes.Aborted})
+	for _, o := range options {
+		o.apply(r)
+	}
+	return r
+}
+
+func (r *retrier) setCodes(retryableCodes []codes.Code) {
+	r.codes = make(map[codes.Code]bool, len(retryableCodes))
+	for _, code := range retryableCodes {
+		r.codes[code] = true
+	}
+}
+
+func (r *retrier) setMaxAttempts(maxAttempts int) {
+	r.maxAttempts = maxAttempts
+}
+
+func (r *retrier) setBackoff(initial, max time.Duration) {
+	r.initialBackoff = initial
+	r.maxBackoff = max
+}
+
+// do - calls f until it succeeds, fails with not retryable error, runs out of attempts or there is no time left
+//      before the ctx deadline for the next attempt
+func (r *retrier) do(ctx context.Context, name string, f func() error) error {
+	backoff := r.initialBackoff
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= r.maxAttempts || !r.codes[status.Code(errors.Cause(err))] {
+			return err
+		}
+
+		delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return err
+		}
+		trace.Log(ctx).Warnf("%s attempt %d failed, retrying in %s: %+v", name, attempt, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		if backoff *= 2; backoff > r.maxBackoff {
+			backoff = r.maxBackoff
+		}
+	}
+}
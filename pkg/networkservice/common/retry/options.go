@@ -0,0 +1,63 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+type configurable interface {
+	setCodes([]codes.Code)
+	setMaxAttempts(int)
+	setBackoff(initial, max time.Duration)
+}
+
+// Option is retry chain elements configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithCodes sets gRPC codes of the errors to retry, by default Unavailable and Aborted. ResourceExhausted is not
+// retried by default, since the server could be overloaded or rate limit the client
+func WithCodes(retryableCodes ...codes.Code) Option {
+	return applierFunc(func(c configurable) {
+		c.setCodes(retryableCodes)
+	})
+}
+
+// WithMaxAttempts sets the number of attempts for each Request and Close including the first one, by default 5
+func WithMaxAttempts(maxAttempts int) Option {
+	return applierFunc(func(c configurable) {
+		c.setMaxAttempts(maxAttempts)
+	})
+}
+
+// WithBackoff sets the delay before the first retry, doubled with each next retry up to max, by default 100ms and 5s.
+// Actual delay is randomly jittered between a half and a full of the computed one
+func WithBackoff(initial, max time.Duration) Option {
+	return applierFunc(func(c configurable) {
+		c.setBackoff(initial, max)
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/retry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+func TestRetryServer(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	attempts := 0
+	server := chain.NewNetworkServiceServer(
+		retry.NewServer(retry.WithBackoff(backoff, backoff)),
+		&failingServer{attempts: &attempts},
+	)
+
+	conn, err := server.Request(context.Background(), request())
+	require.NoError(t, err)
+	require.Equal(t, "id", conn.GetId())
+	require.Equal(t, 2, attempts)
+}
+
+type failingServer struct {
+	attempts *int
+}
+
+func (s *failingServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	*s.attempts++
+	if *s.attempts == 1 {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *failingServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
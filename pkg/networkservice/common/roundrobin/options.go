@@ -0,0 +1,44 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundrobin
+
+import (
+	"github.com/networkservicemesh/sdk/pkg/tools/circuitbreaker"
+)
+
+type configurable interface {
+	setCircuitBreakers(*circuitbreaker.Breakers)
+}
+
+// Option is round robin server configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithCircuitBreakers sets the circuit breakers used by connect server, so the endpoints with the open circuit are
+// skipped unless all the candidates have the open circuit
+func WithCircuitBreakers(breakers *circuitbreaker.Breakers) Option {
+	return applierFunc(func(c configurable) {
+		c.setCircuitBreakers(breakers)
+	})
+}
@@ -26,21 +26,32 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/circuitbreaker"
 )
 
 type selectEndpointServer struct {
 	selector *roundRobinSelector
+	breakers *circuitbreaker.Breakers
 }
 
 // NewServer - provides a NetworkServiceServer chain element that round robins among candidates provided by
 // discover.Candidate(ctx) in the context.
-func NewServer() networkservice.NetworkServiceServer {
-	return &selectEndpointServer{
+func NewServer(options ...Option) networkservice.NetworkServiceServer {
+	rv := &selectEndpointServer{
 		selector: newRoundRobinSelector(),
 	}
+	for _, o := range options {
+		o.apply(rv)
+	}
+	return rv
+}
+
+func (s *selectEndpointServer) setCircuitBreakers(breakers *circuitbreaker.Breakers) {
+	s.breakers = breakers
 }
 
 func (s *selectEndpointServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
@@ -52,18 +63,14 @@ func (s *selectEndpointServer) Request(ctx context.Context, request *networkserv
 }
 
 func (s *selectEndpointServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	// TODO - we should remember the previous selection here.
-	ctx, err := s.withClientURL(ctx, conn)
-	if err != nil {
-		return nil, err
-	}
+	// Endpoint has been selected on Request, discover provides no candidates on Close
 	return next.Server(ctx).Close(ctx, conn)
 }
 
 func (s *selectEndpointServer) withClientURL(ctx context.Context, conn *networkservice.Connection) (context.Context, error) {
 	if clienturl.ClientURL(ctx) == nil {
 		candidates := discover.Candidates(ctx)
-		endpoint := s.selector.selectEndpoint(candidates.NetworkService, candidates.Endpoints)
+		endpoint := s.selector.selectEndpoint(candidates.NetworkService, s.available(candidates.Endpoints))
 		if endpoint == nil {
 			return nil, errors.Errorf("failed to find endpoint for Network Service: %v %v", candidates.NetworkService, candidates.Endpoints)
 		}
@@ -73,8 +80,27 @@ func (s *selectEndpointServer) withClientURL(ctx context.Context, conn *networks
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
-		ctx = clienturl.WithClientURL(ctx, u)
+		// Circuit breaker is keyed by the endpoint URL, clientURL can be changed by the next elements (e.g. selectforwarder)
+		ctx = circuitbreaker.WithURL(clienturl.WithClientURL(ctx, u), u)
 		return ctx, nil
 	}
 	return ctx, nil
 }
+
+// available - returns the endpoints with not open circuit, or all the endpoints if there are no such endpoints
+func (s *selectEndpointServer) available(endpoints []*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+	if s.breakers == nil {
+		return endpoints
+	}
+	var rv []*registry.NetworkServiceEndpoint
+	for _, endpoint := range endpoints {
+		if u, err := url.Parse(endpoint.GetUrl()); err == nil && s.breakers.State(u) == circuitbreaker.Open {
+			continue
+		}
+		rv = append(rv, endpoint)
+	}
+	if len(rv) == 0 {
+		return endpoints
+	}
+	return rv
+}
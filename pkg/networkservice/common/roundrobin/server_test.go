@@ -0,0 +1,69 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundrobin_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/roundrobin"
+	"github.com/networkservicemesh/sdk/pkg/tools/circuitbreaker"
+)
+
+func TestSelectEndpointServer_CircuitBreakers(t *testing.T) {
+	breakers := circuitbreaker.New(circuitbreaker.WithFailureThreshold(1))
+	server := roundrobin.NewServer(roundrobin.WithCircuitBreakers(breakers))
+
+	ns := &registry.NetworkService{Name: "ns"}
+	nses := []*registry.NetworkServiceEndpoint{
+		{Name: "nse-1", Url: "tcp://127.0.0.1:5001"},
+		{Name: "nse-2", Url: "tcp://127.0.0.1:5002"},
+	}
+	request := func() string {
+		conn, err := server.Request(discover.WithCandidates(context.Background(), nses, ns), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				NetworkService: ns.Name,
+			},
+		})
+		require.NoError(t, err)
+		return conn.GetNetworkServiceEndpointName()
+	}
+
+	// Open the circuit for nse-1
+	u, err := url.Parse(nses[0].Url)
+	require.NoError(t, err)
+	breakers.Report(u, status.Error(codes.Unavailable, "unavailable"))
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, "nse-2", request())
+	}
+
+	// Open the circuit for nse-2, so all the candidates have open circuit
+	u, err = url.Parse(nses[1].Url)
+	require.NoError(t, err)
+	breakers.Report(u, status.Error(codes.Unavailable, "unavailable"))
+
+	require.ElementsMatch(t, []string{"nse-1", "nse-2"}, []string{request(), request()})
+}
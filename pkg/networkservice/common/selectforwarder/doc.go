@@ -0,0 +1,20 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package selectforwarder provides chain elements to put a forwarder (dataplane) in the path of the connection:
+// Network Service Manager selects a local forwarder for the Request and sends it to the forwarder with the selected
+// Network Service Endpoint URL as the next hop, forwarder sends the Request further to the next hop.
+package selectforwarder
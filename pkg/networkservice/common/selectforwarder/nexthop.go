@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectforwarder
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clienturl"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type nextHopServer struct{}
+
+// NewNextHopServer - creates a forwarder NetworkServiceServer that sets clienturl.ClientURL(ctx) to the next hop URL
+//                    passed by the Network Service Manager selectforwarder chain element
+func NewNextHopServer() networkservice.NetworkServiceServer {
+	return &nextHopServer{}
+}
+
+func (s *nextHopServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	nextHop, err := nextHopURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if nextHop == nil {
+		return nil, errors.Errorf("next hop URL is not passed for connection: %v", request.GetConnection().GetId())
+	}
+	ctx = clienturl.WithClientURL(ctx, nextHop)
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *nextHopServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	nextHop, err := nextHopURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if nextHop != nil {
+		ctx = clienturl.WithClientURL(ctx, nextHop)
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func nextHopURL(ctx context.Context) (*url.URL, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	values := md.Get(nextHopKey)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	u, err := url.Parse(values[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid next hop URL: %v", values[0])
+	}
+	return u, nil
+}
+
+// withoutNextHop - removes the next hop URL from the incoming metadata, so it can't be passed by the client to the
+// Network Service Manager and sent further to the forwarder instead of the selected one
+func withoutNextHop(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(nextHopKey)) == 0 {
+		return ctx
+	}
+	md = md.Copy()
+	delete(md, nextHopKey)
+	return metadata.NewIncomingContext(ctx, md)
+}
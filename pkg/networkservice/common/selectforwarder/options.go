@@ -0,0 +1,83 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectforwarder
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcpool"
+)
+
+type configurable interface {
+	setDialOptions([]grpc.DialOption)
+	setPool(*grpcpool.Pool)
+	setProbe(ProbeFunc)
+	setProbePeriod(time.Duration)
+	setClock(clock.Clock)
+}
+
+// Option configures selectforwarder server
+type Option interface {
+	apply(configurable)
+}
+
+type applyOptionFunc func(configurable)
+
+func (a applyOptionFunc) apply(c configurable) {
+	a(c)
+}
+
+// WithDialOptions sets dial options for the connections to the forwarders. Ignored if WithPool is used
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return applyOptionFunc(func(c configurable) {
+		c.setDialOptions(opts)
+	})
+}
+
+// WithPool sets the pool of grpc.ClientConns used to probe the forwarders, by default a new grpcpool.Pool is created
+// with the dial options. The same pool should be passed to connect, so the forwarders are probed with the same
+// connections the Requests are sent with
+func WithPool(pool *grpcpool.Pool) Option {
+	return applyOptionFunc(func(c configurable) {
+		c.setPool(pool)
+	})
+}
+
+// WithProbe sets the function checking if the forwarder is reachable, by default HealthProbe with the pool is used
+func WithProbe(probe ProbeFunc) Option {
+	return applyOptionFunc(func(c configurable) {
+		c.setProbe(probe)
+	})
+}
+
+// WithProbePeriod sets how often the forwarders are probed, by default 10 seconds
+func WithProbePeriod(period time.Duration) Option {
+	return applyOptionFunc(func(c configurable) {
+		c.setProbePeriod(period)
+	})
+}
+
+// WithClock sets the clock used to probe the forwarders periodically and to check the forwarder registrations
+// expiration, by default the real clock is used
+func WithClock(c clock.Clock) Option {
+	return applyOptionFunc(func(cfg configurable) {
+		cfg.setClock(c)
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectforwarder
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcpool"
+)
+
+const (
+	defaultProbePeriod  = 10 * time.Second
+	defaultProbeTimeout = time.Second
+)
+
+// ProbeFunc - checks if the forwarder listening on u is reachable, returns nil if it is
+type ProbeFunc func(ctx context.Context, u *url.URL) error
+
+// HealthProbe - returns ProbeFunc checking the forwarder with the gRPC health service over the pooled connection
+func HealthProbe(pool *grpcpool.Pool) ProbeFunc {
+	return func(ctx context.Context, u *url.URL) error {
+		cc, err := pool.Acquire(u)
+		if err != nil {
+			return err
+		}
+		defer cc.Release()
+
+		ctx, cancel := context.WithTimeout(ctx, defaultProbeTimeout)
+		defer cancel()
+		resp, err := grpc_health_v1.NewHealthClient(cc).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+		if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			return errors.Errorf("forwarder %s is %s", u, resp.GetStatus())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectforwarder
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	// NetworkServiceName - reserved Network Service name forwarders register with
+	NetworkServiceName = "forwarder"
+	// MechanismsLabel - forwarder Network Service label with comma separated list of the supported mechanism types,
+	// forwarder without the label is considered to support all mechanism types
+	MechanismsLabel = "mechanisms"
+)
+
+type forwarder struct {
+	name       string
+	url        *url.URL
+	mechanisms []string
+	expiration time.Time
+	unhealthy  bool
+}
+
+func (f *forwarder) supports(mechanismTypes []string) bool {
+	if len(f.mechanisms) == 0 || len(mechanismTypes) == 0 {
+		return true
+	}
+	for _, mechanismType := range mechanismTypes {
+		for _, supported := range f.mechanisms {
+			if mechanismType == supported {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// forwarders is a set of the local forwarders
+type forwarders struct {
+	entries map[string]*forwarder
+	counter int
+	clock   clock.Clock
+	mutex   sync.Mutex
+}
+
+func newForwarders(clk clock.Clock) *forwarders {
+	return &forwarders{
+		entries: map[string]*forwarder{},
+		clock:   clk,
+	}
+}
+
+func (f *forwarders) store(fwd *forwarder) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if old, ok := f.entries[fwd.name]; ok {
+		// Health is checked by the probe, registration refresh doesn't mean the forwarder is reachable
+		fwd.unhealthy = old.unhealthy
+	}
+	f.entries[fwd.name] = fwd
+}
+
+func (f *forwarders) delete(name string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.entries, name)
+}
+
+func (f *forwarders) load(name string) *forwarder {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.entries[name]
+}
+
+func (f *forwarders) isEmpty() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return len(f.entries) == 0
+}
+
+// setHealthy sets the forwarder health reported by the probe, unhealthy forwarder is excluded from the selection until
+// the probe succeeds
+func (f *forwarders) setHealthy(name string, healthy bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if fwd, ok := f.entries[name]; ok {
+		fwd.unhealthy = !healthy
+	}
+}
+
+// urls - returns the snapshot of the forwarder URLs by names
+func (f *forwarders) urls() map[string]*url.URL {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	rv := make(map[string]*url.URL, len(f.entries))
+	for name, fwd := range f.entries {
+		rv[name] = fwd.url
+	}
+	return rv
+}
+
+// candidates returns healthy not expired forwarders supporting any of the mechanismTypes. Candidates are rotated on
+// each call to spread connections between the forwarders, the preferred forwarder goes first.
+func (f *forwarders) candidates(mechanismTypes []string, preferred string) []*forwarder {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var candidates []*forwarder
+	now := f.clock.Now()
+	for _, fwd := range f.entries {
+		if fwd.unhealthy || (!fwd.expiration.IsZero() && fwd.expiration.Before(now)) || !fwd.supports(mechanismTypes) {
+			continue
+		}
+		candidates = append(candidates, fwd)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].name < candidates[j].name
+	})
+
+	f.counter++
+	shift := f.counter % len(candidates)
+	candidates = append(candidates[shift:], candidates[:shift]...)
+	for i, fwd := range candidates {
+		if fwd.name == preferred {
+			candidates[0], candidates[i] = candidates[i], candidates[0]
+			break
+		}
+	}
+	return candidates
+}
+
+type forwarderRegistryServer struct {
+	forwarders *forwarders
+}
+
+func newForwarderRegistryServer(fwds *forwarders) registry.NetworkServiceEndpointRegistryServer {
+	return &forwarderRegistryServer{
+		forwarders: fwds,
+	}
+}
+
+func (s *forwarderRegistryServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if !isForwarder(nse) {
+		return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	}
+	u, err := url.Parse(nse.Url)
+	if err != nil {
+		return nil, err
+	}
+	fwd := &forwarder{
+		url: u,
+	}
+	if labels := nse.GetNetworkServiceLabels()[NetworkServiceName].GetLabels(); labels[MechanismsLabel] != "" {
+		fwd.mechanisms = strings.Split(labels[MechanismsLabel], ",")
+	}
+	if nse.GetExpirationTime() != nil {
+		if fwd.expiration, err = ptypes.Timestamp(nse.GetExpirationTime()); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	fwd.name = resp.Name
+	s.forwarders.store(fwd)
+	return resp, nil
+}
+
+func (s *forwarderRegistryServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *forwarderRegistryServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	s.forwarders.delete(nse.Name)
+	return resp, nil
+}
+
+func isForwarder(nse *registry.NetworkServiceEndpoint) bool {
+	for _, ns := range nse.GetNetworkServiceNames() {
+		if ns == NetworkServiceName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectforwarder
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clienturl"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcpool"
+)
+
+const nextHopKey = "nsm-next-hop-url"
+
+type selectForwarderServer struct {
+	ctx         context.Context
+	forwarders  *forwarders
+	dialOptions []grpc.DialOption
+	pool        *grpcpool.Pool
+	probe       ProbeFunc
+	probePeriod time.Duration
+	clock       clock.Clock
+	// Map of connection IDs -> selected forwarder names
+	selected sync.Map
+}
+
+// NewServer - creates a NetworkServiceServer that selects a local forwarder supporting the requested mechanisms and
+//             sends the Request to it passing clienturl.ClientURL(ctx) as the next hop. The forwarders are probed
+//             periodically and the unreachable ones are excluded from the selection until the probe succeeds. If the
+//             Request fails with codes.Unavailable, the forwarder is probed to tell its own failure from the downstream
+//             one: unreachable forwarder is excluded and the next one is selected, the downstream failure is returned.
+//             If there are no forwarders registered, the Request is passed to the clienturl.ClientURL(ctx) directly.
+//             Next hop URL passed in the incoming metadata is removed, only the selected one is sent to the forwarder.
+//             - ctx - context for the lifecycle of the server, the forwarders are probed until it is done
+//             - registryServer - *registry.NetworkServiceEndpointRegistryServer.  Since registry.NetworkServiceEndpointRegistryServer is an interface
+//                        (and thus a pointer) *registry.NetworkServiceEndpointRegistryServer is a double pointer.  Meaning it
+//                        points to a place that points to a place that implements registry.NetworkServiceEndpointRegistryServer
+//                        This is done so that we can return a registry.NetworkServiceEndpointRegistryServer chain element
+//                        while maintaining the NewServer pattern for use like anything else in a chain.
+//                        The value in *registryServer must be included in the registry.NetworkServiceEndpointRegistryServer listening
+//                        so it can capture the forwarders registrations.
+//             - options - configuration options
+func NewServer(ctx context.Context, registryServer *registry.NetworkServiceEndpointRegistryServer, options ...Option) networkservice.NetworkServiceServer {
+	rv := &selectForwarderServer{
+		ctx:         ctx,
+		probePeriod: defaultProbePeriod,
+		clock:       clock.New(),
+	}
+	for _, o := range options {
+		o.apply(rv)
+	}
+	rv.forwarders = newForwarders(rv.clock)
+	if rv.probe == nil {
+		if rv.pool == nil {
+			rv.pool = grpcpool.New(ctx, grpcpool.WithDialOptions(rv.dialOptions...))
+		}
+		rv.probe = HealthProbe(rv.pool)
+	}
+	*registryServer = newForwarderRegistryServer(rv.forwarders)
+	go rv.probeForwarders()
+	return rv
+}
+
+func (s *selectForwarderServer) setDialOptions(dialOptions []grpc.DialOption) {
+	s.dialOptions = dialOptions
+}
+
+func (s *selectForwarderServer) setPool(pool *grpcpool.Pool) {
+	s.pool = pool
+}
+
+func (s *selectForwarderServer) setProbe(probe ProbeFunc) {
+	s.probe = probe
+}
+
+func (s *selectForwarderServer) setProbePeriod(probePeriod time.Duration) {
+	s.probePeriod = probePeriod
+}
+
+func (s *selectForwarderServer) setClock(c clock.Clock) {
+	s.clock = c
+}
+
+func (s *selectForwarderServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	ctx = withoutNextHop(ctx)
+	if s.forwarders.isEmpty() {
+		return next.Server(ctx).Request(ctx, request)
+	}
+	nextHop := clienturl.ClientURL(ctx)
+	if nextHop == nil {
+		return nil, errors.Errorf("next hop URL not found for connection: %v", request.GetConnection().GetId())
+	}
+
+	id := request.GetConnection().GetId()
+	var preferred string
+	if v, ok := s.selected.Load(id); ok {
+		preferred = v.(string)
+	}
+	mechanismTypes := requestedMechanismTypes(request)
+	candidates := s.forwarders.candidates(mechanismTypes, preferred)
+	if len(candidates) == 0 {
+		return nil, errors.Errorf("no healthy forwarder supporting %v is found", mechanismTypes)
+	}
+
+	var err error
+	for _, fwd := range candidates {
+		var conn *networkservice.Connection
+		conn, err = next.Server(ctx).Request(withForwarder(ctx, fwd.url, nextHop), request)
+		if err == nil {
+			s.selected.Store(id, fwd.name)
+			return conn, nil
+		}
+		if status.Code(errors.Cause(err)) != codes.Unavailable {
+			return nil, err
+		}
+		// Unavailable could be returned by the downstream NSE or Network Service Manager, so check the forwarder itself
+		if probeErr := s.probe(ctx, fwd.url); probeErr == nil {
+			return nil, err
+		}
+		trace.Log(ctx).Warnf("forwarder %s is unavailable, selecting the next one: %v", fwd.name, err)
+		s.forwarders.setHealthy(fwd.name, false)
+		if fwd.name == preferred {
+			// Close the connection via the failed forwarder, so the next one can be used for it
+			_, _ = next.Server(ctx).Close(withForwarder(ctx, fwd.url, nextHop), request.GetConnection())
+			s.selected.Delete(id)
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Wrap(err, "all forwarders have failed")
+}
+
+func (s *selectForwarderServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	ctx = withoutNextHop(ctx)
+	v, ok := s.selected.Load(conn.GetId())
+	if !ok {
+		return next.Server(ctx).Close(ctx, conn)
+	}
+	s.selected.Delete(conn.GetId())
+	if fwd := s.forwarders.load(v.(string)); fwd != nil {
+		ctx = withForwarder(ctx, fwd.url, clienturl.ClientURL(ctx))
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// probeForwarders - periodically probes all the forwarders and updates their health until s.ctx is done
+func (s *selectForwarderServer) probeForwarders() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.clock.After(s.probePeriod):
+		}
+		for name, u := range s.forwarders.urls() {
+			err := s.probe(s.ctx, u)
+			if err != nil && s.ctx.Err() == nil {
+				trace.Log(s.ctx).Warnf("forwarder %s probe has failed: %v", name, err)
+			}
+			s.forwarders.setHealthy(name, err == nil)
+		}
+	}
+}
+
+func withForwarder(ctx context.Context, forwarderURL, nextHop *url.URL) context.Context {
+	if nextHop != nil {
+		ctx = metadata.AppendToOutgoingContext(ctx, nextHopKey, nextHop.String())
+	}
+	return clienturl.WithClientURL(ctx, forwarderURL)
+}
+
+func requestedMechanismTypes(request *networkservice.NetworkServiceRequest) []string {
+	var rv []string
+	if mechanism := request.GetConnection().GetMechanism(); mechanism != nil {
+		rv = append(rv, mechanism.GetType())
+	}
+	for _, mechanism := range request.GetMechanismPreferences() {
+		rv = append(rv, mechanism.GetType())
+	}
+	return rv
+}
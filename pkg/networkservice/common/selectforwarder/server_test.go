@@ -0,0 +1,362 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectforwarder_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clienturl"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/selectforwarder"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type forwarderServer struct {
+	unavailable           map[string]bool
+	downstreamUnavailable bool
+	clientURL             *url.URL
+	nextHop               string
+	incomingNextHop       string
+	closedVia             []string
+	mutex                 sync.Mutex
+}
+
+func (s *forwarderServer) handle(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.clientURL = clienturl.ClientURL(ctx)
+	s.nextHop = ""
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get("nsm-next-hop-url")) > 0 {
+		s.nextHop = md.Get("nsm-next-hop-url")[0]
+	}
+	s.incomingNextHop = ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("nsm-next-hop-url")) > 0 {
+		s.incomingNextHop = md.Get("nsm-next-hop-url")[0]
+	}
+	if s.unavailable[s.clientURL.String()] {
+		return status.Error(codes.Unavailable, "forwarder is unavailable")
+	}
+	if s.downstreamUnavailable {
+		return status.Error(codes.Unavailable, "endpoint is unavailable")
+	}
+	return nil
+}
+
+func (s *forwarderServer) probe(_ context.Context, u *url.URL) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.unavailable[u.String()] {
+		return errors.New("forwarder is unreachable")
+	}
+	return nil
+}
+
+func (s *forwarderServer) setUnavailable(u string, unavailable bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.unavailable[u] = unavailable
+}
+
+func (s *forwarderServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if err := s.handle(ctx); err != nil {
+		return nil, err
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *forwarderServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.mutex.Lock()
+	s.closedVia = append(s.closedVia, clienturl.ClientURL(ctx).String())
+	s.mutex.Unlock()
+	if err := s.handle(ctx); err != nil {
+		return nil, err
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func registerForwarder(t *testing.T, s registry.NetworkServiceEndpointRegistryServer, name, u, mechanisms string) {
+	nse := &registry.NetworkServiceEndpoint{
+		Name:                name,
+		NetworkServiceNames: []string{selectforwarder.NetworkServiceName},
+		Url:                 u,
+	}
+	if mechanisms != "" {
+		nse.NetworkServiceLabels = map[string]*registry.NetworkServiceLabels{
+			selectforwarder.NetworkServiceName: {
+				Labels: map[string]string{selectforwarder.MechanismsLabel: mechanisms},
+			},
+		}
+	}
+	_, err := s.Register(context.Background(), nse)
+	require.NoError(t, err)
+}
+
+func newRequest(mechanismType string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Type: mechanismType},
+		},
+		Connection: &networkservice.Connection{
+			Id: "1",
+		},
+	}
+}
+
+const (
+	nseURL      = "tcp://127.0.0.1:5000"
+	probePeriod = time.Minute
+)
+
+func newServer(ctx context.Context, t *testing.T, fwd *forwarderServer, clk clock.Clock) (networkservice.NetworkServiceServer, registry.NetworkServiceEndpointRegistryServer) {
+	u, err := url.Parse(nseURL)
+	require.NoError(t, err)
+	var registryServer registry.NetworkServiceEndpointRegistryServer
+	server := next.NewNetworkServiceServer(
+		clienturl.NewServer(u),
+		selectforwarder.NewServer(ctx, &registryServer,
+			selectforwarder.WithProbe(fwd.probe),
+			selectforwarder.WithProbePeriod(probePeriod),
+			selectforwarder.WithClock(clk)),
+		fwd,
+	)
+	return server, registryServer
+}
+
+func TestSelectForwarder_NoForwarders(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fwd := &forwarderServer{}
+	server, _ := newServer(ctx, t, fwd, clock.NewFake(time.Now()))
+
+	_, err := server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	require.Equal(t, nseURL, fwd.clientURL.String())
+	require.Empty(t, fwd.nextHop)
+}
+
+func TestSelectForwarder_Mechanisms(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fwd := &forwarderServer{}
+	server, registryServer := newServer(ctx, t, fwd, clock.NewFake(time.Now()))
+	registerForwarder(t, registryServer, "forwarder-memif", "tcp://127.0.0.1:5001", memif.MECHANISM)
+	registerForwarder(t, registryServer, "forwarder-kernel", "tcp://127.0.0.1:5002", kernel.MECHANISM+","+memif.MECHANISM)
+
+	for i := 0; i < 3; i++ {
+		conn, err := server.Request(context.Background(), newRequest(kernel.MECHANISM))
+		require.NoError(t, err)
+		require.Equal(t, "tcp://127.0.0.1:5002", fwd.clientURL.String())
+		require.Equal(t, nseURL, fwd.nextHop)
+
+		_, err = server.Close(context.Background(), conn)
+		require.NoError(t, err)
+		require.Equal(t, "tcp://127.0.0.1:5002", fwd.clientURL.String())
+	}
+
+	_, err := server.Request(context.Background(), newRequest("UNKNOWN"))
+	require.Error(t, err)
+}
+
+func TestSelectForwarder_Reselect(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fwd := &forwarderServer{unavailable: map[string]bool{}}
+	clk := clock.NewFake(time.Now())
+	server, registryServer := newServer(ctx, t, fwd, clk)
+	registerForwarder(t, registryServer, "forwarder-1", "tcp://127.0.0.1:5001", "")
+	registerForwarder(t, registryServer, "forwarder-2", "tcp://127.0.0.1:5002", "")
+
+	_, err := server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	selected := fwd.clientURL.String()
+
+	// Refresh goes to the same forwarder
+	_, err = server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	require.Equal(t, selected, fwd.clientURL.String())
+
+	// Selected forwarder fails, so another one should be selected
+	fwd.setUnavailable(selected, true)
+	_, err = server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	require.NotEqual(t, selected, fwd.clientURL.String())
+	other := fwd.clientURL.String()
+	// Connection is closed via the failed forwarder
+	require.Equal(t, []string{selected}, fwd.closedVia)
+
+	// All forwarders fail
+	fwd.setUnavailable(other, true)
+	_, err = server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.Error(t, err)
+
+	// Failed forwarder becomes available after the probe succeeds
+	fwd.setUnavailable(selected, false)
+	_, err = server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.Error(t, err)
+
+	clk.BlockUntil(1)
+	clk.Add(probePeriod)
+	// Probe loop waits for the next period after all the forwarders are probed
+	clk.BlockUntil(1)
+	_, err = server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	require.Equal(t, selected, fwd.clientURL.String())
+}
+
+func TestSelectForwarder_DownstreamUnavailable(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fwd := &forwarderServer{unavailable: map[string]bool{}}
+	server, registryServer := newServer(ctx, t, fwd, clock.NewFake(time.Now()))
+	registerForwarder(t, registryServer, "forwarder-1", "tcp://127.0.0.1:5001", "")
+
+	// Forwarder is reachable, so the failure is returned and the forwarder is kept
+	fwd.downstreamUnavailable = true
+	_, err := server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	fwd.downstreamUnavailable = false
+	_, err = server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	require.Equal(t, "tcp://127.0.0.1:5001", fwd.clientURL.String())
+}
+
+func TestSelectForwarder_Expiration(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fwd := &forwarderServer{}
+	clk := clock.NewFake(time.Now())
+	server, registryServer := newServer(ctx, t, fwd, clk)
+	expiration, err := ptypes.TimestampProto(clk.Now().Add(time.Minute))
+	require.NoError(t, err)
+	_, err = registryServer.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "forwarder-1",
+		NetworkServiceNames: []string{selectforwarder.NetworkServiceName},
+		Url:                 "tcp://127.0.0.1:5001",
+		ExpirationTime:      expiration,
+	})
+	require.NoError(t, err)
+
+	_, err = server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	require.Equal(t, "tcp://127.0.0.1:5001", fwd.clientURL.String())
+
+	// Expired forwarder is not selected
+	clk.Add(time.Minute + time.Millisecond)
+	_, err = server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.Error(t, err)
+}
+
+func TestSelectForwarder_Unregister(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fwd := &forwarderServer{}
+	server, registryServer := newServer(ctx, t, fwd, clock.NewFake(time.Now()))
+	registerForwarder(t, registryServer, "forwarder-1", "tcp://127.0.0.1:5001", "")
+
+	_, err := server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	require.Equal(t, "tcp://127.0.0.1:5001", fwd.clientURL.String())
+
+	_, err = registryServer.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "forwarder-1"})
+	require.NoError(t, err)
+
+	_, err = server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	require.Equal(t, nseURL, fwd.clientURL.String())
+}
+
+func TestSelectForwarder_IncomingNextHop(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fwd := &forwarderServer{}
+	server, registryServer := newServer(ctx, t, fwd, clock.NewFake(time.Now()))
+
+	// Without forwarders next hop passed by the client should not reach the next elements
+	requestCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("nsm-next-hop-url", "tcp://127.0.0.1:6000"))
+	_, err := server.Request(requestCtx, newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	require.Empty(t, fwd.incomingNextHop)
+
+	// With forwarders only the selected next hop is sent
+	registerForwarder(t, registryServer, "forwarder-1", "tcp://127.0.0.1:5001", "")
+	_, err = server.Request(requestCtx, newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	require.Empty(t, fwd.incomingNextHop)
+	require.Equal(t, nseURL, fwd.nextHop)
+}
+
+func TestNextHopServer(t *testing.T) {
+	var clientURL *url.URL
+	server := next.NewNetworkServiceServer(
+		selectforwarder.NewNextHopServer(),
+		&captureClientURLServer{clientURL: &clientURL},
+	)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("nsm-next-hop-url", nseURL))
+	_, err := server.Request(ctx, newRequest(kernel.MECHANISM))
+	require.NoError(t, err)
+	require.Equal(t, nseURL, clientURL.String())
+
+	_, err = server.Request(context.Background(), newRequest(kernel.MECHANISM))
+	require.Error(t, err)
+}
+
+type captureClientURLServer struct {
+	clientURL **url.URL
+}
+
+func (s *captureClientURLServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	*s.clientURL = clienturl.ClientURL(ctx)
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *captureClientURLServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	*s.clientURL = clienturl.ClientURL(ctx)
+	return next.Server(ctx).Close(ctx, conn)
+}
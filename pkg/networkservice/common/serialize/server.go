@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package serialize provides a chain element serializing Requests and Closes per connection ID
+package serialize
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	serialize_tools "github.com/networkservicemesh/sdk/pkg/tools/serialize"
+)
+
+type executor struct {
+	serialize_tools.Executor
+	refs int
+}
+
+type serializeServer struct {
+	executors map[string]*executor
+	mutex     sync.Mutex
+}
+
+// NewServer - creates a new NetworkServiceServer chain element serializing Requests and Closes for the same connection
+//             ID (e.g. concurrent heal, refresh and application Requests), so the next chain elements never process
+//             them concurrently. Requests and Closes are processed in the order they are received, so a Close received
+//             during an in-flight Request is processed after it. Request or Close whose ctx is done while waiting for
+//             its turn fails with the ctx error without calling the next chain elements.
+//             Should be placed after setid.
+func NewServer() networkservice.NetworkServiceServer {
+	return &serializeServer{
+		executors: make(map[string]*executor),
+	}
+}
+
+func (s *serializeServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (conn *networkservice.Connection, err error) {
+	err = s.exec(ctx, request.GetConnection().GetId(), func() error {
+		conn, err = next.Server(ctx).Request(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (s *serializeServer) Close(ctx context.Context, conn *networkservice.Connection) (rv *empty.Empty, err error) {
+	err = s.exec(ctx, conn.GetId(), func() error {
+		rv, err = next.Server(ctx).Close(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+// exec - executes f in the connID executor and waits for it to complete
+func (s *serializeServer) exec(ctx context.Context, connID string, f func() error) (err error) {
+	s.mutex.Lock()
+	e, ok := s.executors[connID]
+	if !ok {
+		e = &executor{}
+		s.executors[connID] = e
+	}
+	e.refs++
+	s.mutex.Unlock()
+
+	<-e.AsyncExec(func() {
+		if err = ctx.Err(); err == nil {
+			err = f()
+		}
+
+		s.mutex.Lock()
+		e.refs--
+		if e.refs == 0 {
+			delete(s.executors, connID)
+		}
+		s.mutex.Unlock()
+	})
+	return err
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serialize_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/serialize"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type eventsServer struct {
+	blockCh chan struct{}
+	active  sync.Map
+	events  []string
+	mutex   sync.Mutex
+}
+
+func (s *eventsServer) event(id, name string) {
+	raw, _ := s.active.LoadOrStore(id, new(int32))
+	active := raw.(*int32)
+	if atomic.AddInt32(active, 1) != 1 {
+		panic("concurrent call for " + id)
+	}
+	s.mutex.Lock()
+	s.events = append(s.events, name)
+	s.mutex.Unlock()
+	if s.blockCh != nil {
+		<-s.blockCh
+	}
+	atomic.AddInt32(active, -1)
+}
+
+func (s *eventsServer) list() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.events...)
+}
+
+func (s *eventsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	s.event(request.GetConnection().GetId(), "request")
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *eventsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.event(conn.GetId(), "close")
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func request(id string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: id},
+	}
+}
+
+func TestSerializeServer_Concurrent(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	events := &eventsServer{}
+	server := chain.NewNetworkServiceServer(serialize.NewServer(), events)
+
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := server.Request(context.Background(), request("id"))
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	require.Len(t, events.list(), 20)
+}
+
+func TestSerializeServer_CloseAfterRequest(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	events := &eventsServer{blockCh: make(chan struct{})}
+	server := chain.NewNetworkServiceServer(serialize.NewServer(), events)
+
+	requestDone := make(chan struct{})
+	go func() {
+		defer close(requestDone)
+		_, err := server.Request(context.Background(), request("id"))
+		require.NoError(t, err)
+	}()
+	require.Eventually(t, func() bool {
+		return len(events.list()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	closeDone := make(chan struct{})
+	go func() {
+		defer close(closeDone)
+		_, err := server.Close(context.Background(), request("id").GetConnection())
+		require.NoError(t, err)
+	}()
+	// Close waits for the in-flight Request
+	require.Never(t, func() bool {
+		return len(events.list()) > 1
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	events.blockCh <- struct{}{}
+	<-requestDone
+	events.blockCh <- struct{}{}
+	<-closeDone
+	require.Equal(t, []string{"request", "close"}, events.list())
+}
+
+func TestSerializeServer_ContextDone(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	events := &eventsServer{blockCh: make(chan struct{})}
+	server := chain.NewNetworkServiceServer(serialize.NewServer(), events)
+
+	requestDone := make(chan struct{})
+	go func() {
+		defer close(requestDone)
+		_, err := server.Request(context.Background(), request("id"))
+		require.NoError(t, err)
+	}()
+	require.Eventually(t, func() bool {
+		return len(events.list()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// Other connections are not blocked
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	otherDone := make(chan struct{})
+	go func() {
+		defer close(otherDone)
+		_, err := server.Request(ctx, request("other"))
+		require.NoError(t, err)
+	}()
+	require.Eventually(t, func() bool {
+		return len(events.list()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	closeDone := make(chan struct{})
+	go func() {
+		defer close(closeDone)
+		_, err := server.Close(ctx, request("id").GetConnection())
+		require.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+	}()
+	<-ctx.Done()
+
+	events.blockCh <- struct{}{}
+	events.blockCh <- struct{}{}
+	<-requestDone
+	<-otherDone
+	<-closeDone
+	require.Len(t, events.list(), 2)
+}
@@ -19,7 +19,6 @@ package timeout
 
 import (
 	"context"
-	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -27,14 +26,16 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 	"github.com/networkservicemesh/sdk/pkg/tools/extend"
 	"github.com/networkservicemesh/sdk/pkg/tools/serialize"
 )
 
 type timeoutServer struct {
 	onTimeout   *networkservice.NetworkServiceServer
-	connections map[string]*time.Timer
+	connections map[string]clock.Timer
 	executor    serialize.Executor
+	clock       clock.Clock
 }
 
 // NewServer - creates a new NetworkServiceServer chain element that implements timeout of expired connections
@@ -46,11 +47,16 @@ type timeoutServer struct {
 //                        If onTimeout is nil, then we simply set onTimeout to this server chain element
 //                        If we are part of a larger chain, we should pass the resulting chain into
 //                        this constructor before we actually have a pointer to it.
-func NewServer(onTimout *networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+//             - options - configuration options, e.g. WithClock
+func NewServer(onTimout *networkservice.NetworkServiceServer, options ...Option) networkservice.NetworkServiceServer {
 	rv := &timeoutServer{
-		connections: make(map[string]*time.Timer),
+		connections: make(map[string]clock.Timer),
 		executor:    serialize.NewExecutor(),
 		onTimeout:   onTimout,
+		clock:       clock.New(),
+	}
+	for _, o := range options {
+		o.apply(rv)
 	}
 	if rv.onTimeout == nil {
 		var actualOnTimeout networkservice.NetworkServiceServer = rv
@@ -83,13 +89,13 @@ func (t *timeoutServer) Close(ctx context.Context, conn *networkservice.Connecti
 	return next.Server(ctx).Close(ctx, conn)
 }
 
-func (t *timeoutServer) createTimer(ctx context.Context, request *networkservice.NetworkServiceRequest) (*time.Timer, error) {
+func (t *timeoutServer) createTimer(ctx context.Context, request *networkservice.NetworkServiceRequest) (clock.Timer, error) {
 	expireTime, err := ptypes.Timestamp(request.GetConnection().GetPath().GetPathSegments()[request.GetConnection().GetPath().GetIndex()].GetExpires())
 	if err != nil {
 		return nil, err
 	}
-	duration := time.Until(expireTime)
-	return time.AfterFunc(duration, func() {
+	duration := t.clock.Until(expireTime)
+	return t.clock.AfterFunc(duration, func() {
 		newCtx := extend.WithValuesFromContext(context.Background(), ctx)
 		if _, err := (*t.onTimeout).Close(newCtx, request.GetConnection()); err != nil {
 			trace.Log(newCtx).Errorf("Error attempting to close timed out connection: %s: %+v", request.GetConnection().GetId(), err)
@@ -0,0 +1,144 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/timeout"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	expireTimeout        = time.Minute
+	expectAbsenceTimeout = 100 * time.Millisecond
+)
+
+type closeRecorder struct {
+	closed chan string
+}
+
+func (r *closeRecorder) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (r *closeRecorder) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	r.closed <- conn.GetId()
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func newServer(clk clock.Clock) (networkservice.NetworkServiceServer, *closeRecorder) {
+	recorder := &closeRecorder{closed: make(chan string, 10)}
+	return next.NewNetworkServiceServer(timeout.NewServer(nil, timeout.WithClock(clk)), recorder), recorder
+}
+
+func request(t *testing.T, expires time.Time) *networkservice.NetworkServiceRequest {
+	ts, err := ptypes.TimestampProto(expires)
+	require.NoError(t, err)
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "conn-1",
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{
+					{Expires: ts},
+				},
+			},
+		},
+	}
+}
+
+func requireClosed(t *testing.T, recorder *closeRecorder) {
+	select {
+	case id := <-recorder.closed:
+		require.Equal(t, "conn-1", id)
+	case <-time.After(time.Second):
+		require.FailNow(t, "connection is not closed")
+	}
+}
+
+func requireNotClosed(t *testing.T, recorder *closeRecorder) {
+	select {
+	case id := <-recorder.closed:
+		require.FailNow(t, "unexpected Close", id)
+	case <-time.After(expectAbsenceTimeout):
+	}
+}
+
+func TestTimeoutServer_Expired(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	clk := clock.NewFake(time.Now())
+	server, recorder := newServer(clk)
+
+	_, err := server.Request(context.Background(), request(t, clk.Now().Add(expireTimeout)))
+	require.NoError(t, err)
+
+	clk.Add(expireTimeout - time.Millisecond)
+	requireNotClosed(t, recorder)
+
+	clk.Add(time.Millisecond)
+	requireClosed(t, recorder)
+}
+
+func TestTimeoutServer_Refresh(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	clk := clock.NewFake(time.Now())
+	server, recorder := newServer(clk)
+
+	_, err := server.Request(context.Background(), request(t, clk.Now().Add(expireTimeout)))
+	require.NoError(t, err)
+
+	// Refresh extends the expiration time
+	clk.Add(expireTimeout / 2)
+	_, err = server.Request(context.Background(), request(t, clk.Now().Add(expireTimeout)))
+	require.NoError(t, err)
+
+	// Previous timer is stopped asynchronously
+	<-time.After(expectAbsenceTimeout)
+	clk.Add(expireTimeout / 2)
+	requireNotClosed(t, recorder)
+
+	clk.Add(expireTimeout / 2)
+	requireClosed(t, recorder)
+}
+
+func TestTimeoutServer_Close(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	clk := clock.NewFake(time.Now())
+	server, recorder := newServer(clk)
+
+	req := request(t, clk.Now().Add(expireTimeout))
+	_, err := server.Request(context.Background(), req)
+	require.NoError(t, err)
+
+	_, err = server.Close(context.Background(), req.GetConnection())
+	require.NoError(t, err)
+	requireClosed(t, recorder)
+
+	// Timer is stopped asynchronously
+	<-time.After(expectAbsenceTimeout)
+	clk.Add(expireTimeout)
+	requireNotClosed(t, recorder)
+}
@@ -23,11 +23,11 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnscontext"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/serialize"
 )
 
@@ -58,7 +58,7 @@ func NewClient(monitorClient networkservice.MonitorConnectionClient, options ...
 		o.apply(c)
 	}
 	if r, err := dnscontext.OpenResolveConfig(c.resolveConfigPath); err != nil {
-		logrus.Errorf("DnsContextClient: can not load resolve config file. Path: %v. Error: %v", c.resolveConfigPath, err.Error())
+		log.FromContext(c.chainContext).Errorf("DnsContextClient: can not load resolve config file. Path: %v. Error: %v", c.resolveConfigPath, err.Error())
 	} else {
 		c.dnsConfigManager.Store("", &networkservice.DNSConfig{
 			SearchDomains: r.Value(dnscontext.AnyDomain),
@@ -66,7 +66,7 @@ func NewClient(monitorClient networkservice.MonitorConnectionClient, options ...
 		})
 		r.SetValue(dnscontext.NameserverProperty, c.defaultNameServerIP)
 		if err := r.Save(); err != nil {
-			logrus.Errorf("DnsContextClient: can not update resolve config file. Error: %v", err.Error())
+			log.FromContext(c.chainContext).Errorf("DnsContextClient: can not update resolve config file. Error: %v", err.Error())
 		}
 	}
 	return c
@@ -94,7 +94,7 @@ func (c *dnsContextClient) monitorConfigs() {
 	c.monitorContext, c.cancelMonitoring = context.WithCancel(c.chainContext)
 	steam, err := c.monitorClient.MonitorConnections(c.monitorContext, &networkservice.MonitorScopeSelector{}, c.monitorCallOptions...)
 	if err != nil {
-		logrus.Errorf("DnsContextClient: Can not start monitor connections: %v", err)
+		log.FromContext(c.monitorContext).Errorf("DnsContextClient: Can not start monitor connections: %v", err)
 		c.executor.AsyncExec(c.monitorConfigs)
 		return
 	}
@@ -109,7 +109,7 @@ func (c *dnsContextClient) monitorConfigs() {
 		}
 		c.handleEvent(event)
 		v := c.dnsConfigManager.String()
-		logrus.Info(v)
+		log.FromContext(c.monitorContext).Debugf("DnsContextClient: updated DNS config: %v", v)
 		_ = ioutil.WriteFile(c.coreFilePath, []byte(v), os.ModePerm)
 	}
 }
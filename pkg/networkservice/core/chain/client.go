@@ -20,10 +20,13 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/stage"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
 )
 
-// NewNetworkServiceClient - chains together a list of networkservice.NetworkServiceClient with tracing
+// NewNetworkServiceClient - chains together a list of networkservice.NetworkServiceClient with tracing and stage tracking
 func NewNetworkServiceClient(clients ...networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
-	return next.NewWrappedNetworkServiceClient(trace.NewNetworkServiceClient, clients...)
+	return next.NewWrappedNetworkServiceClient(func(client networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+		return stage.NewNetworkServiceClient(client, trace.NewNetworkServiceClient(client))
+	}, clients...)
 }
@@ -0,0 +1,56 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/metrics"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/stage"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/chainmetrics"
+)
+
+// Metrics - creates the chains collecting Prometheus metrics of their chain elements
+type Metrics struct {
+	metrics *chainmetrics.Metrics
+}
+
+// WithMetrics - returns Metrics creating the chains collecting the metrics registered on registerer, e.g.
+//   chain.WithMetrics(registry).NewNetworkServiceServer(servers...)
+func WithMetrics(registerer prometheus.Registerer) *Metrics {
+	return &Metrics{
+		metrics: chainmetrics.New(registerer),
+	}
+}
+
+// NewNetworkServiceServer - chains together a list of networkservice.Servers with tracing, metrics and stage tracking
+func (m *Metrics) NewNetworkServiceServer(servers ...networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+	return next.NewWrappedNetworkServiceServer(func(server networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+		return stage.NewNetworkServiceServer(server, metrics.NewNetworkServiceServer(m.metrics, server, trace.NewNetworkServiceServer(server)))
+	}, servers...)
+}
+
+// NewNetworkServiceClient - chains together a list of networkservice.NetworkServiceClient with tracing, metrics and stage
+// tracking
+func (m *Metrics) NewNetworkServiceClient(clients ...networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+	return next.NewWrappedNetworkServiceClient(func(client networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+		return stage.NewNetworkServiceClient(client, metrics.NewNetworkServiceClient(m.metrics, client, trace.NewNetworkServiceClient(client)))
+	}, clients...)
+}
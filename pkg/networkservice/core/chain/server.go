@@ -22,10 +22,13 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/stage"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
 )
 
-// NewNetworkServiceServer - chains together a list of networkservice.Servers with tracing
+// NewNetworkServiceServer - chains together a list of networkservice.Servers with tracing and stage tracking
 func NewNetworkServiceServer(servers ...networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
-	return next.NewWrappedNetworkServiceServer(trace.NewNetworkServiceServer, servers...)
+	return next.NewWrappedNetworkServiceServer(func(server networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+		return stage.NewNetworkServiceServer(server, trace.NewNetworkServiceServer(server))
+	}, servers...)
 }
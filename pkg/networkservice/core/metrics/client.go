@@ -0,0 +1,58 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/chainmetrics"
+)
+
+type metricsClient struct {
+	measured networkservice.NetworkServiceClient
+	metrics  *chainmetrics.ElementMetrics
+}
+
+// NewNetworkServiceClient - wraps measured with the Request/Close latency and errors metrics
+//   - m - metrics to collect into
+//   - element - chain element the metrics are labeled with
+//   - measured - element itself or its wrapper (e.g. trace) to measure
+func NewNetworkServiceClient(m *chainmetrics.Metrics, element, measured networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+	return &metricsClient{
+		measured: measured,
+		metrics:  m.Element("client", element),
+	}
+}
+
+func (m *metricsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	start := time.Now()
+	conn, err := m.measured.Request(ctx, request, opts...)
+	m.metrics.Observe("Request", start, err)
+	return conn, err
+}
+
+func (m *metricsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	start := time.Now()
+	rv, err := m.measured.Close(ctx, conn, opts...)
+	m.metrics.Observe("Close", start, err)
+	return rv, err
+}
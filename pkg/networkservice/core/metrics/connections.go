@@ -0,0 +1,80 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/chainmetrics"
+)
+
+type connectionLabelsKey struct{}
+
+type connectionsServer struct {
+	activeConnections *prometheus.GaugeVec
+}
+
+// NewConnectionsServer - creates a new NetworkServiceServer chain element keeping the nsm_active_connections gauges
+//                        of the active connections by network service and network service endpoint. Connection is
+//                        counted after the successful Request and until the Close, including the failed one (e.g.
+//                        on timeout). Should be placed after metadata, endpoint.WithMetrics adds it to the
+//                        endpoint chain.
+//                        - m - metrics to collect into
+func NewConnectionsServer(m *chainmetrics.Metrics) networkservice.NetworkServiceServer {
+	return &connectionsServer{
+		activeConnections: m.ActiveConnections(),
+	}
+}
+
+func (c *connectionsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := prometheus.Labels{
+		"network_service":          conn.GetNetworkService(),
+		"network_service_endpoint": conn.GetNetworkServiceEndpointName(),
+	}
+	if raw, loaded := metadata.Map(ctx, false).Load(connectionLabelsKey{}); loaded {
+		prev := raw.(prometheus.Labels)
+		if prev["network_service"] == labels["network_service"] &&
+			prev["network_service_endpoint"] == labels["network_service_endpoint"] {
+			return conn, nil
+		}
+		c.activeConnections.With(prev).Dec()
+	}
+	metadata.Map(ctx, false).Store(connectionLabelsKey{}, labels)
+	c.activeConnections.With(labels).Inc()
+
+	return conn, nil
+}
+
+func (c *connectionsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+	if raw, loaded := metadata.Map(ctx, false).Load(connectionLabelsKey{}); loaded {
+		metadata.Map(ctx, false).Delete(connectionLabelsKey{})
+		c.activeConnections.With(raw.(prometheus.Labels)).Dec()
+	}
+	return rv, err
+}
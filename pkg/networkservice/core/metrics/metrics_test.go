@@ -0,0 +1,133 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/metrics"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/chainmetrics"
+)
+
+func scrape(t *testing.T, gatherer prometheus.Gatherer) string {
+	w := httptest.NewRecorder()
+	chainmetrics.Handler(gatherer).ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := ioutil.ReadAll(w.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+type setEndpointServer struct {
+	err      error
+	closeErr error
+}
+
+func (s *setEndpointServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	request.GetConnection().NetworkServiceEndpointName = "nse-" + request.GetConnection().GetNetworkService()
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *setEndpointServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if s.closeErr != nil {
+		return nil, s.closeErr
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func TestMetrics(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	registry := prometheus.NewRegistry()
+	setEndpoint := &setEndpointServer{}
+	server := chain.WithMetrics(registry).NewNetworkServiceServer(
+		metadata.NewServer(),
+		metrics.NewConnectionsServer(chainmetrics.New(registry)),
+		setEndpoint,
+	)
+
+	request := func(id string) *networkservice.NetworkServiceRequest {
+		return &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:             id,
+				NetworkService: "metrics-ns",
+			},
+		}
+	}
+
+	for _, id := range []string{"1", "2", "1"} {
+		_, err := server.Request(context.Background(), request(id))
+		require.NoError(t, err)
+	}
+	setEndpoint.err = status.Error(codes.Unavailable, "unavailable")
+	_, err := server.Request(context.Background(), request("3"))
+	require.Error(t, err)
+
+	body := scrape(t, registry)
+	require.Contains(t, body, `nsm_active_connections{network_service="metrics-ns",network_service_endpoint="nse-metrics-ns"} 2`)
+	require.Contains(t, body, `nsm_chain_element_duration_seconds_count{chain="server",element="metrics_test.setEndpointServer",method="Request"} 4`)
+	require.Contains(t, body, `nsm_chain_element_errors_total{chain="server",code="Unavailable",element="metrics_test.setEndpointServer",method="Request"} 1`)
+	require.Contains(t, body, `nsm_chain_element_errors_total{chain="server",code="Unavailable",element="metadata.metaDataServer",method="Request"} 1`)
+
+	_, err = server.Close(context.Background(), request("1").GetConnection())
+	require.NoError(t, err)
+	require.Contains(t, scrape(t, registry), `nsm_active_connections{network_service="metrics-ns",network_service_endpoint="nse-metrics-ns"} 1`)
+
+	// Failed Close also ends the connection
+	setEndpoint.closeErr = status.Error(codes.DeadlineExceeded, "timeout")
+	_, err = server.Close(context.Background(), request("2").GetConnection())
+	require.Error(t, err)
+	require.Contains(t, scrape(t, registry), `nsm_active_connections{network_service="metrics-ns",network_service_endpoint="nse-metrics-ns"} 0`)
+}
+
+func TestMetrics_SharedRegistry(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	registry := prometheus.NewRegistry()
+	for i := 0; i < 2; i++ {
+		server := chain.WithMetrics(registry).NewNetworkServiceServer(&setEndpointServer{})
+		_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{Id: "1"},
+		})
+		require.NoError(t, err)
+	}
+	require.Contains(t, scrape(t, registry), `nsm_chain_element_duration_seconds_count{chain="server",element="metrics_test.setEndpointServer",method="Request"} 2`)
+
+	// Chains without metrics collect nothing
+	server := chain.NewNetworkServiceServer(&setEndpointServer{})
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "1"},
+	})
+	require.NoError(t, err)
+	require.NotContains(t, scrape(t, prometheus.DefaultGatherer), "nsm_chain_element")
+}
@@ -72,3 +72,10 @@ func Client(ctx context.Context) networkservice.NetworkServiceClient {
 	}
 	return &tailClient{}
 }
+
+// ClearNext -
+//   Returns a context.Context with the values from ctx, but without the next Server and Client set. Should be used
+//   when a chain is called with a context.Context saved in the middle of some other chain call
+func ClearNext(ctx context.Context) context.Context {
+	return withNextClient(withNextServer(ctx, nil), nil)
+}
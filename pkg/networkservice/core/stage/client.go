@@ -0,0 +1,58 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stage
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
+)
+
+type stageClient struct {
+	tracked networkservice.NetworkServiceClient
+	request string
+	close   string
+}
+
+// NewNetworkServiceClient - wraps tracked recording the element Stage to the context Tracker (see WithTracker)
+//   - element - chain element the Stage is named with
+//   - tracked - element itself or its wrapper (e.g. trace) to track
+func NewNetworkServiceClient(element, tracked networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+	return &stageClient{
+		tracked: tracked,
+		request: typeutils.GetFuncName(element, "Request"),
+		close:   typeutils.GetFuncName(element, "Close"),
+	}
+}
+
+func (s *stageClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if tracker := trackerFromContext(ctx); tracker != nil {
+		defer tracker.restore(tracker.enter(s.request))
+	}
+	return s.tracked.Request(ctx, request, opts...)
+}
+
+func (s *stageClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if tracker := trackerFromContext(ctx); tracker != nil {
+		defer tracker.restore(tracker.enter(s.close))
+	}
+	return s.tracked.Close(ctx, conn, opts...)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stage provides the wrappers recording the chain element currently processing the Request/Close of the
+// connection, so the stuck Requests/Closes can be inspected while they are in progress
+package stage
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type contextKeyType string
+
+const (
+	trackerKey contextKeyType = "Tracker"
+)
+
+// Stage - chain element currently processing the Request/Close
+type Stage struct {
+	// ChainElement - chain element method, e.g. "github.com/.../connect/connectServer.Request"
+	ChainElement string
+	// Since - time the chain element has been entered
+	Since time.Time
+}
+
+// Tracker - tracks the Stage of the connection
+type Tracker struct {
+	stage Stage
+	mutex sync.Mutex
+}
+
+// Load - returns the current Stage, zero Stage if there is no Request/Close in progress
+func (t *Tracker) Load() Stage {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.stage
+}
+
+func (t *Tracker) enter(chainElement string) (prev Stage) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	prev = t.stage
+	t.stage = Stage{
+		ChainElement: chainElement,
+		Since:        time.Now(),
+	}
+	return prev
+}
+
+func (t *Tracker) restore(prev Stage) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.stage = prev
+}
+
+// WithTracker - returns new context with the Tracker, the chain elements wrapped with NewNetworkService{Server,Client}
+//               record their Stage to it
+func WithTracker(parent context.Context, tracker *Tracker) context.Context {
+	if parent == nil {
+		parent = context.TODO()
+	}
+	return context.WithValue(parent, trackerKey, tracker)
+}
+
+func trackerFromContext(ctx context.Context) *Tracker {
+	if rv, ok := ctx.Value(trackerKey).(*Tracker); ok {
+		return rv
+	}
+	return nil
+}
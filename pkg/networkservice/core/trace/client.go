@@ -25,6 +25,7 @@ import (
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
 	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
 )
@@ -45,13 +46,16 @@ func (t *traceClient) Request(ctx context.Context, request *networkservice.Netwo
 	defer span.Finish()
 
 	// Make sure we log to span
+	ctx = withLog(ctx, span, map[string]interface{}{
+		log.ConnectionIDField:   request.GetConnection().GetId(),
+		log.NetworkServiceField: request.GetConnection().GetNetworkService(),
+		log.ChainElementField:   operation,
+	})
 
-	ctx = withLog(span.Context(), span.Logger())
-
-	span.LogObject("request", request)
+	tracedCtx, nextResponse := logRequest(ctx, span, "Request", request)
 
 	// Actually call the next
-	rv, err := t.traced.Request(ctx, request, opts...)
+	rv, err := t.traced.Request(tracedCtx, request, opts...)
 
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
@@ -62,7 +66,7 @@ func (t *traceClient) Request(ctx context.Context, request *networkservice.Netwo
 		span.LogErrorf("%v", err)
 		return nil, err
 	}
-	span.LogObject("response", rv)
+	logResponse(ctx, span, "Request", nextResponse, rv)
 	return rv, err
 }
 
@@ -72,10 +76,14 @@ func (t *traceClient) Close(ctx context.Context, conn *networkservice.Connection
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 	// Make sure we log to span
-	ctx = withLog(span.Context(), span.Logger())
-
-	span.LogObject("request", conn)
-	rv, err := t.traced.Close(ctx, conn, opts...)
+	ctx = withLog(ctx, span, map[string]interface{}{
+		log.ConnectionIDField:   conn.GetId(),
+		log.NetworkServiceField: conn.GetNetworkService(),
+		log.ChainElementField:   operation,
+	})
+
+	tracedCtx, _ := logRequest(ctx, span, "Close", conn)
+	rv, err := t.traced.Close(tracedCtx, conn, opts...)
 
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
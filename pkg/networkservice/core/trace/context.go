@@ -15,45 +15,45 @@
 // limitations under the License.
 
 // Package trace provides a wrapper for tracing around a networkservice.NetworkServiceClient
+// The logged requests and responses, and the arguments of the messages logged with Log are redacted with
+// redact.Default
+// In the diff mode (see IsDiffEnabled) the full request and response are logged once per chain, and after that only
+// the JSON patch made by each chain element
 package trace
 
 import (
 	"context"
 
-	"github.com/sirupsen/logrus"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
+	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
 )
 
 type contextKeyType string
 
 const (
-	logKey contextKeyType = "Log"
+	baseLogKey contextKeyType = "BaseLog"
 )
 
 // withLog -
-//   Provides a FieldLogger in context
-func withLog(parent context.Context, log logrus.FieldLogger) context.Context {
+//   Provides a log.Logger with the fields in context. If there is no Logger set by the user in parent, the Logger also
+//   logs to the span.
+func withLog(parent context.Context, span spanhelper.SpanHelper, fields map[string]interface{}) context.Context {
 	if parent == nil {
 		parent = context.TODO()
 	}
-	return context.WithValue(parent, logKey, log)
-}
-
-// Log - return FieldLogger from context
-func Log(ctx context.Context) logrus.FieldLogger {
-	rv, ok := ctx.Value(logKey).(logrus.FieldLogger)
+	logger, ok := parent.Value(baseLogKey).(log.Logger)
 	if !ok {
-		logger := &logrus.Logger{
-			Out:          logrus.StandardLogger().Out,
-			Formatter:    logrus.StandardLogger().Formatter,
-			Hooks:        make(logrus.LevelHooks),
-			Level:        logrus.StandardLogger().Level,
-			ExitFunc:     logrus.StandardLogger().ExitFunc,
-			ReportCaller: logrus.StandardLogger().ReportCaller,
-		}
-		for k, v := range logrus.StandardLogger().Hooks {
-			logger.Hooks[k] = v
-		}
-		rv = logger
+		logger = log.FromContext(parent)
+	}
+	ctx := context.WithValue(span.Context(), baseLogKey, logger)
+	if logger == log.Default() {
+		logger = log.NewLogrus(span.Logger())
 	}
-	return rv
+	return log.WithLog(ctx, redact.Logger(logger.WithFields(fields)))
+}
+
+// Log - return log.Logger from context
+func Log(ctx context.Context) log.Logger {
+	return log.FromContext(ctx)
 }
@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/jsonpatch"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
+	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
+)
+
+const (
+	diffEnv  = "TRACER_DIFF_ENABLED"
+	noChange = "no changes"
+)
+
+// IsDiffEnabled - returns true if the trace chain elements log the full request (response) only once per chain and
+//                 after that only the JSON patch each chain element has made to it. Set TRACER_DIFF_ENABLED=true
+//                 environment variable to enable.
+func IsDiffEnabled() bool {
+	val, err := strconv.ParseBool(os.Getenv(diffEnv))
+	return err == nil && val
+}
+
+// diffInfo - the last logged request and the place for the next chain element to store its response
+type diffInfo struct {
+	request  []byte
+	response *[]byte
+}
+
+// diffKey - Request and Close are diffed separately, because context of the Close can be inherited from the Request
+//           (e.g. timeout)
+func diffKey(method string) contextKeyType {
+	return contextKeyType("Diff" + method)
+}
+
+func withDiffInfo(parent context.Context, method string, info *diffInfo) context.Context {
+	return context.WithValue(parent, diffKey(method), info)
+}
+
+func diffInfoFromContext(ctx context.Context, method string) *diffInfo {
+	if rv, ok := ctx.Value(diffKey(method)).(*diffInfo); ok {
+		return rv
+	}
+	return nil
+}
+
+// logRequest - logs the request or, in the diff mode, the JSON patch made to it by the previous chain element. Returns
+//              the context to pass to the traced chain element and the place where the next chain element stores its
+//              response (nil if the diff mode is disabled).
+func logRequest(ctx context.Context, span spanhelper.SpanHelper, method string, request interface{}) (context.Context, *[]byte) {
+	if !IsDiffEnabled() {
+		span.LogObject("request", request)
+		return ctx, nil
+	}
+	data := marshal(request)
+	if prev := diffInfoFromContext(ctx, method); prev != nil && prev.request != nil {
+		logDiff(span, "request", prev.request, data, request)
+	} else {
+		span.LogObject("request", request)
+	}
+	nextResponse := new([]byte)
+	return withDiffInfo(ctx, method, &diffInfo{request: data, response: nextResponse}), nextResponse
+}
+
+// logResponse - logs the response or, in the diff mode, the JSON patch made to the response of the next chain element
+func logResponse(ctx context.Context, span spanhelper.SpanHelper, method string, nextResponse *[]byte, response interface{}) {
+	if nextResponse == nil {
+		span.LogObject("response", response)
+		return
+	}
+	data := marshal(response)
+	if *nextResponse != nil {
+		logDiff(span, "response", *nextResponse, data, response)
+	} else {
+		span.LogObject("response", response)
+	}
+	if prev := diffInfoFromContext(ctx, method); prev != nil {
+		*prev.response = data
+	}
+}
+
+func logDiff(span spanhelper.SpanHelper, attribute string, from, to []byte, value interface{}) {
+	ops, err := jsonpatch.Diff(from, to)
+	switch {
+	case err != nil:
+		span.LogObject(attribute, value)
+	case len(ops) == 0:
+		span.LogValue(attribute+"-diff", noChange)
+	default:
+		span.LogObject(attribute+"-diff", ops)
+	}
+}
+
+func marshal(value interface{}) []byte {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return redact.Default().JSON(data)
+}
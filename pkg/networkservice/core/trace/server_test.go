@@ -0,0 +1,160 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type labelServer struct {
+	key, value string
+}
+
+func (s *labelServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	request.GetConnection().Labels = map[string]string{s.key: s.value}
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	conn.Context = &networkservice.ConnectionContext{}
+	return conn, nil
+}
+
+func (s *labelServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+type passThroughServer struct{}
+
+func (s *passThroughServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *passThroughServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+type logServer struct{}
+
+func (s *logServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	trace.Log(ctx).Infof("request")
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *logServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func captureLogs(t *testing.T, f func()) string {
+	buff := &bytes.Buffer{}
+	logrus.SetOutput(buff)
+	defer logrus.SetOutput(os.Stderr)
+
+	f()
+	return buff.String()
+}
+
+func TestTraceServer_Diff(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	require.NoError(t, os.Setenv("TRACER_DIFF_ENABLED", "true"))
+	defer func() { _ = os.Unsetenv("TRACER_DIFF_ENABLED") }()
+	require.True(t, trace.IsDiffEnabled())
+
+	server := chain.NewNetworkServiceServer(
+		&passThroughServer{},
+		&labelServer{key: "a", value: "1"},
+		&passThroughServer{},
+	)
+	logs := captureLogs(t, func() {
+		_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{Id: "id"},
+		})
+		require.NoError(t, err)
+	})
+
+	require.Equal(t, 1, strings.Count(logs, " request="))
+	require.Equal(t, 1, strings.Count(logs, " response="))
+	require.Equal(t, 2, strings.Count(logs, " request-diff="))
+	require.Equal(t, 2, strings.Count(logs, " response-diff="))
+	require.Contains(t, logs, `/connection/labels`)
+	require.Contains(t, logs, `/context`)
+	require.Contains(t, logs, "no changes")
+}
+
+func TestTraceServer_NoDiff(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	require.False(t, trace.IsDiffEnabled())
+
+	server := chain.NewNetworkServiceServer(
+		&passThroughServer{},
+		&labelServer{key: "a", value: "1"},
+		&passThroughServer{},
+	)
+	logs := captureLogs(t, func() {
+		_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{Id: "id"},
+		})
+		require.NoError(t, err)
+	})
+
+	require.Equal(t, 3, strings.Count(logs, " request="))
+	require.Equal(t, 3, strings.Count(logs, " response="))
+	require.NotContains(t, logs, "-diff=")
+}
+
+func TestTraceServer_LoggerFields(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	core, logs := observer.New(zap.DebugLevel)
+	ctx := log.WithLog(context.Background(), log.NewZap(zap.New(core)))
+
+	server := chain.NewNetworkServiceServer(
+		&passThroughServer{},
+		&logServer{},
+	)
+	_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id", NetworkService: "ns"},
+	})
+	require.NoError(t, err)
+
+	entries := logs.FilterMessage("request").All()
+	require.Len(t, entries, 1)
+
+	fields := entries[0].ContextMap()
+	require.Equal(t, "id", fields[log.ConnectionIDField])
+	require.Equal(t, "ns", fields[log.NetworkServiceField])
+	require.Contains(t, fields[log.ChainElementField], "logServer")
+}
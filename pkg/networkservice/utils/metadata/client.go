@@ -0,0 +1,63 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type metaDataClient struct {
+	metaDataMap
+}
+
+// NewClient - creates a new NetworkServiceClient chain element storing per-connection metadata for the next chain
+//             elements. Metadata is created on the initial Request, deleted if the initial Request fails or after the
+//             successful Close. Should be placed after setid.
+func NewClient() networkservice.NetworkServiceClient {
+	return &metaDataClient{}
+}
+
+func (m *metaDataClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	connID := request.GetConnection().GetId()
+	md, loaded := m.loadOrStore(connID)
+
+	conn, err := next.Client(ctx).Request(withMetaData(ctx, md), request, opts...)
+	if err != nil {
+		if !loaded {
+			m.delete(connID, md)
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (m *metaDataClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	md := m.load(conn.GetId())
+
+	rv, err := next.Client(ctx).Close(withMetaData(ctx, md), conn, opts...)
+	if err != nil {
+		return nil, err
+	}
+	m.delete(conn.GetId(), md)
+	return rv, nil
+}
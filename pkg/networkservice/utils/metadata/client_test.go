@@ -0,0 +1,93 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type sideClient struct {
+	isClient bool
+}
+
+func (c *sideClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	metadata.Map(ctx, c.isClient).Store("side", c.isClient)
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *sideClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func TestMetaDataClient(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	counter := &counterServer{}
+	client := chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		&sideClient{isClient: true},
+		adapters.NewServerToClient(counter))
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Request(context.Background(), request("1"))
+		require.NoError(t, err)
+	}
+	_, err := client.Close(context.Background(), request("1").GetConnection())
+	require.NoError(t, err)
+
+	// Server map is separate from the client one
+	require.Equal(t, []int{1, 2, -2}, counter.counters)
+
+	var ctx context.Context
+	client = chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		&sideClient{isClient: true},
+		adapters.NewServerToClient(&captureServer{ctx: &ctx}))
+	_, err = client.Request(context.Background(), request("1"))
+	require.NoError(t, err)
+
+	side, ok := metadata.Map(ctx, true).Load("side")
+	require.True(t, ok)
+	require.Equal(t, true, side)
+	_, ok = metadata.Map(ctx, false).Load("side")
+	require.False(t, ok)
+}
+
+type captureServer struct {
+	ctx *context.Context
+}
+
+func (s *captureServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	*s.ctx = ctx
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *captureServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
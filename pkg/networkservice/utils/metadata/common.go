@@ -0,0 +1,62 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"sync"
+)
+
+type metaDataMap struct {
+	entries map[string]*metaData
+	mutex   sync.Mutex
+}
+
+// loadOrStore - returns the connID metadata, creates a new one if there is no such
+func (m *metaDataMap) loadOrStore(connID string) (md *metaData, loaded bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.entries == nil {
+		m.entries = make(map[string]*metaData)
+	}
+	if md, loaded = m.entries[connID]; !loaded {
+		md = new(metaData)
+		m.entries[connID] = md
+	}
+	return md, loaded
+}
+
+// load - returns the connID metadata, a new not stored one if there is no such
+func (m *metaDataMap) load(connID string) *metaData {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if md, ok := m.entries[connID]; ok {
+		return md
+	}
+	return new(metaData)
+}
+
+// delete - deletes the connID metadata if it is md
+func (m *metaDataMap) delete(connID string, md *metaData) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.entries[connID] == md {
+		delete(m.entries, connID)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metadata provides chain elements storing per-connection metadata available to the next chain elements
+// with metadata.Map(ctx, isClient)
+package metadata
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	metaDataKey contextKeyType = "MetaData"
+)
+
+type contextKeyType string
+
+type metaData struct {
+	client sync.Map
+	server sync.Map
+}
+
+// withMetaData -
+//    Wraps 'parent' in a new Context that has the connection metadata
+func withMetaData(parent context.Context, md *metaData) context.Context {
+	if parent == nil {
+		parent = context.TODO()
+	}
+	return context.WithValue(parent, metaDataKey, md)
+}
+
+// Map -
+//   Returns the metadata map of the connection, it is kept across the Requests (including refreshes) and deleted after
+//   the successful Close of the connection.
+//   - isClient - true for the client chain elements, client and server chain elements have separate maps for the same
+//                connection
+//   Panics if there is no metadata chain element before the caller in the chain.
+func Map(ctx context.Context, isClient bool) *sync.Map {
+	md, ok := ctx.Value(metaDataKey).(*metaData)
+	if !ok {
+		panic("metadata chain element is missing in the chain")
+	}
+	if isClient {
+		return &md.client
+	}
+	return &md.server
+}
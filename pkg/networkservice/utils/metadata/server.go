@@ -0,0 +1,62 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type metaDataServer struct {
+	metaDataMap
+}
+
+// NewServer - creates a new NetworkServiceServer chain element storing per-connection metadata for the next chain
+//             elements. Metadata is created on the initial Request, deleted if the initial Request fails or after the
+//             successful Close. Should be placed after setid.
+func NewServer() networkservice.NetworkServiceServer {
+	return &metaDataServer{}
+}
+
+func (m *metaDataServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	connID := request.GetConnection().GetId()
+	md, loaded := m.loadOrStore(connID)
+
+	conn, err := next.Server(ctx).Request(withMetaData(ctx, md), request)
+	if err != nil {
+		if !loaded {
+			m.delete(connID, md)
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (m *metaDataServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	md := m.load(conn.GetId())
+
+	rv, err := next.Server(ctx).Close(withMetaData(ctx, md), conn)
+	if err != nil {
+		return nil, err
+	}
+	m.delete(conn.GetId(), md)
+	return rv, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+const counterKey = "counter"
+
+// counterServer - counts Requests of the connection in metadata, reports the counter on Close
+type counterServer struct {
+	counters []int
+	err      error
+}
+
+func (s *counterServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	raw, _ := metadata.Map(ctx, false).LoadOrStore(counterKey, 0)
+	counter := raw.(int) + 1
+	metadata.Map(ctx, false).Store(counterKey, counter)
+	s.counters = append(s.counters, counter)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *counterServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	raw, _ := metadata.Map(ctx, false).Load(counterKey)
+	counter, _ := raw.(int)
+	s.counters = append(s.counters, -counter)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func request(id string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: id},
+	}
+}
+
+func TestMetaDataServer(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	counter := &counterServer{}
+	server := chain.NewNetworkServiceServer(metadata.NewServer(), counter)
+
+	for i := 0; i < 3; i++ {
+		_, err := server.Request(context.Background(), request("1"))
+		require.NoError(t, err)
+	}
+	_, err := server.Request(context.Background(), request("2"))
+	require.NoError(t, err)
+
+	_, err = server.Close(context.Background(), request("1").GetConnection())
+	require.NoError(t, err)
+	// Metadata is deleted after Close
+	_, err = server.Close(context.Background(), request("1").GetConnection())
+	require.NoError(t, err)
+	_, err = server.Request(context.Background(), request("1"))
+	require.NoError(t, err)
+
+	require.Equal(t, []int{1, 2, 3, 1, -3, 0, 1}, counter.counters)
+}
+
+func TestMetaDataServer_Failure(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	counter := &counterServer{}
+	server := chain.NewNetworkServiceServer(metadata.NewServer(), counter)
+
+	// Metadata is deleted if the initial Request fails
+	counter.err = errors.New("failure")
+	_, err := server.Request(context.Background(), request("1"))
+	require.Error(t, err)
+	counter.err = nil
+	_, err = server.Request(context.Background(), request("1"))
+	require.NoError(t, err)
+
+	// Metadata is kept if the refresh or Close fails
+	counter.err = errors.New("failure")
+	_, err = server.Request(context.Background(), request("1"))
+	require.Error(t, err)
+	_, err = server.Close(context.Background(), request("1").GetConnection())
+	require.Error(t, err)
+	counter.err = nil
+	_, err = server.Close(context.Background(), request("1").GetConnection())
+	require.NoError(t, err)
+
+	require.Equal(t, []int{1, 1, 2, -2, -2}, counter.counters)
+}
+
+// storeOnCloseServer - stores the counter in metadata on Close and fails
+type storeOnCloseServer struct{}
+
+func (s *storeOnCloseServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *storeOnCloseServer) Close(ctx context.Context, _ *networkservice.Connection) (*empty.Empty, error) {
+	metadata.Map(ctx, false).Store(counterKey, 10)
+	return nil, errors.New("failure")
+}
+
+func TestMetaDataServer_CloseUnknown(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	counter := &counterServer{}
+	server := chain.NewNetworkServiceServer(metadata.NewServer(), &storeOnCloseServer{}, counter)
+
+	// Failed Close of the unknown connection doesn't store metadata
+	_, err := server.Close(context.Background(), request("1").GetConnection())
+	require.Error(t, err)
+	_, err = server.Request(context.Background(), request("1"))
+	require.NoError(t, err)
+
+	require.Equal(t, []int{1}, counter.counters)
+}
+
+func TestMetaData_Missing(t *testing.T) {
+	require.Panics(t, func() {
+		metadata.Map(context.Background(), false)
+	})
+}
@@ -23,7 +23,8 @@ import (
 )
 
 const (
-	clientURLKey contextKeyType = "ClientURL"
+	clientURLKey  contextKeyType = "ClientURL"
+	clientURLsKey contextKeyType = "ClientURLs"
 )
 
 type contextKeyType string
@@ -31,10 +32,25 @@ type contextKeyType string
 // WithClientURL -
 //    Wraps 'parent' in a new Context that has the ClientURL
 func WithClientURL(parent context.Context, clientURL *url.URL) context.Context {
+	if clientURL == nil {
+		return WithClientURLs(parent)
+	}
+	return WithClientURLs(parent, clientURL)
+}
+
+// WithClientURLs -
+//    Wraps 'parent' in a new Context that has the ordered list of the candidate ClientURLs, the first one is
+//    returned as the ClientURL
+func WithClientURLs(parent context.Context, clientURLs ...*url.URL) context.Context {
 	if parent == nil {
 		parent = context.TODO()
 	}
-	return context.WithValue(parent, clientURLKey, clientURL)
+	var clientURL *url.URL
+	if len(clientURLs) > 0 {
+		clientURL = clientURLs[0]
+	}
+	parent = context.WithValue(parent, clientURLKey, clientURL)
+	return context.WithValue(parent, clientURLsKey, clientURLs)
 }
 
 // ClientURL -
@@ -45,3 +61,12 @@ func ClientURL(ctx context.Context) *url.URL {
 	}
 	return nil
 }
+
+// ClientURLs -
+//   Returns the ordered list of the candidate ClientURLs
+func ClientURLs(ctx context.Context) []*url.URL {
+	if rv, ok := ctx.Value(clientURLsKey).([]*url.URL); ok {
+		return rv
+	}
+	return nil
+}
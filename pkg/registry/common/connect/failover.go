@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connect
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/trace"
+)
+
+// withFailover calls f for each of the clienturl.ClientURLs(ctx) candidates in order until f either succeeds or fails
+// with non retryable error
+func withFailover(ctx context.Context, f func(ctx context.Context) error) error {
+	urls := clienturl.ClientURLs(ctx)
+	if len(urls) < 2 {
+		return unwrapNoRetry(f(ctx))
+	}
+	var err error
+	for _, u := range urls {
+		err = f(clienturl.WithClientURL(ctx, u))
+		if _, ok := err.(*noRetryError); ok || !isUnavailable(err) || ctx.Err() != nil {
+			return unwrapNoRetry(err)
+		}
+		trace.Log(ctx).Warnf("%v is unavailable, trying the next candidate: %v", u, err)
+	}
+	return err
+}
+
+func isUnavailable(err error) bool {
+	return err != nil && status.Code(errors.Cause(err)) == codes.Unavailable
+}
+
+// noRetryError marks the error that should not be retried with the next candidate
+type noRetryError struct {
+	error
+}
+
+func unwrapNoRetry(err error) error {
+	if e, ok := err.(*noRetryError); ok {
+		return e.error
+	}
+	return err
+}
+
+// nseFindServerSentTracker tracks if anything has been sent to the wrapped stream, so Find can't be safely retried
+type nseFindServerSentTracker struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	sent bool
+}
+
+func (s *nseFindServerSentTracker) Send(nse *registry.NetworkServiceEndpoint) error {
+	s.sent = true
+	return s.NetworkServiceEndpointRegistry_FindServer.Send(nse)
+}
+
+// nsFindServerSentTracker tracks if anything has been sent to the wrapped stream, so Find can't be safely retried
+type nsFindServerSentTracker struct {
+	registry.NetworkServiceRegistry_FindServer
+	sent bool
+}
+
+func (s *nsFindServerSentTracker) Send(ns *registry.NetworkService) error {
+	s.sent = true
+	return s.NetworkServiceRegistry_FindServer.Send(ns)
+}
@@ -26,10 +26,12 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamcontext"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 type nsCacheEntry struct {
-	expirationTimer *time.Timer
+	expirationTimer clock.Timer
 	client          registry.NetworkServiceRegistryClient
 }
 
@@ -38,6 +40,7 @@ type connectNSServer struct {
 	clientFactory     func(ctx context.Context, cc grpc.ClientConnInterface) registry.NetworkServiceRegistryClient
 	cache             nsClientMap
 	connectExpiration time.Duration
+	clock             clock.Clock
 }
 
 // NewNetworkServiceRegistryServer creates new connect NetworkServiceEndpointRegistryServer with specific chain context, registry client factory and options
@@ -48,6 +51,7 @@ func NewNetworkServiceRegistryServer(
 	r := &connectNSServer{
 		clientFactory:     clientFactory,
 		connectExpiration: defaultConnectExpiration,
+		clock:             clock.New(),
 	}
 	for _, o := range options {
 		o.apply(r)
@@ -56,15 +60,32 @@ func NewNetworkServiceRegistryServer(
 }
 
 func (c *connectNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
-	return c.connect(ctx).Register(ctx, ns)
+	var resp *registry.NetworkService
+	err := withFailover(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.connect(ctx).Register(ctx, ns)
+		return err
+	})
+	return resp, err
 }
 
 func (c *connectNSServer) Find(q *registry.NetworkServiceQuery, s registry.NetworkServiceRegistry_FindServer) error {
-	return adapters.NetworkServiceClientToServer(c.connect(s.Context())).Find(q, s)
+	return withFailover(s.Context(), func(ctx context.Context) error {
+		tracker := &nsFindServerSentTracker{NetworkServiceRegistry_FindServer: s}
+		err := adapters.NetworkServiceClientToServer(c.connect(ctx)).Find(q, streamcontext.NetworkServiceRegistryFindServer(ctx, tracker))
+		if err != nil && tracker.sent {
+			return &noRetryError{error: err}
+		}
+		return err
+	})
 }
 
 func (c *connectNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
-	return c.connect(ctx).Unregister(ctx, ns)
+	var resp *empty.Empty
+	err := withFailover(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.connect(ctx).Unregister(ctx, ns)
+		return err
+	})
+	return resp, err
 }
 
 func (c *connectNSServer) connect(ctx context.Context) registry.NetworkServiceRegistryClient {
@@ -80,7 +101,7 @@ func (c *connectNSServer) connect(ctx context.Context) registry.NetworkServiceRe
 	}
 	client := clienturl.NewNetworkServiceRegistryClient(ctx, c.clientFactory, c.dialOptions...)
 	cached, _ := c.cache.LoadOrStore(key, &nsCacheEntry{
-		expirationTimer: time.AfterFunc(c.connectExpiration, func() {
+		expirationTimer: c.clock.AfterFunc(c.connectExpiration, func() {
 			c.cache.Delete(key)
 		}),
 		client: client,
@@ -96,4 +117,8 @@ func (c *connectNSServer) setClientDialOptions(opts []grpc.DialOption) {
 	c.dialOptions = opts
 }
 
+func (c *connectNSServer) setClock(clk clock.Clock) {
+	c.clock = clk
+}
+
 var _ registry.NetworkServiceRegistryServer = (*connectNSServer)(nil)
@@ -26,10 +26,12 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamcontext"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 type nseCacheEntry struct {
-	expirationTimer *time.Timer
+	expirationTimer clock.Timer
 	client          registry.NetworkServiceEndpointRegistryClient
 }
 
@@ -38,6 +40,7 @@ type connectNSEServer struct {
 	clientFactory     func(ctx context.Context, cc grpc.ClientConnInterface) registry.NetworkServiceEndpointRegistryClient
 	cache             nseClientMap
 	connectExpiration time.Duration
+	clock             clock.Clock
 }
 
 // NewNetworkServiceEndpointRegistryServer creates new connect NetworkServiceEndpointEndpointRegistryServer with specific chain context, registry client factory and options
@@ -48,6 +51,7 @@ func NewNetworkServiceEndpointRegistryServer(
 	r := &connectNSEServer{
 		clientFactory:     clientFactory,
 		connectExpiration: defaultConnectExpiration,
+		clock:             clock.New(),
 	}
 	for _, o := range options {
 		o.apply(r)
@@ -56,15 +60,32 @@ func NewNetworkServiceEndpointRegistryServer(
 }
 
 func (c *connectNSEServer) Register(ctx context.Context, ns *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
-	return c.connect(ctx).Register(ctx, ns)
+	var resp *registry.NetworkServiceEndpoint
+	err := withFailover(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.connect(ctx).Register(ctx, ns)
+		return err
+	})
+	return resp, err
 }
 
 func (c *connectNSEServer) Find(q *registry.NetworkServiceEndpointQuery, s registry.NetworkServiceEndpointRegistry_FindServer) error {
-	return adapters.NetworkServiceEndpointClientToServer(c.connect(s.Context())).Find(q, s)
+	return withFailover(s.Context(), func(ctx context.Context) error {
+		tracker := &nseFindServerSentTracker{NetworkServiceEndpointRegistry_FindServer: s}
+		err := adapters.NetworkServiceEndpointClientToServer(c.connect(ctx)).Find(q, streamcontext.NetworkServiceEndpointRegistryFindServer(ctx, tracker))
+		if err != nil && tracker.sent {
+			return &noRetryError{error: err}
+		}
+		return err
+	})
 }
 
 func (c *connectNSEServer) Unregister(ctx context.Context, ns *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
-	return c.connect(ctx).Unregister(ctx, ns)
+	var resp *empty.Empty
+	err := withFailover(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.connect(ctx).Unregister(ctx, ns)
+		return err
+	})
+	return resp, err
 }
 
 func (c *connectNSEServer) connect(ctx context.Context) registry.NetworkServiceEndpointRegistryClient {
@@ -80,7 +101,7 @@ func (c *connectNSEServer) connect(ctx context.Context) registry.NetworkServiceE
 	}
 	client := clienturl.NewNetworkServiceEndpointRegistryClient(ctx, c.clientFactory, c.dialOptions...)
 	cached, _ := c.cache.LoadOrStore(key, &nseCacheEntry{
-		expirationTimer: time.AfterFunc(c.connectExpiration, func() {
+		expirationTimer: c.clock.AfterFunc(c.connectExpiration, func() {
 			c.cache.Delete(key)
 		}),
 		client: client,
@@ -96,4 +117,8 @@ func (c *connectNSEServer) setClientDialOptions(opts []grpc.DialOption) {
 	c.dialOptions = opts
 }
 
+func (c *connectNSEServer) setClock(clk clock.Clock) {
+	c.clock = clk
+}
+
 var _ registry.NetworkServiceEndpointRegistryServer = (*connectNSEServer)(nil)
@@ -91,3 +91,34 @@ func TestConnect_NewNetworkServiceEndpointRegistryServer(t *testing.T) {
 		return goleak.Find() != nil
 	}, time.Second, time.Microsecond*100)
 }
+
+func TestConnect_NewNetworkServiceEndpointRegistryServer_Failover(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	deadURL := grpcutils.AddressToURL(l.Addr())
+	require.Nil(t, l.Close())
+
+	aliveURL, closeServer := startNSEServer(t)
+	defer closeServer()
+
+	s := connect.NewNetworkServiceEndpointRegistryServer(func(_ context.Context, cc grpc.ClientConnInterface) registry.NetworkServiceEndpointRegistryClient {
+		return registry.NewNetworkServiceEndpointRegistryClient(cc)
+	}, connect.WithClientDialOptions(grpc.WithInsecure()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	ctx = clienturl.WithClientURLs(ctx, deadURL, aliveURL)
+
+	_, err = s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+
+	ch := make(chan *registry.NetworkServiceEndpoint, 1)
+	err = s.Find(&registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+	}}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, ch))
+	require.Nil(t, err)
+	require.Equal(t, "nse-1", (<-ch).Name)
+
+	_, err = s.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+}
@@ -20,11 +20,14 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 type configurable interface {
 	setExpirationDuration(time.Duration)
 	setClientDialOptions([]grpc.DialOption)
+	setClock(clock.Clock)
 }
 
 // Option configures connect servers
@@ -51,3 +54,10 @@ func WithClientDialOptions(opts ...grpc.DialOption) Option {
 		c.setClientDialOptions(opts)
 	})
 }
+
+// WithClock sets the clock used to expire the connections, by default the real clock is used
+func WithClock(c clock.Clock) Option {
+	return applyOptionFunc(func(cfg configurable) {
+		cfg.setClock(c)
+	})
+}
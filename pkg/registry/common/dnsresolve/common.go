@@ -18,10 +18,17 @@ package dnsresolve
 
 import (
 	"context"
-	"errors"
-	"fmt"
+	"math/rand"
 	"net"
 	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 // Resolver is DNS resolver
@@ -36,34 +43,210 @@ type Resolver interface {
 	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
 }
 
-func resolveDomain(ctx context.Context, service, domain string, r Resolver) (*url.URL, error) {
-	_, records, err := r.LookupSRV(ctx, service, "tcp", domain)
+// TTLResolver is DNS resolver also providing TTL of the resolved records. Resolved domains are cached only if the
+// resolver is TTLResolver, because net.Resolver doesn't provide TTL.
+type TTLResolver interface {
+	Resolver
+	// LookupSRVTTL is the same as LookupSRV, but also returns the minimal TTL of the records
+	LookupSRVTTL(ctx context.Context, service, proto, name string) (string, []*net.SRV, time.Duration, error)
+	// LookupIPAddrTTL is the same as LookupIPAddr, but also returns the minimal TTL of the records
+	LookupIPAddrTTL(ctx context.Context, host string) ([]net.IPAddr, time.Duration, error)
+}
+
+type resolvedDomain struct {
+	records    []*net.SRV
+	hosts      map[string][]net.IPAddr
+	expiration time.Time
+}
 
+// domainResolver resolves domains to the ordered lists of the candidate URLs and caches the resolved records
+type domainResolver struct {
+	resolver      Resolver
+	service       string
+	cacheDuration time.Duration
+	clock         clock.Clock
+	cache         map[string]*resolvedDomain
+	mutex         sync.Mutex
+}
+
+func newDomainResolver() *domainResolver {
+	return &domainResolver{
+		resolver:      net.DefaultResolver,
+		service:       NSMRegistryService,
+		cacheDuration: defaultCacheDuration,
+		clock:         clock.New(),
+		cache:         map[string]*resolvedDomain{},
+	}
+}
+
+// resolve returns URLs for all the SRV records of the domain ordered according to the RFC 2782: records are sorted by
+// priority and shuffled by weight within a priority. Every record produces an URL for each of its target addresses.
+func (d *domainResolver) resolve(ctx context.Context, domain string) ([]*url.URL, error) {
+	resolved, err := d.lookup(ctx, domain)
 	if err != nil {
 		return nil, err
 	}
+	var urls []*url.URL
+	for _, record := range orderByPriorityWeight(resolved.records) {
+		for _, ip := range resolved.hosts[record.Target] {
+			urls = append(urls, &url.URL{
+				Scheme: "tcp",
+				Host:   net.JoinHostPort(ip.IP.String(), strconv.Itoa(int(record.Port))),
+			})
+		}
+	}
+	return urls, nil
+}
 
-	if len(records) == 0 {
-		return nil, errors.New("resolver.LookupSERV return empty result")
+func (d *domainResolver) lookup(ctx context.Context, domain string) (*resolvedDomain, error) {
+	d.mutex.Lock()
+	resolved, ok := d.cache[domain]
+	d.mutex.Unlock()
+	if ok && d.clock.Now().Before(resolved.expiration) {
+		return resolved, nil
 	}
 
-	ips, err := r.LookupIPAddr(ctx, records[0].Target)
+	ttlResolver, canCache := d.resolver.(TTLResolver)
+	canCache = canCache && d.cacheDuration > 0
 
+	ttl := d.cacheDuration
+	records, srvTTL, err := d.lookupSRV(ctx, ttlResolver, domain)
 	if err != nil {
 		return nil, err
 	}
+	if len(records) == 0 {
+		return nil, errors.New("resolver.LookupSRV return empty result")
+	}
+	ttl = minDuration(ttl, srvTTL)
 
-	if len(ips) == 0 {
+	resolved = &resolvedDomain{
+		records: records,
+		hosts:   map[string][]net.IPAddr{},
+	}
+	for _, record := range records {
+		if _, ok := resolved.hosts[record.Target]; ok {
+			continue
+		}
+		ips, ipTTL, lookupErr := d.lookupIPAddr(ctx, ttlResolver, record.Target)
+		if lookupErr != nil {
+			// Partially resolved domain is not cached, so the failed lookup is retried next time
+			err = lookupErr
+			canCache = false
+			continue
+		}
+		resolved.hosts[record.Target] = ips
+		ttl = minDuration(ttl, ipTTL)
+	}
+	if !resolved.hasAddresses() {
+		if err != nil {
+			return nil, err
+		}
 		return nil, errors.New("resolver.LookupIPAddr return empty result")
 	}
 
-	u, err := url.Parse(fmt.Sprintf("tcp://%v:%v", ips[0].IP, records[0].Port))
+	if canCache && ttl > 0 {
+		resolved.expiration = d.clock.Now().Add(ttl)
+		d.mutex.Lock()
+		d.cache[domain] = resolved
+		d.mutex.Unlock()
+	}
+	return resolved, nil
+}
 
-	if err != nil {
-		return nil, err
+func (d *domainResolver) lookupSRV(ctx context.Context, ttlResolver TTLResolver, domain string) ([]*net.SRV, time.Duration, error) {
+	if ttlResolver != nil {
+		_, records, ttl, err := ttlResolver.LookupSRVTTL(ctx, d.service, "tcp", domain)
+		return records, ttl, err
+	}
+	_, records, err := d.resolver.LookupSRV(ctx, d.service, "tcp", domain)
+	return records, 0, err
+}
+
+func (d *domainResolver) lookupIPAddr(ctx context.Context, ttlResolver TTLResolver, host string) ([]net.IPAddr, time.Duration, error) {
+	if ttlResolver != nil {
+		return ttlResolver.LookupIPAddrTTL(ctx, host)
+	}
+	ips, err := d.resolver.LookupIPAddr(ctx, host)
+	return ips, 0, err
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (r *resolvedDomain) hasAddresses() bool {
+	for _, ips := range r.hosts {
+		if len(ips) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *domainResolver) setResolver(r Resolver) {
+	d.resolver = r
+}
+
+func (d *domainResolver) setService(service string) {
+	d.service = service
+}
+
+func (d *domainResolver) setCacheDuration(duration time.Duration) {
+	d.cacheDuration = duration
+}
+
+func (d *domainResolver) setClock(c clock.Clock) {
+	d.clock = c
+}
+
+// orderByPriorityWeight returns a copy of the records sorted by priority and shuffled by weight within a priority.
+func orderByPriorityWeight(records []*net.SRV) []*net.SRV {
+	ordered := append([]*net.SRV(nil), records...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority < ordered[j].Priority
+	})
+	for i := 0; i < len(ordered); {
+		j := i + 1
+		for j < len(ordered) && ordered[j].Priority == ordered[i].Priority {
+			j++
+		}
+		shuffleByWeight(ordered[i:j])
+		i = j
 	}
+	return ordered
+}
 
-	return u, nil
+// shuffleByWeight orders records of the same priority according to the RFC 2782: records with zero weight are placed
+// at the beginning of the not yet ordered records, a random number from [0, sum of weights] is chosen and the first
+// record with the running sum of weights greater or equal to it is selected. So records with zero weight have a small
+// chance to be selected while there are records with non-zero weight.
+func shuffleByWeight(records []*net.SRV) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Weight == 0 && records[j].Weight != 0
+	})
+	sum := 0
+	for _, record := range records {
+		sum += int(record.Weight)
+	}
+	for len(records) > 1 {
+		s := 0
+		n := rand.Intn(sum + 1)
+		for i := range records {
+			s += int(records[i].Weight)
+			if s >= n {
+				// Move the selected record to the front keeping the order of the rest
+				selected := records[i]
+				copy(records[1:i+1], records[:i])
+				records[0] = selected
+				break
+			}
+		}
+		sum -= int(records[0].Weight)
+		records = records[1:]
+	}
 }
 
 var _ Resolver = (*net.Resolver)(nil)
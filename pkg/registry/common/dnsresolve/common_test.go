@@ -21,14 +21,18 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"time"
 )
 
 type testResolver struct {
 	srvRecords  map[string][]*net.SRV
 	hostRecords map[string][]net.IPAddr
+	ttl         time.Duration
+	srvLookups  int
 }
 
 func (t *testResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	t.srvLookups++
 	key := fmt.Sprintf("_%v._%v.%v", service, proto, name)
 	result := t.srvRecords[key]
 	if len(result) == 0 {
@@ -44,3 +48,13 @@ func (t *testResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPA
 	}
 	return result, nil
 }
+
+func (t *testResolver) LookupSRVTTL(ctx context.Context, service, proto, name string) (string, []*net.SRV, time.Duration, error) {
+	cname, records, err := t.LookupSRV(ctx, service, proto, name)
+	return cname, records, t.ttl, err
+}
+
+func (t *testResolver) LookupIPAddrTTL(ctx context.Context, host string) ([]net.IPAddr, time.Duration, error) {
+	ips, err := t.LookupIPAddr(ctx, host)
+	return ips, t.ttl, err
+}
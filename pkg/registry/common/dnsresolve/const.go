@@ -16,5 +16,9 @@
 
 package dnsresolve
 
+import "time"
+
 // NSMRegistryService is default service to lookup SRV records
 const NSMRegistryService = "nsm-registry-svc"
+
+const defaultCacheDuration = time.Second * 30
@@ -14,5 +14,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package dnsresolve provides registry chain elements that can resolve passed Domain to URL
+// Package dnsresolve provides registry chain elements that can resolve passed Domain to the ordered list of the
+// candidate URLs using DNS SRV records
 package dnsresolve
@@ -18,7 +18,6 @@ package dnsresolve
 
 import (
 	"context"
-	"net"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/interdomain"
 
@@ -31,15 +30,13 @@ import (
 )
 
 type dnsNSResolveServer struct {
-	resolver Resolver
-	service  string
+	*domainResolver
 }
 
 // NewNetworkServiceRegistryServer creates new NetworkServiceRegistryServer that can resolve passed domain to clienturl
 func NewNetworkServiceRegistryServer(options ...Option) registry.NetworkServiceRegistryServer {
 	r := &dnsNSResolveServer{
-		resolver: net.DefaultResolver,
-		service:  NSMRegistryService,
+		domainResolver: newDomainResolver(),
 	}
 
 	for _, o := range options {
@@ -51,40 +48,32 @@ func NewNetworkServiceRegistryServer(options ...Option) registry.NetworkServiceR
 
 func (d *dnsNSResolveServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
 	domain := interdomain.Domain(ns.Name)
-	url, err := resolveDomain(ctx, d.service, domain, d.resolver)
+	urls, err := d.resolve(ctx, domain)
 	if err != nil {
 		return nil, err
 	}
-	ctx = clienturl.WithClientURL(ctx, url)
+	ctx = clienturl.WithClientURLs(ctx, urls...)
 	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
 }
 
 func (d *dnsNSResolveServer) Find(q *registry.NetworkServiceQuery, s registry.NetworkServiceRegistry_FindServer) error {
 	ctx := s.Context()
 	domain := interdomain.Domain(q.NetworkService.Name)
-	url, err := resolveDomain(ctx, d.service, domain, d.resolver)
+	urls, err := d.resolve(ctx, domain)
 	if err != nil {
 		return err
 	}
-	ctx = clienturl.WithClientURL(s.Context(), url)
+	ctx = clienturl.WithClientURLs(s.Context(), urls...)
 	s = streamcontext.NetworkServiceRegistryFindServer(ctx, s)
 	return next.NetworkServiceRegistryServer(s.Context()).Find(q, s)
 }
 
 func (d *dnsNSResolveServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
 	domain := interdomain.Domain(ns.Name)
-	url, err := resolveDomain(ctx, d.service, domain, d.resolver)
+	urls, err := d.resolve(ctx, domain)
 	if err != nil {
 		return nil, err
 	}
-	ctx = clienturl.WithClientURL(ctx, url)
+	ctx = clienturl.WithClientURLs(ctx, urls...)
 	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
 }
-
-func (d *dnsNSResolveServer) setResolver(r Resolver) {
-	d.resolver = r
-}
-
-func (d *dnsNSResolveServer) setService(service string) {
-	d.service = service
-}
@@ -18,7 +18,6 @@ package dnsresolve
 
 import (
 	"context"
-	"net"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/interdomain"
 
@@ -31,19 +30,13 @@ import (
 )
 
 type dnsNSEResolveServer struct {
-	resolver Resolver
-	service  string
-}
-
-func (d *dnsNSEResolveServer) setService(service string) {
-	d.service = service
+	*domainResolver
 }
 
 // NewNetworkServiceEndpointRegistryServer creates new NetworkServiceRegistryServer that can resolve passed domain to clienturl
 func NewNetworkServiceEndpointRegistryServer(options ...Option) registry.NetworkServiceEndpointRegistryServer {
 	r := &dnsNSEResolveServer{
-		resolver: net.DefaultResolver,
-		service:  NSMRegistryService,
+		domainResolver: newDomainResolver(),
 	}
 
 	for _, o := range options {
@@ -55,36 +48,36 @@ func NewNetworkServiceEndpointRegistryServer(options ...Option) registry.Network
 
 func (d *dnsNSEResolveServer) Register(ctx context.Context, ns *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
 	domain := interdomain.Domain(ns.Name)
-	url, err := resolveDomain(ctx, d.service, domain, d.resolver)
+	urls, err := d.resolve(ctx, domain)
 	if err != nil {
 		return nil, err
 	}
-	ctx = clienturl.WithClientURL(ctx, url)
+	ctx = clienturl.WithClientURLs(ctx, urls...)
 	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, ns)
 }
 
 func (d *dnsNSEResolveServer) Find(q *registry.NetworkServiceEndpointQuery, s registry.NetworkServiceEndpointRegistry_FindServer) error {
 	ctx := s.Context()
-	domain := interdomain.Domain(q.NetworkServiceEndpoint.Name)
-	url, err := resolveDomain(ctx, d.service, domain, d.resolver)
+	if interdomain.URL(q.NetworkServiceEndpoint.Name) != nil {
+		// Domain is already resolved to the URL
+		return next.NetworkServiceEndpointRegistryServer(ctx).Find(q, s)
+	}
+	domain := interdomain.FirstDomain(append([]string{q.NetworkServiceEndpoint.Name}, q.NetworkServiceEndpoint.NetworkServiceNames...)...)
+	urls, err := d.resolve(ctx, domain)
 	if err != nil {
 		return err
 	}
-	ctx = clienturl.WithClientURL(s.Context(), url)
+	ctx = clienturl.WithClientURLs(s.Context(), urls...)
 	s = streamcontext.NetworkServiceEndpointRegistryFindServer(ctx, s)
 	return next.NetworkServiceEndpointRegistryServer(s.Context()).Find(q, s)
 }
 
 func (d *dnsNSEResolveServer) Unregister(ctx context.Context, ns *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
 	domain := interdomain.Domain(ns.Name)
-	url, err := resolveDomain(ctx, d.service, domain, d.resolver)
+	urls, err := d.resolve(ctx, domain)
 	if err != nil {
 		return nil, err
 	}
-	ctx = clienturl.WithClientURL(ctx, url)
+	ctx = clienturl.WithClientURLs(ctx, urls...)
 	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, ns)
 }
-
-func (d *dnsNSEResolveServer) setResolver(r Resolver) {
-	d.resolver = r
-}
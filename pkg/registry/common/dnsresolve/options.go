@@ -16,9 +16,17 @@
 
 package dnsresolve
 
+import (
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
 type configurable interface {
 	setResolver(Resolver)
 	setService(string)
+	setCacheDuration(time.Duration)
+	setClock(clock.Clock)
 }
 
 // Option is option to configure dnsresovle chain elements
@@ -45,3 +53,18 @@ func WithService(service string) Option {
 		c.setService(service)
 	})
 }
+
+// WithCacheDuration sets the maximum duration resolved DNS records are cached, by default 30 seconds. Records are cached
+// for their TTL limited by the duration and only if the resolver is TTLResolver. Zero disables the cache.
+func WithCacheDuration(duration time.Duration) Option {
+	return optionApplyFunc(func(c configurable) {
+		c.setCacheDuration(duration)
+	})
+}
+
+// WithClock sets the clock used to expire the cached DNS records, by default the real clock is used
+func WithClock(c clock.Clock) Option {
+	return optionApplyFunc(func(cfg configurable) {
+		cfg.setClock(c)
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsresolve_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/dnsresolve"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type captureNSEURLs struct {
+	urls []*url.URL
+}
+
+func (c *captureNSEURLs) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	c.urls = clienturl.ClientURLs(ctx)
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (c *captureNSEURLs) Find(q *registry.NetworkServiceEndpointQuery, s registry.NetworkServiceEndpointRegistry_FindServer) error {
+	c.urls = clienturl.ClientURLs(s.Context())
+	return next.NetworkServiceEndpointRegistryServer(s.Context()).Find(q, s)
+}
+
+func (c *captureNSEURLs) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	c.urls = clienturl.ClientURLs(ctx)
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+func urlStrings(urls []*url.URL) []string {
+	var rv []string
+	for _, u := range urls {
+		rv = append(rv, u.String())
+	}
+	return rv
+}
+
+func TestResolve_PriorityAndIPv6(t *testing.T) {
+	resolver := &testResolver{
+		srvRecords: map[string][]*net.SRV{
+			"_" + dnsresolve.NSMRegistryService + "._tcp.domain1": {
+				{Target: "backup", Port: 5002, Priority: 20},
+				{Target: "primary", Port: 5001, Priority: 10},
+			},
+		},
+		hostRecords: map[string][]net.IPAddr{
+			"primary": {{IP: net.ParseIP("127.0.0.1")}, {IP: net.ParseIP("::1")}},
+			"backup":  {{IP: net.ParseIP("fe80::1")}},
+		},
+	}
+	capture := &captureNSEURLs{}
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		dnsresolve.NewNetworkServiceEndpointRegistryServer(dnsresolve.WithResolver(resolver)),
+		capture)
+
+	_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1@domain1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"tcp://127.0.0.1:5001",
+		"tcp://[::1]:5001",
+		"tcp://[fe80::1]:5002",
+	}, urlStrings(capture.urls))
+	require.Equal(t, "tcp://127.0.0.1:5001", clienturl.ClientURL(clienturl.WithClientURLs(context.Background(), capture.urls...)).String())
+}
+
+func TestResolve_Weight(t *testing.T) {
+	resolver := &testResolver{
+		srvRecords: map[string][]*net.SRV{
+			"_" + dnsresolve.NSMRegistryService + "._tcp.domain1": {
+				{Target: "zero", Port: 5000, Priority: 10, Weight: 0},
+				{Target: "light", Port: 5001, Priority: 10, Weight: 10},
+				{Target: "heavy", Port: 5002, Priority: 10, Weight: 90},
+			},
+		},
+		hostRecords: map[string][]net.IPAddr{
+			"zero":  {{IP: net.ParseIP("127.0.0.1")}},
+			"light": {{IP: net.ParseIP("127.0.0.2")}},
+			"heavy": {{IP: net.ParseIP("127.0.0.3")}},
+		},
+	}
+	capture := &captureNSEURLs{}
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		dnsresolve.NewNetworkServiceEndpointRegistryServer(dnsresolve.WithResolver(resolver)),
+		capture)
+
+	first := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1@domain1"})
+		require.NoError(t, err)
+		require.Len(t, capture.urls, 3)
+		first[capture.urls[0].String()]++
+	}
+	require.Greater(t, first["tcp://127.0.0.3:5002"], first["tcp://127.0.0.2:5001"])
+	require.Greater(t, first["tcp://127.0.0.2:5001"], first["tcp://127.0.0.1:5000"])
+	require.Greater(t, first["tcp://127.0.0.1:5000"], 0)
+	require.Less(t, first["tcp://127.0.0.1:5000"], 100)
+}
+
+func newCacheTestResolver(ttl time.Duration) *testResolver {
+	return &testResolver{
+		srvRecords: map[string][]*net.SRV{
+			"_" + dnsresolve.NSMRegistryService + "._tcp.domain1": {
+				{Target: "domain1", Port: 5001},
+				{Target: "domain2", Port: 5002},
+			},
+		},
+		hostRecords: map[string][]net.IPAddr{
+			"domain1": {{IP: net.ParseIP("127.0.0.1")}},
+			"domain2": {{IP: net.ParseIP("127.0.0.2")}},
+		},
+		ttl: ttl,
+	}
+}
+
+func register(t *testing.T, s registry.NetworkServiceEndpointRegistryServer, count int) {
+	for i := 0; i < count; i++ {
+		_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1@domain1"})
+		require.NoError(t, err)
+	}
+}
+
+func TestResolve_Cache(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	resolver := newCacheTestResolver(time.Minute)
+	s := dnsresolve.NewNetworkServiceEndpointRegistryServer(dnsresolve.WithResolver(resolver),
+		dnsresolve.WithCacheDuration(time.Hour), dnsresolve.WithClock(clk))
+	register(t, s, 3)
+	require.Equal(t, 1, resolver.srvLookups)
+
+	// Records are cached for TTL
+	clk.Add(time.Minute - time.Second)
+	register(t, s, 1)
+	require.Equal(t, 1, resolver.srvLookups)
+	clk.Add(time.Second)
+	register(t, s, 1)
+	require.Equal(t, 2, resolver.srvLookups)
+}
+
+func TestResolve_CacheDurationLimitsTTL(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	resolver := newCacheTestResolver(time.Hour)
+	s := dnsresolve.NewNetworkServiceEndpointRegistryServer(dnsresolve.WithResolver(resolver),
+		dnsresolve.WithCacheDuration(time.Minute), dnsresolve.WithClock(clk))
+	register(t, s, 1)
+	clk.Add(time.Minute)
+	register(t, s, 1)
+	require.Equal(t, 2, resolver.srvLookups)
+}
+
+func TestResolve_NoCache(t *testing.T) {
+	// Cache is disabled
+	resolver := newCacheTestResolver(time.Minute)
+	register(t, dnsresolve.NewNetworkServiceEndpointRegistryServer(dnsresolve.WithResolver(resolver),
+		dnsresolve.WithCacheDuration(0)), 3)
+	require.Equal(t, 3, resolver.srvLookups)
+
+	// TTL is unknown
+	resolver = newCacheTestResolver(time.Minute)
+	register(t, dnsresolve.NewNetworkServiceEndpointRegistryServer(dnsresolve.WithResolver(struct {
+		dnsresolve.Resolver
+	}{resolver})), 3)
+	require.Equal(t, 3, resolver.srvLookups)
+
+	// One of the targets is not resolved
+	resolver = newCacheTestResolver(time.Minute)
+	delete(resolver.hostRecords, "domain2")
+	register(t, dnsresolve.NewNetworkServiceEndpointRegistryServer(dnsresolve.WithResolver(resolver)), 3)
+	require.Equal(t, 3, resolver.srvLookups)
+}
@@ -20,17 +20,19 @@ import (
 	"time"
 
 	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 const defaultPeriod = time.Second * 5
 
-func getExpiredNSEs(nseMap map[string]*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+func getExpiredNSEs(c clock.Clock, nseMap map[string]*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
 	var list []*registry.NetworkServiceEndpoint
 	for _, v := range nseMap {
 		if v.ExpirationTime == nil {
 			continue
 		}
-		if time.Until(time.Unix(v.ExpirationTime.Seconds, int64(v.ExpirationTime.Nanos))) <= 0 {
+		if c.Until(time.Unix(v.ExpirationTime.Seconds, int64(v.ExpirationTime.Nanos))) <= 0 {
 			list = append(list, v)
 		}
 	}
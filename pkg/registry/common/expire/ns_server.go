@@ -24,6 +24,8 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 type nsServer struct {
@@ -31,6 +33,7 @@ type nsServer struct {
 	nseClient  registry.NetworkServiceEndpointRegistryClient
 	once       sync.Once
 	period     time.Duration
+	clock      clock.Clock
 	monitorErr error
 	nses       map[string]*registry.NetworkServiceEndpoint
 	nsCounter  map[string]int64
@@ -42,6 +45,10 @@ func (n *nsServer) setPeriod(d time.Duration) {
 	n.period = d
 }
 
+func (n *nsServer) setClock(c clock.Clock) {
+	n.clock = c
+}
+
 func (n *nsServer) monitorUpdates() {
 	for {
 		c, err := n.nseClient.Find(context.Background(), &registry.NetworkServiceEndpointQuery{
@@ -52,8 +59,8 @@ func (n *nsServer) monitorUpdates() {
 			n.monitorErr = err
 			return
 		}
-		for event := range registry.ReadNetworkServiceEndpointChannel(c) {
-			nse := event
+		// Events are received one by one, so each event is handled before the next one is received
+		for nse, err := c.Recv(); err == nil; nse, err = c.Recv() {
 			n.Lock()
 			_, exist := n.nses[nse.Name]
 			n.nses[nse.Name] = nse
@@ -71,7 +78,7 @@ func (n *nsServer) monitorNSEsExpiration() {
 	for {
 		var list []*registry.NetworkService
 		n.Lock()
-		for _, nse := range getExpiredNSEs(n.nses) {
+		for _, nse := range getExpiredNSEs(n.clock, n.nses) {
 			for _, service := range nse.NetworkServiceNames {
 				n.nsCounter[service]--
 				if n.nsCounter[service] == 0 {
@@ -88,7 +95,7 @@ func (n *nsServer) monitorNSEsExpiration() {
 			_, _ = n.server.Unregister(context.Background(), ns)
 		}
 		n.Unlock()
-		<-time.After(n.period)
+		<-n.clock.After(n.period)
 	}
 }
 
@@ -140,6 +147,8 @@ func NewNetworkServiceServer(s registry.NetworkServiceRegistryServer, nseClient
 	r := &nsServer{
 		server:    s,
 		nseClient: nseClient,
+		period:    defaultPeriod,
+		clock:     clock.New(),
 		nsCounter: map[string]int64{},
 		nss:       map[string]*registry.NetworkService{},
 		nses:      map[string]*registry.NetworkServiceEndpoint{},
@@ -24,20 +24,55 @@ import (
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/networkservicemesh/api/pkg/api/registry"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/setid"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
+// recvClient notifies recvCh each time Recv is called on the Find stream, so the test knows that all the previously
+// received events are handled
+type recvClient struct {
+	registry.NetworkServiceEndpointRegistryClient
+	recvCh chan struct{}
+}
+
+func (c *recvClient) Find(ctx context.Context, query *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	stream, err := c.NetworkServiceEndpointRegistryClient.Find(ctx, query, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &recvStream{NetworkServiceEndpointRegistry_FindClient: stream, recvCh: c.recvCh}, nil
+}
+
+type recvStream struct {
+	registry.NetworkServiceEndpointRegistry_FindClient
+	recvCh chan struct{}
+}
+
+func (s *recvStream) Recv() (*registry.NetworkServiceEndpoint, error) {
+	s.recvCh <- struct{}{}
+	return s.NetworkServiceEndpointRegistry_FindClient.Recv()
+}
+
+func newRecvClient(s registry.NetworkServiceEndpointRegistryServer) *recvClient {
+	return &recvClient{
+		NetworkServiceEndpointRegistryClient: adapters.NetworkServiceEndpointServerToClient(s),
+		recvCh:                               make(chan struct{}, 10),
+	}
+}
+
 func TestNewNetworkServiceRegistryServer(t *testing.T) {
+	fakeClock := clock.NewFake(time.Now())
 	nseMem := next.NewNetworkServiceEndpointRegistryServer(
 		setid.NewNetworkServiceEndpointRegistryServer(),
 		memory.NewNetworkServiceEndpointRegistryServer(),
 	)
-	expiration := time.Now().Add(testPeriod * 2)
+	expiration := fakeClock.Now().Add(testPeriod * 2)
 	_, err := nseMem.Register(context.Background(), &registry.NetworkServiceEndpoint{
 		NetworkServiceNames: []string{"IP terminator"},
 		ExpirationTime: &timestamp.Timestamp{
@@ -46,36 +81,32 @@ func TestNewNetworkServiceRegistryServer(t *testing.T) {
 		},
 	})
 	require.Nil(t, err)
-	nseClient := adapters.NetworkServiceEndpointServerToClient(nseMem)
+	nseClient := newRecvClient(nseMem)
 	nsMem := memory.NewNetworkServiceRegistryServer()
-	s := expire.NewNetworkServiceServer(nsMem, nseClient, expire.WithPeriod(testPeriod))
+	s := expire.NewNetworkServiceServer(nsMem, nseClient, expire.WithPeriod(testPeriod), expire.WithClock(fakeClock))
 	_, err = s.Register(context.Background(), &registry.NetworkService{
 		Name: "IP terminator",
 	})
 	require.Nil(t, err)
 	nsClient := adapters.NetworkServiceServerToClient(s)
-	stream, err := nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
-		NetworkService: &registry.NetworkService{},
-	})
-	require.Nil(t, err)
-	list := registry.ReadNetworkServiceList(stream)
-	require.NotEmpty(t, list)
-
-	require.Eventually(t, func() bool {
-		stream, err = nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
-			NetworkService: &registry.NetworkService{},
-		})
-		require.Nil(t, err)
-		list = registry.ReadNetworkServiceList(stream)
-		return len(list) == 0
-	}, time.Second, time.Millisecond*100)
+	require.NotEmpty(t, findNSs(t, nsClient))
+
+	// Wait for the NSE to be received and handled
+	<-nseClient.recvCh
+	<-nseClient.recvCh
+
+	fakeClock.BlockUntil(1)
+	fakeClock.Add(testPeriod * 2)
+	fakeClock.BlockUntil(1)
+	require.Empty(t, findNSs(t, nsClient))
 }
 
 func TestNewNetworkServiceRegistryServer_NSEUnregister(t *testing.T) {
+	fakeClock := clock.NewFake(time.Now())
 	nseMem := next.NewNetworkServiceEndpointRegistryServer(
 		memory.NewNetworkServiceEndpointRegistryServer(),
 	)
-	expiration := time.Now().Add(time.Hour)
+	expiration := fakeClock.Now().Add(time.Hour)
 	_, err := nseMem.Register(context.Background(), &registry.NetworkServiceEndpoint{
 		Name:                "nse-1",
 		NetworkServiceNames: []string{"IP terminator"},
@@ -85,32 +116,65 @@ func TestNewNetworkServiceRegistryServer_NSEUnregister(t *testing.T) {
 		},
 	})
 	require.Nil(t, err)
-	nseClient := adapters.NetworkServiceEndpointServerToClient(nseMem)
+	nseClient := newRecvClient(nseMem)
 	nsMem := memory.NewNetworkServiceRegistryServer()
-	s := expire.NewNetworkServiceServer(nsMem, nseClient, expire.WithPeriod(testPeriod))
+	s := expire.NewNetworkServiceServer(nsMem, nseClient, expire.WithPeriod(testPeriod), expire.WithClock(fakeClock))
 	_, err = s.Register(context.Background(), &registry.NetworkService{
 		Name: "IP terminator",
 	})
 	require.Nil(t, err)
 	nsClient := adapters.NetworkServiceServerToClient(s)
-	stream, err := nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
-		NetworkService: &registry.NetworkService{},
-	})
-	require.Nil(t, err)
-	list := registry.ReadNetworkServiceList(stream)
-	require.NotEmpty(t, list)
-	<-time.After(testPeriod * 2)
+	require.NotEmpty(t, findNSs(t, nsClient))
+
+	// Wait for the NSE to be received and handled
+	<-nseClient.recvCh
+	<-nseClient.recvCh
+
+	fakeClock.BlockUntil(1)
+	fakeClock.Add(testPeriod * 2)
+	fakeClock.BlockUntil(1)
+	require.NotEmpty(t, findNSs(t, nsClient))
+
 	_, err = nseClient.Unregister(context.Background(), &registry.NetworkServiceEndpoint{
 		Name:                "nse-1",
 		NetworkServiceNames: []string{"IP terminator"},
 	})
 	require.Nil(t, err)
-	require.Eventually(t, func() bool {
-		stream, err = nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
-			NetworkService: &registry.NetworkService{},
-		})
-		require.Nil(t, err)
-		list = registry.ReadNetworkServiceList(stream)
-		return len(list) == 0
-	}, time.Second, time.Millisecond*100)
+
+	// Wait for the NSE unregister event to be received and handled
+	<-nseClient.recvCh
+
+	fakeClock.Add(testPeriod)
+	fakeClock.BlockUntil(1)
+	require.Empty(t, findNSs(t, nsClient))
+}
+
+func findNSs(t *testing.T, c registry.NetworkServiceRegistryClient) []*registry.NetworkService {
+	stream, err := c.Find(context.Background(), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{},
+	})
+	require.Nil(t, err)
+	return registry.ReadNetworkServiceList(stream)
+}
+
+func TestNewNetworkServiceRegistryServer_DefaultPeriod(t *testing.T) {
+	fakeClock := clock.NewFake(time.Now())
+	nseClient := adapters.NetworkServiceEndpointServerToClient(memory.NewNetworkServiceEndpointRegistryServer())
+	s := expire.NewNetworkServiceServer(memory.NewNetworkServiceRegistryServer(), nseClient, expire.WithClock(fakeClock))
+	_, err := s.Register(context.Background(), &registry.NetworkService{
+		Name: "IP terminator",
+	})
+	require.Nil(t, err)
+
+	// The expiration monitor should wait for the period between the checks instead of spinning
+	waitCh := make(chan struct{})
+	go func() {
+		fakeClock.BlockUntil(1)
+		close(waitCh)
+	}()
+	select {
+	case <-waitCh:
+	case <-time.After(time.Second):
+		require.FailNow(t, "expiration monitor doesn't wait for the period")
+	}
 }
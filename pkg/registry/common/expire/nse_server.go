@@ -21,15 +21,19 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 type nseServer struct {
 	nses      map[string]*registry.NetworkServiceEndpoint
 	nsesMutex sync.Mutex
 	period    time.Duration
-	once      sync.Once
+	clock     clock.Clock
 	server    registry.NetworkServiceEndpointRegistryServer
 }
 
@@ -37,8 +41,11 @@ func (n *nseServer) setPeriod(d time.Duration) {
 	n.period = d
 }
 
+func (n *nseServer) setClock(c clock.Clock) {
+	n.clock = c
+}
+
 func (n *nseServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
-	n.once.Do(n.monitor)
 	r, err := n.server.Register(ctx, nse)
 	if err != nil {
 		return nil, err
@@ -64,26 +71,45 @@ func (n *nseServer) Unregister(ctx context.Context, nse *registry.NetworkService
 	return resp, nil
 }
 
-func (n *nseServer) monitor() {
-	go func() {
-		for {
-			n.nsesMutex.Lock()
-			for _, nse := range getExpiredNSEs(n.nses) {
-				delete(n.nses, nse.Name)
-				_, _ = n.server.Unregister(context.Background(), nse)
-			}
-			n.nsesMutex.Unlock()
-
-			<-time.After(n.period)
+func (n *nseServer) monitor(ctx context.Context) {
+	for {
+		n.nsesMutex.Lock()
+		for _, nse := range getExpiredNSEs(n.clock, n.nses) {
+			delete(n.nses, nse.Name)
+			_, _ = n.server.Unregister(context.Background(), proto.Clone(nse).(*registry.NetworkServiceEndpoint))
 		}
-	}()
+		n.nsesMutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-n.clock.After(n.period):
+		}
+	}
+}
+
+// loadNSEs starts monitoring of the Network service endpoints already stored in the server, e.g. restored by the
+// persistent registry after restart
+func (n *nseServer) loadNSEs() {
+	stream, err := adapters.NetworkServiceEndpointServerToClient(n.server).Find(context.Background(), &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+	})
+	if err != nil {
+		return
+	}
+	for _, nse := range registry.ReadNetworkServiceEndpointList(stream) {
+		n.nses[nse.Name] = nse
+	}
 }
 
 // NewNetworkServiceEndpointRegistryServer wraps passed NetworkServiceEndpointRegistryServer and monitor Network service endpoints
-func NewNetworkServiceEndpointRegistryServer(server registry.NetworkServiceEndpointRegistryServer, options ...Option) registry.NetworkServiceEndpointRegistryServer {
+// until ctx is done. Network service endpoints already stored in the server are monitored from the start, so the ones
+// restored after restart expire with no new Register.
+func NewNetworkServiceEndpointRegistryServer(ctx context.Context, server registry.NetworkServiceEndpointRegistryServer, options ...Option) registry.NetworkServiceEndpointRegistryServer {
 	r := &nseServer{
 		server: server,
 		period: defaultPeriod,
+		clock:  clock.New(),
 		nses:   map[string]*registry.NetworkServiceEndpoint{},
 	}
 
@@ -91,5 +117,9 @@ func NewNetworkServiceEndpointRegistryServer(server registry.NetworkServiceEndpo
 		o.apply(r)
 	}
 
+	// Stored NSEs are loaded before any Register is served, so the ones unregistered later are not loaded back
+	r.loadNSEs()
+	go r.monitor(ctx)
+
 	return r
 }
@@ -18,6 +18,9 @@ package expire_test
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -28,11 +31,17 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/persistent"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 func TestNewNetworkServiceEndpointRegistryServer(t *testing.T) {
-	s := expire.NewNetworkServiceEndpointRegistryServer(memory.NewNetworkServiceEndpointRegistryServer(), expire.WithPeriod(testPeriod))
-	expiration := time.Now().Add(testPeriod * 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeClock := clock.NewFake(time.Now())
+	s := expire.NewNetworkServiceEndpointRegistryServer(ctx, memory.NewNetworkServiceEndpointRegistryServer(),
+		expire.WithPeriod(testPeriod), expire.WithClock(fakeClock))
+	expiration := fakeClock.Now().Add(testPeriod * 2)
 	_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{
 		ExpirationTime: &timestamp.Timestamp{
 			Seconds: expiration.Unix(),
@@ -41,18 +50,106 @@ func TestNewNetworkServiceEndpointRegistryServer(t *testing.T) {
 	})
 	require.Nil(t, err)
 	c := adapters.NetworkServiceEndpointServerToClient(s)
+	require.NotEmpty(t, findNSEs(t, c))
+
+	fakeClock.BlockUntil(1)
+	fakeClock.Add(testPeriod * 2)
+	fakeClock.BlockUntil(1)
+	require.Empty(t, findNSEs(t, c))
+}
+
+func TestNewNetworkServiceEndpointRegistryServer_RestoredNSE(t *testing.T) {
+	dir, err := ioutil.TempDir("", "expire")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "registry.log")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stored, err := persistent.NewNetworkServiceEndpointRegistryServer(ctx, path)
+	require.NoError(t, err)
+	fakeClock := clock.NewFake(time.Now())
+	expiration := fakeClock.Now().Add(testPeriod * 2)
+	_, err = stored.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "restored",
+		ExpirationTime: &timestamp.Timestamp{
+			Seconds: expiration.Unix(),
+			Nanos:   int32(expiration.Nanosecond()),
+		},
+	})
+	require.Nil(t, err)
+
+	restored, err := persistent.NewNetworkServiceEndpointRegistryServer(ctx, path)
+	require.NoError(t, err)
+	s := expire.NewNetworkServiceEndpointRegistryServer(ctx, restored,
+		expire.WithPeriod(testPeriod), expire.WithClock(fakeClock))
+	c := adapters.NetworkServiceEndpointServerToClient(s)
+	require.Len(t, findNSEs(t, c), 1)
+
+	// Restored NSE should expire with no new Register
+	fakeClock.BlockUntil(1)
+	fakeClock.Add(testPeriod * 2)
+	fakeClock.BlockUntil(1)
+	require.Empty(t, findNSEs(t, c))
+}
+
+func TestNewNetworkServiceEndpointRegistryServer_FakeClock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeClock := clock.NewFake(time.Now())
+	s := expire.NewNetworkServiceEndpointRegistryServer(ctx, memory.NewNetworkServiceEndpointRegistryServer(),
+		expire.WithPeriod(time.Minute), expire.WithClock(fakeClock))
+	expiration := fakeClock.Now().Add(time.Hour)
+	_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+		ExpirationTime: &timestamp.Timestamp{
+			Seconds: expiration.Unix(),
+			Nanos:   int32(expiration.Nanosecond()),
+		},
+	})
+	require.Nil(t, err)
+
+	c := adapters.NetworkServiceEndpointServerToClient(s)
+
+	fakeClock.BlockUntil(1)
+	fakeClock.Add(time.Minute * 30)
+	fakeClock.BlockUntil(1)
+	require.Len(t, findNSEs(t, c), 1)
+
+	fakeClock.Add(time.Minute * 30)
+	fakeClock.BlockUntil(1)
+	require.Empty(t, findNSEs(t, c))
+}
+
+func findNSEs(t *testing.T, c registry.NetworkServiceEndpointRegistryClient) []*registry.NetworkServiceEndpoint {
 	stream, err := c.Find(context.Background(), &registry.NetworkServiceEndpointQuery{
 		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
 	})
 	require.Nil(t, err)
-	list := registry.ReadNetworkServiceEndpointList(stream)
-	require.NotEmpty(t, list)
-	require.Eventually(t, func() bool {
-		stream, err = c.Find(context.Background(), &registry.NetworkServiceEndpointQuery{
-			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
-		})
-		require.Nil(t, err)
-		list = registry.ReadNetworkServiceEndpointList(stream)
-		return len(list) == 0
-	}, time.Second, time.Millisecond*100)
+	return registry.ReadNetworkServiceEndpointList(stream)
+}
+
+func TestNewNetworkServiceEndpointRegistryServer_RegisteredNSENotChanged(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeClock := clock.NewFake(time.Now())
+	s := expire.NewNetworkServiceEndpointRegistryServer(ctx, memory.NewNetworkServiceEndpointRegistryServer(),
+		expire.WithPeriod(testPeriod), expire.WithClock(fakeClock))
+	expiration := fakeClock.Now().Add(testPeriod)
+	nse, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+		ExpirationTime: &timestamp.Timestamp{
+			Seconds: expiration.Unix(),
+			Nanos:   int32(expiration.Nanosecond()),
+		},
+	})
+	require.Nil(t, err)
+
+	fakeClock.BlockUntil(1)
+	fakeClock.Add(testPeriod)
+	fakeClock.BlockUntil(1)
+	require.Empty(t, findNSEs(t, adapters.NetworkServiceEndpointServerToClient(s)))
+
+	// Unregister of the expired NSE shouldn't change the NSE returned to the caller
+	require.Equal(t, expiration.Unix(), nse.ExpirationTime.Seconds)
 }
@@ -16,10 +16,15 @@
 
 package expire
 
-import "time"
+import (
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
 
 type configurable interface {
 	setPeriod(time.Duration)
+	setClock(clock.Clock)
 }
 
 // Option is expire registry configuration option
@@ -39,3 +44,10 @@ func WithPeriod(duration time.Duration) Option {
 		c.setPeriod(duration)
 	})
 }
+
+// WithClock sets the clock used to check expiration, by default the real clock is used
+func WithClock(c clock.Clock) Option {
+	return applierFunc(func(cfg configurable) {
+		cfg.setClock(c)
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panicrecover
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type recoverNetworkServiceRegistryServer struct{}
+
+// NewNetworkServiceRegistryServer - creates a new registry.NetworkServiceRegistryServer chain element converting panics in the next
+// chain elements to Internal errors. Stack trace of the panic is written to the trace log.
+func NewNetworkServiceRegistryServer() registry.NetworkServiceRegistryServer {
+	return &recoverNetworkServiceRegistryServer{}
+}
+
+func (r *recoverNetworkServiceRegistryServer) Register(ctx context.Context, in *registry.NetworkService) (_ *registry.NetworkService, err error) {
+	defer recoverPanic(ctx, "Register", &err)
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, in)
+}
+
+func (r *recoverNetworkServiceRegistryServer) Find(query *registry.NetworkServiceQuery, s registry.NetworkServiceRegistry_FindServer) (err error) {
+	defer recoverPanic(s.Context(), "Find", &err)
+	return next.NetworkServiceRegistryServer(s.Context()).Find(query, s)
+}
+
+func (r *recoverNetworkServiceRegistryServer) Unregister(ctx context.Context, in *registry.NetworkService) (_ *empty.Empty, err error) {
+	defer recoverPanic(ctx, "Unregister", &err)
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, in)
+}
+
+type recoverNetworkServiceRegistryClient struct{}
+
+// NewNetworkServiceRegistryClient - creates a new registry.NetworkServiceRegistryClient chain element converting panics in the next
+// chain elements to Internal errors. Stack trace of the panic is written to the trace log.
+func NewNetworkServiceRegistryClient() registry.NetworkServiceRegistryClient {
+	return &recoverNetworkServiceRegistryClient{}
+}
+
+func (r *recoverNetworkServiceRegistryClient) Register(ctx context.Context, in *registry.NetworkService, opts ...grpc.CallOption) (_ *registry.NetworkService, err error) {
+	defer recoverPanic(ctx, "Register", &err)
+	return next.NetworkServiceRegistryClient(ctx).Register(ctx, in, opts...)
+}
+
+func (r *recoverNetworkServiceRegistryClient) Find(ctx context.Context, query *registry.NetworkServiceQuery, opts ...grpc.CallOption) (_ registry.NetworkServiceRegistry_FindClient, err error) {
+	defer recoverPanic(ctx, "Find", &err)
+	return next.NetworkServiceRegistryClient(ctx).Find(ctx, query, opts...)
+}
+
+func (r *recoverNetworkServiceRegistryClient) Unregister(ctx context.Context, in *registry.NetworkService, opts ...grpc.CallOption) (_ *empty.Empty, err error) {
+	defer recoverPanic(ctx, "Unregister", &err)
+	return next.NetworkServiceRegistryClient(ctx).Unregister(ctx, in, opts...)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panicrecover_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/panicrecover"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+)
+
+type panicNSServer struct{}
+
+func (s *panicNSServer) Register(context.Context, *registry.NetworkService) (*registry.NetworkService, error) {
+	panic("register panic")
+}
+
+func (s *panicNSServer) Find(*registry.NetworkServiceQuery, registry.NetworkServiceRegistry_FindServer) error {
+	panic("find panic")
+}
+
+func (s *panicNSServer) Unregister(context.Context, *registry.NetworkService) (*empty.Empty, error) {
+	panic("unregister panic")
+}
+
+func requireInternal(t *testing.T, err error, msg string) {
+	require.Error(t, err)
+	require.Equal(t, codes.Internal, status.Code(errors.Cause(err)))
+	require.Contains(t, err.Error(), msg)
+}
+
+func TestRecoverNetworkServiceRegistryServer(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	server := chain.NewNetworkServiceRegistryServer(
+		panicrecover.NewNetworkServiceRegistryServer(),
+		&panicNSServer{},
+	)
+
+	_, err := server.Register(context.Background(), &registry.NetworkService{Name: "ns"})
+	requireInternal(t, err, "register panic")
+
+	err = server.Find(&registry.NetworkServiceQuery{NetworkService: &registry.NetworkService{}},
+		streamchannel.NewNetworkServiceFindServer(context.Background(), nil))
+	requireInternal(t, err, "find panic")
+
+	_, err = server.Unregister(context.Background(), &registry.NetworkService{Name: "ns"})
+	requireInternal(t, err, "unregister panic")
+}
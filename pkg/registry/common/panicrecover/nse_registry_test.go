@@ -0,0 +1,65 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panicrecover_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/panicrecover"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+)
+
+type panicNSEClient struct{}
+
+func (c *panicNSEClient) Register(_ context.Context, nse *registry.NetworkServiceEndpoint, _ ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	// index out of range
+	nse.NetworkServiceNames[0] = "ns"
+	return nse, nil
+}
+
+func (c *panicNSEClient) Find(context.Context, *registry.NetworkServiceEndpointQuery, ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	panic("find panic")
+}
+
+func (c *panicNSEClient) Unregister(context.Context, *registry.NetworkServiceEndpoint, ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func TestRecoverNetworkServiceEndpointRegistryClient(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	client := chain.NewNetworkServiceEndpointRegistryClient(
+		panicrecover.NewNetworkServiceEndpointRegistryClient(),
+		&panicNSEClient{},
+	)
+
+	_, err := client.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse"})
+	requireInternal(t, err, "index out of range")
+
+	_, err = client.Find(context.Background(), &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{}})
+	requireInternal(t, err, "find panic")
+
+	_, err = client.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.NoError(t, err)
+}
@@ -45,7 +45,7 @@ func (n *nseServer) Register(ctx context.Context, nse *registry.NetworkServiceEn
 }
 
 func (n nseServer) Find(q *registry.NetworkServiceEndpointQuery, s registry.NetworkServiceEndpointRegistry_FindServer) error {
-	if !interdomain.Is(q.NetworkServiceEndpoint.Name) {
+	if !interdomain.IsAny(append([]string{q.NetworkServiceEndpoint.Name}, q.NetworkServiceEndpoint.NetworkServiceNames...)...) {
 		return nil
 	}
 	if n.proxyRegistryURL == nil {
@@ -28,6 +28,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 type refreshNSEClient struct {
@@ -36,24 +37,25 @@ type refreshNSEClient struct {
 	nseCancels            map[string]context.CancelFunc
 	retryDelay            time.Duration
 	defaultExpiryDuration time.Duration
+	clock                 clock.Clock
 }
 
 func (c *refreshNSEClient) startRefresh(ctx context.Context, nse *registry.NetworkServiceEndpoint) {
 	t := time.Unix(nse.ExpirationTime.Seconds, int64(nse.ExpirationTime.Nanos))
-	delta := time.Until(t)
+	delta := c.clock.Until(t)
 	go func() {
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(2 * time.Until(t) / 3):
-				t1 := time.Now().Add(delta)
+			case <-c.clock.After(2 * c.clock.Until(t) / 3):
+				t1 := c.clock.Now().Add(delta)
 				nse.ExpirationTime.Seconds = t1.Unix()
 				nse.ExpirationTime.Nanos = int32(t1.Nanosecond())
 				var err error
 				nse, err = c.client.Register(ctx, nse)
 				if err != nil {
-					<-time.After(c.retryDelay)
+					<-c.clock.After(c.retryDelay)
 					continue
 				}
 				t = t1
@@ -64,7 +66,7 @@ func (c *refreshNSEClient) startRefresh(ctx context.Context, nse *registry.Netwo
 
 func (c *refreshNSEClient) Register(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
 	if in.ExpirationTime == nil {
-		expirationTime := time.Now().Add(c.defaultExpiryDuration)
+		expirationTime := c.clock.Now().Add(c.defaultExpiryDuration)
 		in.ExpirationTime = &timestamp.Timestamp{
 			Seconds: expirationTime.Unix(),
 			Nanos:   int32(expirationTime.Nanosecond()),
@@ -111,6 +113,7 @@ func NewNetworkServiceEndpointRegistryClient(client registry.NetworkServiceEndpo
 		nseCancels:            map[string]context.CancelFunc{},
 		retryDelay:            time.Second * 5,
 		defaultExpiryDuration: time.Minute * 30,
+		clock:                 clock.New(),
 	}
 
 	for _, o := range options {
@@ -31,6 +31,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/refresh"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 const testExpiryDuraiton = time.Millisecond * 100
@@ -47,6 +48,12 @@ func (t *testNSEClient) Register(ctx context.Context, in *registry.NetworkServic
 	return in, nil
 }
 
+func (t *testNSEClient) count() int {
+	t.Lock()
+	defer t.Unlock()
+	return t.requestCount
+}
+
 func (t *testNSEClient) Find(ctx context.Context, in *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
 	panic("implement me")
 }
@@ -72,20 +79,22 @@ func (c *checkExpirationTimeClient) Unregister(ctx context.Context, in *registry
 
 func TestNewNetworkServiceEndpointRegistryClient(t *testing.T) {
 	defer goleak.VerifyNone(t)
+	fakeClock := clock.NewFake(time.Now())
 	testClient := testNSEClient{}
 	refreshClient := refresh.NewNetworkServiceEndpointRegistryClient(&testClient,
 		refresh.WithRetryPeriod(time.Millisecond*100),
 		refresh.WithDefaultExpiryDuration(testExpiryDuraiton),
+		refresh.WithClock(fakeClock),
 	)
 	_, err := refreshClient.Register(context.Background(), &registry.NetworkServiceEndpoint{
 		Name: "nse-1",
 	})
 	require.Nil(t, err)
-	require.Eventually(t, func() bool {
-		testClient.Lock()
-		defer testClient.Unlock()
-		return testClient.requestCount == 1
-	}, testExpiryDuraiton*2, testExpiryDuraiton/4)
+
+	fakeClock.BlockUntil(1)
+	fakeClock.Add(testExpiryDuraiton)
+	fakeClock.BlockUntil(1)
+	require.Equal(t, 1, testClient.count())
 	_, err = refreshClient.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
 	require.Nil(t, err)
 }
@@ -106,10 +115,12 @@ func TestRefreshNSEClient_ShouldSetExpirationTime_BeforeCallNext(t *testing.T) {
 
 func TestNewNetworkServiceEndpointRegistryClient_CalledRegisterTwice(t *testing.T) {
 	defer goleak.VerifyNone(t)
+	fakeClock := clock.NewFake(time.Now())
 	testClient := testNSEClient{}
 	refreshClient := refresh.NewNetworkServiceEndpointRegistryClient(&testClient,
 		refresh.WithRetryPeriod(time.Millisecond*100),
-		refresh.WithDefaultExpiryDuration(time.Millisecond*100),
+		refresh.WithDefaultExpiryDuration(testExpiryDuraiton),
+		refresh.WithClock(fakeClock),
 	)
 	_, err := refreshClient.Register(context.Background(), &registry.NetworkServiceEndpoint{
 		Name: "nse-1",
@@ -119,11 +130,37 @@ func TestNewNetworkServiceEndpointRegistryClient_CalledRegisterTwice(t *testing.
 		Name: "nse-1",
 	})
 	require.Nil(t, err)
-	require.Eventually(t, func() bool {
-		testClient.Lock()
-		defer testClient.Unlock()
-		return testClient.requestCount == 1
-	}, testExpiryDuraiton*2, testExpiryDuraiton/4)
+
+	// The timer of the first refresh is never stopped, so wait for the both of them
+	fakeClock.BlockUntil(2)
+	fakeClock.Add(testExpiryDuraiton)
+	fakeClock.BlockUntil(1)
+	require.Equal(t, 1, testClient.count())
+	_, err = refreshClient.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+}
+
+func TestNewNetworkServiceEndpointRegistryClient_FakeClock(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	fakeClock := clock.NewFake(time.Now())
+	testClient := testNSEClient{}
+	refreshClient := refresh.NewNetworkServiceEndpointRegistryClient(&testClient,
+		refresh.WithDefaultExpiryDuration(time.Hour),
+		refresh.WithClock(fakeClock),
+	)
+	_, err := refreshClient.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+	})
+	require.Nil(t, err)
+
+	fakeClock.BlockUntil(1)
+	fakeClock.Add(time.Minute * 30)
+	require.Equal(t, 0, testClient.count())
+
+	fakeClock.Add(time.Minute * 10)
+	fakeClock.BlockUntil(1)
+	require.Equal(t, 1, testClient.count())
+
 	_, err = refreshClient.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
 	require.Nil(t, err)
 }
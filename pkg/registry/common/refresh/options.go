@@ -16,7 +16,11 @@
 
 package refresh
 
-import "time"
+import (
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
 
 // Option is expire registry configuration option
 type Option interface {
@@ -42,3 +46,10 @@ func WithDefaultExpiryDuration(duration time.Duration) Option {
 		c.defaultExpiryDuration = duration
 	})
 }
+
+// WithClock sets the clock used to schedule the refreshes, by default the real clock is used
+func WithClock(clk clock.Clock) Option {
+	return applierFunc(func(c *refreshNSEClient) {
+		c.clock = clk
+	})
+}
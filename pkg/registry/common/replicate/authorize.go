@@ -0,0 +1,75 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc/credentials"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+// PeerAuthorizer checks if the incoming call marked as replicated is made by the other replica
+type PeerAuthorizer func(ctx context.Context) error
+
+// BySpiffeID returns PeerAuthorizer authorizing the peers with the SPIFFE ID of the TLS certificate in ids
+func BySpiffeID(ids ...string) PeerAuthorizer {
+	allowed := map[string]struct{}{}
+	for _, id := range ids {
+		allowed[id] = struct{}{}
+	}
+	return func(ctx context.Context) error {
+		p, ok := grpcpeer.FromContext(ctx)
+		if !ok {
+			return errors.New("no peer in the context")
+		}
+		var tlsInfo credentials.TLSInfo
+		switch v := p.AuthInfo.(type) {
+		case credentials.TLSInfo:
+			tlsInfo = v
+		case *credentials.TLSInfo:
+			tlsInfo = *v
+		default:
+			return errors.New("peer is not authenticated with TLS")
+		}
+		if len(tlsInfo.State.PeerCertificates) == 0 {
+			return errors.New("peer has no certificate")
+		}
+		id, err := x509svid.IDFromCert(tlsInfo.State.PeerCertificates[0])
+		if err != nil {
+			return errors.Wrap(err, "peer certificate has no SPIFFE ID")
+		}
+		if _, ok := allowed[id.String()]; !ok {
+			return errors.Errorf("%s is not a replica", id)
+		}
+		return nil
+	}
+}
+
+// AnyPeer returns PeerAuthorizer authorizing any peer, it should only be used if the registry is not reachable by
+// the clients other than the replicas
+func AnyPeer() PeerAuthorizer {
+	return func(context.Context) error {
+		return nil
+	}
+}
+
+func denyAll(context.Context) error {
+	return errors.New("no replicas are authorized, use WithPeerAuthorizer")
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/serialize"
+)
+
+const (
+	// replicatedKey marks calls received from the other replica, such calls are not replicated further
+	replicatedKey = "nsm-registry-replicated"
+	// versionKey is the version of the replicated change
+	versionKey = "nsm-registry-version"
+)
+
+func withReplicated(ctx context.Context, version int64) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, replicatedKey, "true", versionKey, strconv.FormatInt(version, 10))
+}
+
+// fromReplica returns the version of the change received from the other replica, ok is false for the calls from the
+// clients. Calls marked as replicated are rejected if the peer is not authorized as a replica.
+func (c *config) fromReplica(ctx context.Context) (version int64, ok bool, err error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get(replicatedKey)) == 0 {
+		return 0, false, nil
+	}
+	if err := c.authorizer(ctx); err != nil {
+		return 0, false, status.Errorf(codes.PermissionDenied, "replicated call is not authorized: %s", err.Error())
+	}
+	values := md.Get(versionKey)
+	if len(values) == 0 {
+		return 0, true, nil
+	}
+	if version, err = strconv.ParseInt(values[0], 10, 64); err != nil {
+		return 0, false, status.Errorf(codes.InvalidArgument, "invalid %s: %s", versionKey, values[0])
+	}
+	return version, true, nil
+}
+
+type peer struct {
+	ctx      context.Context
+	url      *url.URL
+	cc       *grpc.ClientConn
+	timeout  time.Duration
+	executor serialize.Executor
+}
+
+// dialPeers creates connections to the peers, connections are closed on ctx.Done()
+func dialPeers(ctx context.Context, urls []*url.URL, c *config) ([]*peer, error) {
+	var peers []*peer
+	closeAll := func() {
+		for _, p := range peers {
+			_ = p.cc.Close()
+		}
+	}
+	for _, u := range urls {
+		cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(u), c.dialOptions...)
+		if err != nil {
+			closeAll()
+			return nil, errors.Wrapf(err, "failed to dial %s", u)
+		}
+		peers = append(peers, &peer{
+			ctx:     ctx,
+			url:     u,
+			cc:      cc,
+			timeout: c.timeout,
+		})
+	}
+	go func() {
+		<-ctx.Done()
+		closeAll()
+	}()
+	return peers, nil
+}
+
+// replicate asynchronously calls f with the replicated mark and the version of the change. All the calls to the same
+// peer are performed in order.
+func (p *peer) replicate(version int64, f func(ctx context.Context) error) {
+	p.executor.AsyncExec(func() {
+		if p.ctx.Err() != nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(p.ctx, p.timeout)
+		defer cancel()
+		if err := f(withReplicated(ctx, version)); err != nil {
+			log.FromContext(p.ctx).Warnf("failed to replicate to %s: %+v", p.url, err)
+		}
+	})
+}
+
+// call calls f with the timeout, errors are logged
+func (p *peer) call(f func(ctx context.Context) error) {
+	ctx, cancel := context.WithTimeout(p.ctx, p.timeout)
+	defer cancel()
+	if err := f(ctx); err != nil {
+		log.FromContext(p.ctx).Warnf("failed to sync with %s: %+v", p.url, err)
+	}
+}
+
+// runAntiEntropy calls sync once and then calls antiEntropy every period until ctx is done
+func runAntiEntropy(ctx context.Context, c *config, sync, antiEntropy func()) {
+	sync()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.clock.After(c.antiEntropyPeriod):
+			antiEntropy()
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replicate provides registry chain elements to build a cluster of registries replicating
+// Register/Unregister calls to each other. Find and Watch are served by the local storage of each replica.
+//
+// Replication is asynchronous: all the changes are sent to each peer in order with a last-writer-wins version, so
+// concurrent changes of the same entry on the different replicas converge to the latest one. Deleted entries are kept
+// as tombstones for several anti-entropy rounds. Each replica periodically compares its state with the peers and sends
+// them the changes they have missed, e.g. because of the failed calls. Replica started later pulls the actual state
+// from its peers.
+//
+// Replicated calls are marked with gRPC metadata, such calls are accepted only from the peers authorized by
+// WithPeerAuthorizer.
+package replicate
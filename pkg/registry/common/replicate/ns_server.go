@@ -0,0 +1,232 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+)
+
+type nsServer struct {
+	ctx      context.Context
+	server   registry.NetworkServiceRegistryServer
+	peers    []*peer
+	config   *config
+	versions *versions
+}
+
+func (n *nsServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	version, replicated, err := n.config.fromReplica(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := n.versions.lock(ns.Name)
+	defer unlock()
+
+	if !replicated {
+		version = n.versions.local(ns.Name)
+	} else if !n.versions.isNewer(ns.Name, version, false) {
+		return ns, nil
+	}
+	r, err := n.server.Register(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	n.versions.set(r.Name, version, false)
+
+	if !replicated {
+		for _, p := range n.peers {
+			n.replicateRegister(p, r, version)
+		}
+	}
+	return r, nil
+}
+
+func (n *nsServer) Find(query *registry.NetworkServiceQuery, s registry.NetworkServiceRegistry_FindServer) error {
+	return n.server.Find(query, s)
+}
+
+func (n *nsServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	version, replicated, err := n.config.fromReplica(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := n.versions.lock(ns.Name)
+	defer unlock()
+
+	if !replicated {
+		version = n.versions.local(ns.Name)
+	} else if !n.versions.isNewer(ns.Name, version, true) {
+		return new(empty.Empty), nil
+	}
+	replica := proto.Clone(ns).(*registry.NetworkService)
+	resp, err := n.server.Unregister(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	n.versions.set(replica.Name, version, true)
+
+	if !replicated {
+		for _, p := range n.peers {
+			n.replicateUnregister(p, replica, version)
+		}
+	}
+	return resp, nil
+}
+
+func (n *nsServer) replicateRegister(p *peer, ns *registry.NetworkService, version int64) {
+	client, replica := registry.NewNetworkServiceRegistryClient(p.cc), proto.Clone(ns).(*registry.NetworkService)
+	p.replicate(version, func(ctx context.Context) error {
+		_, err := client.Register(ctx, replica)
+		return err
+	})
+}
+
+func (n *nsServer) replicateUnregister(p *peer, ns *registry.NetworkService, version int64) {
+	client, replica := registry.NewNetworkServiceRegistryClient(p.cc), proto.Clone(ns).(*registry.NetworkService)
+	p.replicate(version, func(ctx context.Context) error {
+		_, err := client.Unregister(ctx, replica)
+		return err
+	})
+}
+
+func (n *nsServer) find(ctx context.Context, client registry.NetworkServiceRegistryClient, opts ...grpc.CallOption) ([]*registry.NetworkService, error) {
+	stream, err := client.Find(ctx, &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{},
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return registry.ReadNetworkServiceList(stream), nil
+}
+
+func (n *nsServer) findLocal() ([]*registry.NetworkService, error) {
+	return n.find(n.ctx, adapters.NetworkServiceServerToClient(n.server))
+}
+
+// sync pulls the Network services unknown to the local storage from the peers
+func (n *nsServer) sync() {
+	for _, p := range n.peers {
+		client := registry.NewNetworkServiceRegistryClient(p.cc)
+		p.call(func(ctx context.Context) error {
+			nss, err := n.find(ctx, client, grpc.WaitForReady(true))
+			if err != nil {
+				return err
+			}
+			for _, ns := range nss {
+				if err := n.pull(ctx, ns); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
+
+func (n *nsServer) pull(ctx context.Context, ns *registry.NetworkService) error {
+	unlock := n.versions.lock(ns.Name)
+	defer unlock()
+
+	if !n.versions.isNewer(ns.Name, 0, false) {
+		return nil
+	}
+	if _, err := n.server.Register(ctx, ns); err != nil {
+		return err
+	}
+	n.versions.set(ns.Name, 0, false)
+	return nil
+}
+
+// antiEntropy compares the local state with the peers and sends them the changes they have missed. The versions are
+// taken before listing the storage, so an entry is never sent with the version newer than its content.
+func (n *nsServer) antiEntropy() {
+	existing, deleted := n.versions.snapshot()
+	listed := n.config.clock.Now()
+	local, err := n.findLocal()
+	if err != nil {
+		return
+	}
+	names := map[string]struct{}{}
+	for _, ns := range local {
+		names[ns.Name] = struct{}{}
+	}
+	n.versions.gc(listed, names, n.config.tombstoneLifetime())
+
+	for _, p := range n.peers {
+		client := registry.NewNetworkServiceRegistryClient(p.cc)
+		p.call(func(ctx context.Context) error {
+			nss, err := n.find(ctx, client)
+			if err != nil {
+				return err
+			}
+			remote := map[string]*registry.NetworkService{}
+			for _, ns := range nss {
+				remote[ns.Name] = ns
+			}
+			for _, ns := range local {
+				version, ok := existing[ns.Name]
+				if r, found := remote[ns.Name]; ok && (!found || !proto.Equal(r, ns)) {
+					n.replicateRegister(p, ns, version)
+				}
+			}
+			for name, version := range deleted {
+				if r, found := remote[name]; found {
+					n.replicateUnregister(p, r, version)
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// NewNetworkServiceRegistryServer wraps passed NetworkServiceRegistryServer storage and replicates
+// all Register/Unregister calls to the registries by the peers URLs. Replicated changes are ordered by the
+// last-writer-wins versions, the peers are periodically compared with the local state to deliver the missed changes.
+// On start the actual state is pulled from the peers. Peer connections are closed on ctx.Done().
+func NewNetworkServiceRegistryServer(ctx context.Context, server registry.NetworkServiceRegistryServer, peers []*url.URL, options ...Option) (registry.NetworkServiceRegistryServer, error) {
+	c := newConfig(options)
+	p, err := dialPeers(ctx, peers, c)
+	if err != nil {
+		return nil, err
+	}
+	r := &nsServer{
+		ctx:      ctx,
+		server:   server,
+		peers:    p,
+		config:   c,
+		versions: newVersions(c.clock),
+	}
+	// Entries already present in the storage, e.g. restored from the file, have the oldest version
+	local, err := r.findLocal()
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range local {
+		r.versions.set(ns.Name, 0, false)
+	}
+	go runAntiEntropy(ctx, c, r.sync, r.antiEntropy)
+	return r, nil
+}
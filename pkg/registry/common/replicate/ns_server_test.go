@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/replicate"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+func TestNetworkServiceRegistryServer_Replicate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	peer := memory.NewNetworkServiceRegistryServer()
+	s := grpc.NewServer()
+	registry.RegisterNetworkServiceRegistryServer(s, peer)
+	go func() {
+		_ = s.Serve(l)
+	}()
+	defer s.Stop()
+
+	server, err := replicate.NewNetworkServiceRegistryServer(ctx, memory.NewNetworkServiceRegistryServer(), []*url.URL{grpcutils.AddressToURL(l.Addr())},
+		replicate.WithDialOptions(grpc.WithInsecure()))
+	require.NoError(t, err)
+
+	_, err = server.Register(context.Background(), &registry.NetworkService{
+		Name: "ns-1",
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		stream, err := adapters.NetworkServiceServerToClient(peer).Find(context.Background(), &registry.NetworkServiceQuery{
+			NetworkService: &registry.NetworkService{},
+		})
+		require.NoError(t, err)
+		list := registry.ReadNetworkServiceList(stream)
+		return len(list) == 1 && list[0].Name == "ns-1"
+	}, time.Second, time.Millisecond*10)
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+)
+
+type nseServer struct {
+	ctx      context.Context
+	server   registry.NetworkServiceEndpointRegistryServer
+	peers    []*peer
+	config   *config
+	versions *versions
+}
+
+func (n *nseServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	version, replicated, err := n.config.fromReplica(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := n.versions.lock(nse.Name)
+	defer unlock()
+
+	if !replicated {
+		version = n.versions.local(nse.Name)
+	} else if !n.versions.isNewer(nse.Name, version, false) {
+		return nse, nil
+	}
+	r, err := n.server.Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	n.versions.set(r.Name, version, false)
+
+	if !replicated {
+		for _, p := range n.peers {
+			n.replicateRegister(p, r, version)
+		}
+	}
+	return r, nil
+}
+
+func (n *nseServer) Find(query *registry.NetworkServiceEndpointQuery, s registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return n.server.Find(query, s)
+}
+
+func (n *nseServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	version, replicated, err := n.config.fromReplica(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := n.versions.lock(nse.Name)
+	defer unlock()
+
+	if !replicated {
+		version = n.versions.local(nse.Name)
+	} else if !n.versions.isNewer(nse.Name, version, true) {
+		return new(empty.Empty), nil
+	}
+	replica := proto.Clone(nse).(*registry.NetworkServiceEndpoint)
+	resp, err := n.server.Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	n.versions.set(replica.Name, version, true)
+
+	if !replicated {
+		for _, p := range n.peers {
+			n.replicateUnregister(p, replica, version)
+		}
+	}
+	return resp, nil
+}
+
+func (n *nseServer) replicateRegister(p *peer, nse *registry.NetworkServiceEndpoint, version int64) {
+	client, replica := registry.NewNetworkServiceEndpointRegistryClient(p.cc), proto.Clone(nse).(*registry.NetworkServiceEndpoint)
+	p.replicate(version, func(ctx context.Context) error {
+		_, err := client.Register(ctx, replica)
+		return err
+	})
+}
+
+func (n *nseServer) replicateUnregister(p *peer, nse *registry.NetworkServiceEndpoint, version int64) {
+	client, replica := registry.NewNetworkServiceEndpointRegistryClient(p.cc), proto.Clone(nse).(*registry.NetworkServiceEndpoint)
+	p.replicate(version, func(ctx context.Context) error {
+		_, err := client.Unregister(ctx, replica)
+		return err
+	})
+}
+
+func (n *nseServer) find(ctx context.Context, client registry.NetworkServiceEndpointRegistryClient, opts ...grpc.CallOption) ([]*registry.NetworkServiceEndpoint, error) {
+	stream, err := client.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return registry.ReadNetworkServiceEndpointList(stream), nil
+}
+
+func (n *nseServer) findLocal() ([]*registry.NetworkServiceEndpoint, error) {
+	return n.find(n.ctx, adapters.NetworkServiceEndpointServerToClient(n.server))
+}
+
+// sync pulls the Network service endpoints unknown to the local storage from the peers
+func (n *nseServer) sync() {
+	for _, p := range n.peers {
+		client := registry.NewNetworkServiceEndpointRegistryClient(p.cc)
+		p.call(func(ctx context.Context) error {
+			nses, err := n.find(ctx, client, grpc.WaitForReady(true))
+			if err != nil {
+				return err
+			}
+			for _, nse := range nses {
+				if err := n.pull(ctx, nse); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
+
+func (n *nseServer) pull(ctx context.Context, nse *registry.NetworkServiceEndpoint) error {
+	unlock := n.versions.lock(nse.Name)
+	defer unlock()
+
+	if !n.versions.isNewer(nse.Name, 0, false) {
+		return nil
+	}
+	if _, err := n.server.Register(ctx, nse); err != nil {
+		return err
+	}
+	n.versions.set(nse.Name, 0, false)
+	return nil
+}
+
+// antiEntropy compares the local state with the peers and sends them the changes they have missed. The versions are
+// taken before listing the storage, so an entry is never sent with the version newer than its content.
+func (n *nseServer) antiEntropy() {
+	existing, deleted := n.versions.snapshot()
+	listed := n.config.clock.Now()
+	local, err := n.findLocal()
+	if err != nil {
+		return
+	}
+	names := map[string]struct{}{}
+	for _, nse := range local {
+		names[nse.Name] = struct{}{}
+	}
+	n.versions.gc(listed, names, n.config.tombstoneLifetime())
+
+	for _, p := range n.peers {
+		client := registry.NewNetworkServiceEndpointRegistryClient(p.cc)
+		p.call(func(ctx context.Context) error {
+			nses, err := n.find(ctx, client)
+			if err != nil {
+				return err
+			}
+			remote := map[string]*registry.NetworkServiceEndpoint{}
+			for _, nse := range nses {
+				remote[nse.Name] = nse
+			}
+			for _, nse := range local {
+				version, ok := existing[nse.Name]
+				if r, found := remote[nse.Name]; ok && (!found || !proto.Equal(r, nse)) {
+					n.replicateRegister(p, nse, version)
+				}
+			}
+			for name, version := range deleted {
+				if r, found := remote[name]; found {
+					n.replicateUnregister(p, r, version)
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// NewNetworkServiceEndpointRegistryServer wraps passed NetworkServiceEndpointRegistryServer storage and replicates
+// all Register/Unregister calls to the registries by the peers URLs. Replicated changes are ordered by the
+// last-writer-wins versions, the peers are periodically compared with the local state to deliver the missed changes.
+// On start the actual state is pulled from the peers. Peer connections are closed on ctx.Done().
+func NewNetworkServiceEndpointRegistryServer(ctx context.Context, server registry.NetworkServiceEndpointRegistryServer, peers []*url.URL, options ...Option) (registry.NetworkServiceEndpointRegistryServer, error) {
+	c := newConfig(options)
+	p, err := dialPeers(ctx, peers, c)
+	if err != nil {
+		return nil, err
+	}
+	r := &nseServer{
+		ctx:      ctx,
+		server:   server,
+		peers:    p,
+		config:   c,
+		versions: newVersions(c.clock),
+	}
+	// Entries already present in the storage, e.g. restored from the file, have the oldest version
+	local, err := r.findLocal()
+	if err != nil {
+		return nil, err
+	}
+	for _, nse := range local {
+		r.versions.set(nse.Name, 0, false)
+	}
+	go runAntiEntropy(ctx, c, r.sync, r.antiEntropy)
+	return r, nil
+}
@@ -0,0 +1,296 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/replicate"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+const testAntiEntropyPeriod = time.Millisecond * 100
+
+type replica struct {
+	listener net.Listener
+	url      *url.URL
+	storage  registry.NetworkServiceEndpointRegistryServer
+	nse      registry.NetworkServiceEndpointRegistryServer
+}
+
+func newListeners(t *testing.T, count int) []*replica {
+	var replicas []*replica
+	for i := 0; i < count; i++ {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		replicas = append(replicas, &replica{
+			listener: l,
+			url:      grpcutils.AddressToURL(l.Addr()),
+			storage:  memory.NewNetworkServiceEndpointRegistryServer(),
+		})
+	}
+	return replicas
+}
+
+func startReplica(ctx context.Context, t *testing.T, r *replica, peers []*replica) {
+	var peerURLs []*url.URL
+	for _, p := range peers {
+		if p != r {
+			peerURLs = append(peerURLs, p.url)
+		}
+	}
+	var err error
+	r.nse, err = replicate.NewNetworkServiceEndpointRegistryServer(ctx, r.storage, peerURLs,
+		replicate.WithDialOptions(grpc.WithInsecure()),
+		replicate.WithTimeout(time.Second),
+		replicate.WithPeerAuthorizer(replicate.AnyPeer()),
+		replicate.WithAntiEntropyPeriod(testAntiEntropyPeriod))
+	require.NoError(t, err)
+
+	s := grpc.NewServer()
+	registry.RegisterNetworkServiceEndpointRegistryServer(s, r.nse)
+	go func() {
+		_ = s.Serve(r.listener)
+	}()
+	go func() {
+		<-ctx.Done()
+		s.Stop()
+	}()
+}
+
+func findNSEs(t *testing.T, s registry.NetworkServiceEndpointRegistryServer) []*registry.NetworkServiceEndpoint {
+	stream, err := adapters.NetworkServiceEndpointServerToClient(s).Find(context.Background(), &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+	})
+	require.NoError(t, err)
+	return registry.ReadNetworkServiceEndpointList(stream)
+}
+
+func TestNetworkServiceEndpointRegistryServer_Replicate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replicas := newListeners(t, 3)
+	for _, r := range replicas {
+		startReplica(ctx, t, r, replicas)
+	}
+
+	_, err := replicas[0].nse.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+		Url:  "tcp://1.1.1.1",
+	})
+	require.NoError(t, err)
+
+	for _, r := range replicas {
+		s := r.nse
+		require.Eventually(t, func() bool {
+			nses := findNSEs(t, s)
+			return len(nses) == 1 && nses[0].Name == "nse-1"
+		}, time.Second, time.Millisecond*10)
+	}
+
+	_, err = replicas[1].nse.Unregister(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+	})
+	require.NoError(t, err)
+
+	for _, r := range replicas {
+		s := r.nse
+		require.Eventually(t, func() bool {
+			return len(findNSEs(t, s)) == 0
+		}, time.Second, time.Millisecond*10)
+	}
+}
+
+func TestNetworkServiceEndpointRegistryServer_ReplicaRestart(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replicas := newListeners(t, 3)
+	for _, r := range replicas[:2] {
+		startReplica(ctx, t, r, replicas)
+	}
+
+	_, err := replicas[0].nse.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+	})
+	require.NoError(t, err)
+
+	startReplica(ctx, t, replicas[2], replicas)
+
+	require.Eventually(t, func() bool {
+		nses := findNSEs(t, replicas[2].nse)
+		return len(nses) == 1 && nses[0].Name == "nse-1"
+	}, time.Second*2, time.Millisecond*10)
+}
+
+type failingUnregisterStorage struct {
+	registry.NetworkServiceEndpointRegistryServer
+	failures int32
+}
+
+func (s *failingUnregisterStorage) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if atomic.AddInt32(&s.failures, -1) >= 0 {
+		return nil, errors.New("failed to unregister")
+	}
+	return s.NetworkServiceEndpointRegistryServer.Unregister(ctx, nse)
+}
+
+func TestNetworkServiceEndpointRegistryServer_AntiEntropyLostRegister(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replicas := newListeners(t, 2)
+	for _, r := range replicas {
+		startReplica(ctx, t, r, replicas)
+	}
+
+	_, err := replicas[0].nse.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(findNSEs(t, replicas[1].nse)) == 1
+	}, time.Second, time.Millisecond*10)
+
+	// The registration is lost by the storage of the second replica
+	_, err = replicas[1].storage.Unregister(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+	})
+	require.NoError(t, err)
+	require.Empty(t, findNSEs(t, replicas[1].nse))
+
+	require.Eventually(t, func() bool {
+		nses := findNSEs(t, replicas[1].nse)
+		return len(nses) == 1 && nses[0].Name == "nse-1"
+	}, testAntiEntropyPeriod*10, time.Millisecond*10)
+}
+
+func TestNetworkServiceEndpointRegistryServer_AntiEntropyLostUnregister(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replicas := newListeners(t, 2)
+	replicas[1].storage = &failingUnregisterStorage{
+		NetworkServiceEndpointRegistryServer: replicas[1].storage,
+		failures:                             1,
+	}
+	for _, r := range replicas {
+		startReplica(ctx, t, r, replicas)
+	}
+
+	_, err := replicas[0].nse.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(findNSEs(t, replicas[1].nse)) == 1
+	}, time.Second, time.Millisecond*10)
+
+	_, err = replicas[0].nse.Unregister(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(findNSEs(t, replicas[1].nse)) == 0
+	}, testAntiEntropyPeriod*10, time.Millisecond*10)
+}
+
+func withReplicaVersion(version int64) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"nsm-registry-replicated", "true",
+		"nsm-registry-version", strconv.FormatInt(version, 10),
+	))
+}
+
+func TestNetworkServiceEndpointRegistryServer_LastWriterWins(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := replicate.NewNetworkServiceEndpointRegistryServer(ctx, memory.NewNetworkServiceEndpointRegistryServer(), nil,
+		replicate.WithPeerAuthorizer(replicate.AnyPeer()))
+	require.NoError(t, err)
+
+	version := time.Now().UnixNano()
+
+	_, err = s.Unregister(withReplicaVersion(version), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+
+	// Registration older than the deletion is ignored
+	_, err = s.Register(withReplicaVersion(version-1), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	require.Empty(t, findNSEs(t, s))
+
+	_, err = s.Register(withReplicaVersion(version+1), &registry.NetworkServiceEndpoint{Name: "nse-1", Url: "tcp://1.1.1.1"})
+	require.NoError(t, err)
+
+	// Registration older than the current one is ignored
+	_, err = s.Register(withReplicaVersion(version), &registry.NetworkServiceEndpoint{Name: "nse-1", Url: "tcp://2.2.2.2"})
+	require.NoError(t, err)
+
+	nses := findNSEs(t, s)
+	require.Len(t, nses, 1)
+	require.Equal(t, "tcp://1.1.1.1", nses[0].Url)
+}
+
+func TestNetworkServiceEndpointRegistryServer_UnauthorizedReplica(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := replicate.NewNetworkServiceEndpointRegistryServer(ctx, memory.NewNetworkServiceEndpointRegistryServer(), nil)
+	require.NoError(t, err)
+
+	_, err = s.Register(withReplicaVersion(time.Now().UnixNano()), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Empty(t, findNSEs(t, s))
+
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	require.Len(t, findNSEs(t, s), 1)
+}
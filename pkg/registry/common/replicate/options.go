@@ -0,0 +1,131 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	defaultTimeout           = time.Second * 5
+	defaultAntiEntropyPeriod = time.Second * 30
+	// tombstones are kept for several anti-entropy rounds to be delivered to the peers failed to receive them
+	tombstoneLifetimeRounds = 10
+)
+
+type configurable interface {
+	setDialOptions([]grpc.DialOption)
+	setTimeout(time.Duration)
+	setPeerAuthorizer(PeerAuthorizer)
+	setAntiEntropyPeriod(time.Duration)
+	setClock(clock.Clock)
+}
+
+// Option is replicate registry configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithDialOptions sets gRPC dial options for the peer connections
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return applierFunc(func(c configurable) {
+		c.setDialOptions(dialOptions)
+	})
+}
+
+// WithTimeout sets timeout for a single replication call to the peer
+func WithTimeout(timeout time.Duration) Option {
+	return applierFunc(func(c configurable) {
+		c.setTimeout(timeout)
+	})
+}
+
+// WithPeerAuthorizer sets authorizer of the incoming replicated calls, by default all of them are rejected
+func WithPeerAuthorizer(authorizer PeerAuthorizer) Option {
+	return applierFunc(func(c configurable) {
+		c.setPeerAuthorizer(authorizer)
+	})
+}
+
+// WithAntiEntropyPeriod sets period of comparing the local state with the peers and sending them the missed changes
+func WithAntiEntropyPeriod(period time.Duration) Option {
+	return applierFunc(func(c configurable) {
+		c.setAntiEntropyPeriod(period)
+	})
+}
+
+// WithClock sets the clock used for the versions and the anti-entropy, by default the real clock is used
+func WithClock(c clock.Clock) Option {
+	return applierFunc(func(cfg configurable) {
+		cfg.setClock(c)
+	})
+}
+
+type config struct {
+	dialOptions       []grpc.DialOption
+	timeout           time.Duration
+	authorizer        PeerAuthorizer
+	antiEntropyPeriod time.Duration
+	clock             clock.Clock
+}
+
+func (c *config) setDialOptions(dialOptions []grpc.DialOption) {
+	c.dialOptions = dialOptions
+}
+
+func (c *config) setTimeout(timeout time.Duration) {
+	c.timeout = timeout
+}
+
+func (c *config) setPeerAuthorizer(authorizer PeerAuthorizer) {
+	c.authorizer = authorizer
+}
+
+func (c *config) setAntiEntropyPeriod(period time.Duration) {
+	c.antiEntropyPeriod = period
+}
+
+func (c *config) setClock(clk clock.Clock) {
+	c.clock = clk
+}
+
+func (c *config) tombstoneLifetime() time.Duration {
+	return c.antiEntropyPeriod * tombstoneLifetimeRounds
+}
+
+func newConfig(options []Option) *config {
+	c := &config{
+		timeout:           defaultTimeout,
+		authorizer:        denyAll,
+		antiEntropyPeriod: defaultAntiEntropyPeriod,
+		clock:             clock.New(),
+	}
+	for _, o := range options {
+		o.apply(c)
+	}
+	return c
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type version struct {
+	timestamp int64
+	deleted   bool
+	updated   time.Time
+}
+
+// versions tracks last-writer-wins versions of the entries by name. Versions of the deleted entries are kept as
+// tombstones, so the older registrations still being replicated don't bring the entries back.
+type versions struct {
+	clock   clock.Clock
+	entries map[string]*version
+	locks   map[string]*nameLock
+	mutex   sync.Mutex
+}
+
+type nameLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newVersions(c clock.Clock) *versions {
+	return &versions{
+		clock:   c,
+		entries: map[string]*version{},
+		locks:   map[string]*nameLock{},
+	}
+}
+
+// lock locks the entry with the name, so the storage call and the version update are performed atomically
+func (v *versions) lock(name string) (unlock func()) {
+	v.mutex.Lock()
+	l, ok := v.locks[name]
+	if !ok {
+		l = new(nameLock)
+		v.locks[name] = l
+	}
+	l.refs++
+	v.mutex.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		v.mutex.Lock()
+		defer v.mutex.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(v.locks, name)
+		}
+	}
+}
+
+// local returns the version for the local change of the entry, it is greater than the known one even if the clock
+// goes back
+func (v *versions) local(name string) int64 {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	timestamp := v.clock.Now().UnixNano()
+	if known, ok := v.entries[name]; ok && timestamp <= known.timestamp {
+		timestamp = known.timestamp + 1
+	}
+	return timestamp
+}
+
+// isNewer checks if the replicated change of the entry wins over the known one. Deletion wins if the versions are
+// equal.
+func (v *versions) isNewer(name string, timestamp int64, deleted bool) bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	known, ok := v.entries[name]
+	if !ok {
+		return true
+	}
+	if timestamp != known.timestamp {
+		return timestamp > known.timestamp
+	}
+	return deleted && !known.deleted
+}
+
+func (v *versions) set(name string, timestamp int64, deleted bool) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.entries[name] = &version{
+		timestamp: timestamp,
+		deleted:   deleted,
+		updated:   v.clock.Now(),
+	}
+}
+
+// snapshot returns the versions of the existing entries and the tombstones
+func (v *versions) snapshot() (existing, deleted map[string]int64) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	existing, deleted = map[string]int64{}, map[string]int64{}
+	for name, known := range v.entries {
+		if known.deleted {
+			deleted[name] = known.timestamp
+		} else {
+			existing[name] = known.timestamp
+		}
+	}
+	return existing, deleted
+}
+
+// gc forgets the tombstones not updated for the tombstoneLifetime and the versions of the entries not updated since
+// listed time and missing in the storage, e.g. expired ones
+func (v *versions) gc(listed time.Time, names map[string]struct{}, tombstoneLifetime time.Duration) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	for name, known := range v.entries {
+		switch {
+		case known.deleted:
+			if v.clock.Since(known.updated) > tombstoneLifetime {
+				delete(v.entries, name)
+			}
+		case known.updated.Before(listed):
+			if _, ok := names[name]; !ok {
+				delete(v.entries, name)
+			}
+		}
+	}
+}
@@ -51,18 +51,39 @@ func (n *nseSwapRegistryServer) Register(ctx context.Context, nse *registry.Netw
 
 type findNSESwapServer struct {
 	proxyNSMgrURL *url.URL
+	remoteDomain  string
 	registry.NetworkServiceEndpointRegistry_FindServer
 }
 
 func (s *findNSESwapServer) Send(nse *registry.NetworkServiceEndpoint) error {
 	nse.Name = interdomain.Join(interdomain.Target(nse.Name), nse.Url)
 	nse.Url = s.proxyNSMgrURL.String()
+	if s.remoteDomain != "" {
+		for i, ns := range nse.NetworkServiceNames {
+			nse.NetworkServiceNames[i] = interdomain.Join(interdomain.Target(ns), s.remoteDomain)
+		}
+	}
 	return s.NetworkServiceEndpointRegistry_FindServer.Send(nse)
 }
 
 func (n *nseSwapRegistryServer) Find(q *registry.NetworkServiceEndpointQuery, s registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if interdomain.URL(q.NetworkServiceEndpoint.Name) != nil {
+		// Name has been already swapped, so the endpoint is reachable via the proxy network service manager
+		return s.Send(&registry.NetworkServiceEndpoint{
+			Name: q.NetworkServiceEndpoint.Name,
+			Url:  n.proxyNSMgrURL.String(),
+		})
+	}
+	remoteDomain := interdomain.FirstDomain(q.NetworkServiceEndpoint.NetworkServiceNames...)
 	q.NetworkServiceEndpoint.Name = interdomain.Target(q.NetworkServiceEndpoint.Name)
-	return next.NetworkServiceEndpointRegistryServer(s.Context()).Find(q, &findNSESwapServer{NetworkServiceEndpointRegistry_FindServer: s, proxyNSMgrURL: n.proxyNSMgrURL})
+	for i, ns := range q.NetworkServiceEndpoint.NetworkServiceNames {
+		q.NetworkServiceEndpoint.NetworkServiceNames[i] = interdomain.Target(ns)
+	}
+	return next.NetworkServiceEndpointRegistryServer(s.Context()).Find(q, &findNSESwapServer{
+		NetworkServiceEndpointRegistry_FindServer: s,
+		proxyNSMgrURL: n.proxyNSMgrURL,
+		remoteDomain:  remoteDomain,
+	})
 }
 
 func (n *nseSwapRegistryServer) Unregister(ctx context.Context, ns *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
@@ -64,3 +64,35 @@ func TestNewSwapNetworkServiceEndpointRegistryServer_Find(t *testing.T) {
 	require.Equal(t, interdomain.Join("nse-1", "remote_nsmgr_url"), findResult.Name)
 	require.Equal(t, proxyNSMgr.String(), findResult.Url)
 }
+
+func TestNewSwapNetworkServiceEndpointRegistryServer_FindByNetworkService(t *testing.T) {
+	proxyNSMgr := &url.URL{Path: "proxy"}
+	mem := memory.NewNetworkServiceEndpointRegistryServer()
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		swap.NewNetworkServiceEndpointRegistryServer("my.cluster", proxyNSMgr, nil),
+		mem,
+	)
+	_, err := mem.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		Url:                 "tcp://remote-nsmgr:5000",
+	})
+	require.Nil(t, err)
+	ch := make(chan *registry.NetworkServiceEndpoint, 1)
+	err = s.Find(&registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"ns-1@remote.domain"},
+	}}, streamchannel.NewNetworkServiceEndpointFindServer(context.Background(), ch))
+	require.Nil(t, err)
+	findResult := <-ch
+	require.Equal(t, "nse-1@tcp://remote-nsmgr:5000", findResult.Name)
+	require.Equal(t, []string{"ns-1@remote.domain"}, findResult.NetworkServiceNames)
+	require.Equal(t, proxyNSMgr.String(), findResult.Url)
+
+	err = s.Find(&registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+		Name: findResult.Name,
+	}}, streamchannel.NewNetworkServiceEndpointFindServer(context.Background(), ch))
+	require.Nil(t, err)
+	findResult = <-ch
+	require.Equal(t, "nse-1@tcp://remote-nsmgr:5000", findResult.Name)
+	require.Equal(t, proxyNSMgr.String(), findResult.Url)
+}
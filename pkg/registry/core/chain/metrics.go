@@ -0,0 +1,69 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/metrics"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/chainmetrics"
+)
+
+// Metrics - creates the registry chains collecting Prometheus metrics of their chain elements
+type Metrics struct {
+	metrics *chainmetrics.Metrics
+}
+
+// WithMetrics - returns Metrics creating the registry chains collecting the metrics registered on registerer, e.g.
+//   chain.WithMetrics(registry).NewNetworkServiceEndpointRegistryServer(servers...)
+func WithMetrics(registerer prometheus.Registerer) *Metrics {
+	return &Metrics{
+		metrics: chainmetrics.New(registerer),
+	}
+}
+
+// NewNetworkServiceRegistryServer - chains together a list of registry.NetworkServiceRegistryServer with metrics
+func (m *Metrics) NewNetworkServiceRegistryServer(servers ...registry.NetworkServiceRegistryServer) registry.NetworkServiceRegistryServer {
+	return next.NewWrappedNetworkServiceRegistryServer(func(server registry.NetworkServiceRegistryServer) registry.NetworkServiceRegistryServer {
+		return metrics.NewNetworkServiceRegistryServer(m.metrics, server, server)
+	}, servers...)
+}
+
+// NewNetworkServiceRegistryClient - chains together a list of registry.NetworkServiceRegistryClient with metrics
+func (m *Metrics) NewNetworkServiceRegistryClient(clients ...registry.NetworkServiceRegistryClient) registry.NetworkServiceRegistryClient {
+	return next.NewWrappedNetworkServiceRegistryClient(func(client registry.NetworkServiceRegistryClient) registry.NetworkServiceRegistryClient {
+		return metrics.NewNetworkServiceRegistryClient(m.metrics, client, client)
+	}, clients...)
+}
+
+// NewNetworkServiceEndpointRegistryServer - chains together a list of registry.NetworkServiceEndpointRegistryServer
+// with metrics
+func (m *Metrics) NewNetworkServiceEndpointRegistryServer(servers ...registry.NetworkServiceEndpointRegistryServer) registry.NetworkServiceEndpointRegistryServer {
+	return next.NewWrappedNetworkServiceEndpointRegistryServer(func(server registry.NetworkServiceEndpointRegistryServer) registry.NetworkServiceEndpointRegistryServer {
+		return metrics.NewNetworkServiceEndpointRegistryServer(m.metrics, server, server)
+	}, servers...)
+}
+
+// NewNetworkServiceEndpointRegistryClient - chains together a list of registry.NetworkServiceEndpointRegistryClient
+// with metrics
+func (m *Metrics) NewNetworkServiceEndpointRegistryClient(clients ...registry.NetworkServiceEndpointRegistryClient) registry.NetworkServiceEndpointRegistryClient {
+	return next.NewWrappedNetworkServiceEndpointRegistryClient(func(client registry.NetworkServiceEndpointRegistryClient) registry.NetworkServiceEndpointRegistryClient {
+		return metrics.NewNetworkServiceEndpointRegistryClient(m.metrics, client, client)
+	}, clients...)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides chain elements collecting Prometheus metrics of the registry chains into
+// chainmetrics.Metrics. Chains wrapping all their elements with the metrics are created with chain.WithMetrics. Find
+// latency of the watching Find servers is the lifetime of the stream.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/chainmetrics"
+)
+
+type metricsNSRegistryServer struct {
+	measured registry.NetworkServiceRegistryServer
+	metrics  *chainmetrics.ElementMetrics
+}
+
+// NewNetworkServiceRegistryServer - wraps measured with the Register/Find/Unregister latency and errors metrics
+//   - m - metrics to collect into
+//   - element - chain element the metrics are labeled with
+//   - measured - element itself or its wrapper to measure
+func NewNetworkServiceRegistryServer(m *chainmetrics.Metrics, element, measured registry.NetworkServiceRegistryServer) registry.NetworkServiceRegistryServer {
+	return &metricsNSRegistryServer{
+		measured: measured,
+		metrics:  m.Element("registry_ns_server", element),
+	}
+}
+
+func (m *metricsNSRegistryServer) Register(ctx context.Context, in *registry.NetworkService) (*registry.NetworkService, error) {
+	start := time.Now()
+	rv, err := m.measured.Register(ctx, in)
+	m.metrics.Observe("Register", start, err)
+	return rv, err
+}
+
+func (m *metricsNSRegistryServer) Find(query *registry.NetworkServiceQuery, s registry.NetworkServiceRegistry_FindServer) error {
+	start := time.Now()
+	err := m.measured.Find(query, s)
+	m.metrics.Observe("Find", start, err)
+	return err
+}
+
+func (m *metricsNSRegistryServer) Unregister(ctx context.Context, in *registry.NetworkService) (*empty.Empty, error) {
+	start := time.Now()
+	rv, err := m.measured.Unregister(ctx, in)
+	m.metrics.Observe("Unregister", start, err)
+	return rv, err
+}
+
+type metricsNSRegistryClient struct {
+	measured registry.NetworkServiceRegistryClient
+	metrics  *chainmetrics.ElementMetrics
+}
+
+// NewNetworkServiceRegistryClient - wraps measured with the Register/Find/Unregister latency and errors metrics
+//   - m - metrics to collect into
+//   - element - chain element the metrics are labeled with
+//   - measured - element itself or its wrapper to measure
+func NewNetworkServiceRegistryClient(m *chainmetrics.Metrics, element, measured registry.NetworkServiceRegistryClient) registry.NetworkServiceRegistryClient {
+	return &metricsNSRegistryClient{
+		measured: measured,
+		metrics:  m.Element("registry_ns_client", element),
+	}
+}
+
+func (m *metricsNSRegistryClient) Register(ctx context.Context, in *registry.NetworkService, opts ...grpc.CallOption) (*registry.NetworkService, error) {
+	start := time.Now()
+	rv, err := m.measured.Register(ctx, in, opts...)
+	m.metrics.Observe("Register", start, err)
+	return rv, err
+}
+
+func (m *metricsNSRegistryClient) Find(ctx context.Context, query *registry.NetworkServiceQuery, opts ...grpc.CallOption) (registry.NetworkServiceRegistry_FindClient, error) {
+	start := time.Now()
+	rv, err := m.measured.Find(ctx, query, opts...)
+	m.metrics.Observe("Find", start, err)
+	return rv, err
+}
+
+func (m *metricsNSRegistryClient) Unregister(ctx context.Context, in *registry.NetworkService, opts ...grpc.CallOption) (*empty.Empty, error) {
+	start := time.Now()
+	rv, err := m.measured.Unregister(ctx, in, opts...)
+	m.metrics.Observe("Unregister", start, err)
+	return rv, err
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/chainmetrics"
+)
+
+type metricsNSERegistryServer struct {
+	measured registry.NetworkServiceEndpointRegistryServer
+	metrics  *chainmetrics.ElementMetrics
+}
+
+// NewNetworkServiceEndpointRegistryServer - wraps measured with the Register/Find/Unregister latency and errors metrics
+//   - m - metrics to collect into
+//   - element - chain element the metrics are labeled with
+//   - measured - element itself or its wrapper to measure
+func NewNetworkServiceEndpointRegistryServer(m *chainmetrics.Metrics, element, measured registry.NetworkServiceEndpointRegistryServer) registry.NetworkServiceEndpointRegistryServer {
+	return &metricsNSERegistryServer{
+		measured: measured,
+		metrics:  m.Element("registry_nse_server", element),
+	}
+}
+
+func (m *metricsNSERegistryServer) Register(ctx context.Context, in *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	start := time.Now()
+	rv, err := m.measured.Register(ctx, in)
+	m.metrics.Observe("Register", start, err)
+	return rv, err
+}
+
+func (m *metricsNSERegistryServer) Find(query *registry.NetworkServiceEndpointQuery, s registry.NetworkServiceEndpointRegistry_FindServer) error {
+	start := time.Now()
+	err := m.measured.Find(query, s)
+	m.metrics.Observe("Find", start, err)
+	return err
+}
+
+func (m *metricsNSERegistryServer) Unregister(ctx context.Context, in *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	start := time.Now()
+	rv, err := m.measured.Unregister(ctx, in)
+	m.metrics.Observe("Unregister", start, err)
+	return rv, err
+}
+
+type metricsNSERegistryClient struct {
+	measured registry.NetworkServiceEndpointRegistryClient
+	metrics  *chainmetrics.ElementMetrics
+}
+
+// NewNetworkServiceEndpointRegistryClient - wraps measured with the Register/Find/Unregister latency and errors metrics
+//   - m - metrics to collect into
+//   - element - chain element the metrics are labeled with
+//   - measured - element itself or its wrapper to measure
+func NewNetworkServiceEndpointRegistryClient(m *chainmetrics.Metrics, element, measured registry.NetworkServiceEndpointRegistryClient) registry.NetworkServiceEndpointRegistryClient {
+	return &metricsNSERegistryClient{
+		measured: measured,
+		metrics:  m.Element("registry_nse_client", element),
+	}
+}
+
+func (m *metricsNSERegistryClient) Register(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	start := time.Now()
+	rv, err := m.measured.Register(ctx, in, opts...)
+	m.metrics.Observe("Register", start, err)
+	return rv, err
+}
+
+func (m *metricsNSERegistryClient) Find(ctx context.Context, query *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	start := time.Now()
+	rv, err := m.measured.Find(ctx, query, opts...)
+	m.metrics.Observe("Find", start, err)
+	return rv, err
+}
+
+func (m *metricsNSERegistryClient) Unregister(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*empty.Empty, error) {
+	start := time.Now()
+	rv, err := m.measured.Unregister(ctx, in, opts...)
+	m.metrics.Observe("Unregister", start, err)
+	return rv, err
+}
@@ -15,33 +15,43 @@
 // limitations under the License.
 
 // Package trace provides a wrapper for tracing around a registry.{Registry,Discovery}{Server,Client}
+// The logged requests and responses, and the arguments of the messages logged with Log are redacted with
+// redact.Default
 package trace
 
 import (
 	"context"
 
-	"github.com/sirupsen/logrus"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
+	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
 )
 
 type contextKeyType string
 
 const (
-	logKey contextKeyType = "Log"
+	baseLogKey contextKeyType = "BaseLog"
 )
 
 // withLog -
-//   Provides a FieldLogger in context
-func withLog(parent context.Context, log logrus.FieldLogger) context.Context {
+//   Provides a log.Logger with the chain element field in context. If there is no Logger set by the user in parent,
+//   the Logger also logs to the span.
+func withLog(parent context.Context, span spanhelper.SpanHelper, operation string) context.Context {
 	if parent == nil {
 		parent = context.TODO()
 	}
-	return context.WithValue(parent, logKey, log)
+	logger, ok := parent.Value(baseLogKey).(log.Logger)
+	if !ok {
+		logger = log.FromContext(parent)
+	}
+	ctx := context.WithValue(parent, baseLogKey, logger)
+	if logger == log.Default() {
+		logger = log.NewLogrus(span.Logger())
+	}
+	return log.WithLog(ctx, redact.Logger(logger.WithField(log.ChainElementField, operation)))
 }
 
-// Log - return FieldLogger from context
-func Log(ctx context.Context) logrus.FieldLogger {
-	if rv, ok := ctx.Value(logKey).(logrus.FieldLogger); ok {
-		return rv
-	}
-	return logrus.New()
+// Log - return log.Logger from context
+func Log(ctx context.Context) log.Logger {
+	return log.FromContext(ctx)
 }
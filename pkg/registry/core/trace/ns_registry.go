@@ -44,7 +44,7 @@ func (t *traceNetworkServiceRegistryFindClient) Recv() (*registry.NetworkService
 	span := spanhelper.FromContext(t.Context(), operation)
 	defer span.Finish()
 
-	ctx := withLog(span.Context(), span.Logger())
+	ctx := withLog(span.Context(), span, operation)
 	s := streamcontext.NetworkServiceRegistryFindClient(ctx, t)
 	rv, err := s.Recv()
 	if err != nil {
@@ -65,7 +65,7 @@ func (t *traceNetworkServiceRegistryClient) Register(ctx context.Context, in *re
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withLog(span.Context(), span, operation)
 
 	span.LogObject("request", in)
 
@@ -87,7 +87,7 @@ func (t *traceNetworkServiceRegistryClient) Find(ctx context.Context, in *regist
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withLog(span.Context(), span, operation)
 
 	span.LogObject("find", in)
 
@@ -112,7 +112,7 @@ func (t *traceNetworkServiceRegistryClient) Unregister(ctx context.Context, in *
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withLog(span.Context(), span, operation)
 
 	span.LogObject("request", in)
 
@@ -145,7 +145,7 @@ func (t *traceNetworkServiceRegistryServer) Register(ctx context.Context, in *re
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withLog(span.Context(), span, operation)
 
 	span.LogObject("request", in)
 
@@ -168,7 +168,7 @@ func (t *traceNetworkServiceRegistryServer) Find(in *registry.NetworkServiceQuer
 	span := spanhelper.FromContext(s.Context(), operation)
 	defer span.Finish()
 
-	ctx := withLog(span.Context(), span.Logger())
+	ctx := withLog(span.Context(), span, operation)
 	s = &traceNetworkServiceRegistryFindServer{
 		NetworkServiceRegistry_FindServer: streamcontext.NetworkServiceRegistryFindServer(ctx, s),
 	}
@@ -193,7 +193,7 @@ func (t *traceNetworkServiceRegistryServer) Unregister(ctx context.Context, in *
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withLog(span.Context(), span, operation)
 
 	span.LogObject("request", in)
 
@@ -226,7 +226,7 @@ func (t *traceNetworkServiceRegistryFindServer) Send(ns *registry.NetworkService
 	span := spanhelper.FromContext(t.Context(), operation)
 	defer span.Finish()
 	span.LogObject("network service", ns)
-	ctx := withLog(span.Context(), span.Logger())
+	ctx := withLog(span.Context(), span, operation)
 	s := streamcontext.NetworkServiceRegistryFindServer(ctx, t)
 	err := s.Send(ns)
 	if err != nil {
@@ -44,7 +44,7 @@ func (t *traceNetworkServiceEndpointRegistryFindClient) Recv() (*registry.Networ
 	span := spanhelper.FromContext(t.Context(), operation)
 	defer span.Finish()
 
-	ctx := withLog(span.Context(), span.Logger())
+	ctx := withLog(span.Context(), span, operation)
 	s := streamcontext.NetworkServiceEndpointRegistryFindClient(ctx, t)
 	rv, err := s.Recv()
 	if err != nil {
@@ -65,7 +65,7 @@ func (t *traceNetworkServiceEndpointRegistryClient) Register(ctx context.Context
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withLog(span.Context(), span, operation)
 
 	span.LogObject("request", in)
 
@@ -87,7 +87,7 @@ func (t *traceNetworkServiceEndpointRegistryClient) Find(ctx context.Context, in
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withLog(span.Context(), span, operation)
 
 	span.LogObject("find", in)
 
@@ -112,7 +112,7 @@ func (t *traceNetworkServiceEndpointRegistryClient) Unregister(ctx context.Conte
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withLog(span.Context(), span, operation)
 
 	span.LogObject("request", in)
 
@@ -145,7 +145,7 @@ func (t *traceNetworkServiceEndpointRegistryServer) Register(ctx context.Context
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withLog(span.Context(), span, operation)
 
 	span.LogObject("request", in)
 
@@ -168,7 +168,7 @@ func (t *traceNetworkServiceEndpointRegistryServer) Find(in *registry.NetworkSer
 	span := spanhelper.FromContext(s.Context(), operation)
 	defer span.Finish()
 
-	ctx := withLog(span.Context(), span.Logger())
+	ctx := withLog(span.Context(), span, operation)
 	s = &traceNetworkServiceEndpointRegistryFindServer{
 		NetworkServiceEndpointRegistry_FindServer: streamcontext.NetworkServiceEndpointRegistryFindServer(ctx, s),
 	}
@@ -193,7 +193,7 @@ func (t *traceNetworkServiceEndpointRegistryServer) Unregister(ctx context.Conte
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withLog(span.Context(), span, operation)
 
 	span.LogObject("request", in)
 
@@ -226,7 +226,7 @@ func (t *traceNetworkServiceEndpointRegistryFindServer) Send(nse *registry.Netwo
 	span := spanhelper.FromContext(t.Context(), operation)
 	defer span.Finish()
 	span.LogObject("network service endpoint", nse)
-	ctx := withLog(span.Context(), span.Logger())
+	ctx := withLog(span.Context(), span, operation)
 	s := streamcontext.NetworkServiceEndpointRegistryFindServer(ctx, t)
 	err := s.Send(nse)
 	if err != nil {
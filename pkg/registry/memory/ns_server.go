@@ -21,6 +21,7 @@ import (
 	"errors"
 	"io"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
@@ -55,7 +56,7 @@ func (n *networkServiceRegistryServer) Find(query *registry.NetworkServiceQuery,
 		var err error
 		n.networkServices.Range(func(key string, value *registry.NetworkService) bool {
 			if matchutils.MatchNetworkServices(ns, value) {
-				err = s.Send(value)
+				err = s.Send(proto.Clone(value).(*registry.NetworkService))
 				return err == nil
 			}
 			return true
@@ -85,7 +86,7 @@ func (n *networkServiceRegistryServer) Find(query *registry.NetworkServiceQuery,
 					if s.Context().Err() != nil {
 						return io.EOF
 					}
-					if err := s.Send(event); err != nil {
+					if err := s.Send(proto.Clone(event).(*registry.NetworkService)); err != nil {
 						return err
 					}
 				}
@@ -107,3 +107,30 @@ func TestNetworkServiceRegistryServer_RegisterAndFindWatch(t *testing.T) {
 	cancel()
 	close(ch)
 }
+
+func TestNetworkServiceRegistryServer_ChangeFoundService(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	s := next.NewNetworkServiceRegistryServer(memory.NewNetworkServiceRegistryServer())
+
+	_, err := s.Register(context.Background(), &registry.NetworkService{
+		Name:    "a",
+		Payload: "IP",
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan *registry.NetworkService, 1)
+	query := &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{
+			Name: "a",
+		},
+	}
+	_ = s.Find(query, streamchannel.NewNetworkServiceFindServer(ctx, ch))
+
+	// The next chain elements can change the found service
+	(<-ch).Payload = "ETHERNET"
+
+	_ = s.Find(query, streamchannel.NewNetworkServiceFindServer(ctx, ch))
+	require.Equal(t, "IP", (<-ch).Payload)
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
@@ -53,7 +54,7 @@ func (n *networkServiceEndpointRegistryServer) Find(query *registry.NetworkServi
 		var err error
 		n.networkServiceEndpoints.Range(func(key string, value *registry.NetworkServiceEndpoint) bool {
 			if matchutils.MatchNetworkServiceEndpoints(ns, value) {
-				err = s.Send(value)
+				err = s.Send(proto.Clone(value).(*registry.NetworkServiceEndpoint))
 				return err == nil
 			}
 			return true
@@ -83,7 +84,7 @@ func (n *networkServiceEndpointRegistryServer) Find(query *registry.NetworkServi
 					if s.Context().Err() != nil {
 						return io.EOF
 					}
-					if err := s.Send(event); err != nil {
+					if err := s.Send(proto.Clone(event).(*registry.NetworkServiceEndpoint)); err != nil {
 						return err
 					}
 				}
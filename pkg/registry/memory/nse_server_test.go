@@ -107,3 +107,30 @@ func TestNetworkServiceEndpointRegistryServer_RegisterAndFindWatch(t *testing.T)
 	cancel()
 	close(ch)
 }
+
+func TestNetworkServiceEndpointRegistryServer_ChangeFoundEndpoint(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	s := next.NewNetworkServiceEndpointRegistryServer(memory.NewNetworkServiceEndpointRegistryServer())
+
+	_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "a",
+		Url:  "tcp://1.1.1.1",
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan *registry.NetworkServiceEndpoint, 1)
+	query := &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name: "a",
+		},
+	}
+	_ = s.Find(query, streamchannel.NewNetworkServiceEndpointFindServer(ctx, ch))
+
+	// The next chain elements can change the found endpoint
+	(<-ch).Url = "tcp://2.2.2.2"
+
+	_ = s.Find(query, streamchannel.NewNetworkServiceEndpointFindServer(ctx, ch))
+	require.Equal(t, "tcp://1.1.1.1", (<-ch).Url)
+}
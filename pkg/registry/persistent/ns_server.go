@@ -0,0 +1,81 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistent
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+)
+
+type networkServiceRegistryServer struct {
+	memory registry.NetworkServiceRegistryServer
+	store  *store
+}
+
+func (n *networkServiceRegistryServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	r, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	// The registration is persisted first, so memory never has the values missing in the log file
+	if err := n.store.put(r.Name, r); err != nil {
+		return nil, err
+	}
+	// Memory is the last element here, the next elements have already been called
+	return n.memory.Register(context.Background(), r)
+}
+
+func (n *networkServiceRegistryServer) Find(query *registry.NetworkServiceQuery, s registry.NetworkServiceRegistry_FindServer) error {
+	return n.memory.Find(query, s)
+}
+
+func (n *networkServiceRegistryServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if err := n.store.delete(ns.Name); err != nil {
+		return nil, err
+	}
+	return n.memory.Unregister(ctx, ns)
+}
+
+// NewNetworkServiceRegistryServer creates new file based NetworkServiceRegistryServer. All the registrations are
+// stored in the log file by the path and restored on the next start. The log file is closed on ctx.Done().
+func NewNetworkServiceRegistryServer(ctx context.Context, path string, options ...Option) (registry.NetworkServiceRegistryServer, error) {
+	c := newConfig(options)
+	st, err := newStore(ctx, path, c.compactionThreshold, func() proto.Message {
+		return new(registry.NetworkService)
+	}, func(proto.Message) bool {
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := &networkServiceRegistryServer{
+		memory: memory.NewNetworkServiceRegistryServer(c.memoryOptions...),
+		store:  st,
+	}
+	for _, value := range st.values() {
+		if _, err := r.memory.Register(context.Background(), value.(*registry.NetworkService)); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
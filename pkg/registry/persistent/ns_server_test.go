@@ -0,0 +1,64 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/persistent"
+)
+
+func TestNetworkServiceRegistryServer_Restart(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	path, cleanup := tempPath(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := persistent.NewNetworkServiceRegistryServer(ctx, path)
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b"} {
+		_, err = s.Register(context.Background(), &registry.NetworkService{
+			Name:    name,
+			Payload: "IP",
+		})
+		require.NoError(t, err)
+	}
+	_, err = s.Unregister(context.Background(), &registry.NetworkService{Name: "a"})
+	require.NoError(t, err)
+	cancel()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	s, err = persistent.NewNetworkServiceRegistryServer(ctx, path)
+	require.NoError(t, err)
+
+	stream, err := adapters.NetworkServiceServerToClient(s).Find(context.Background(), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{},
+	})
+	require.NoError(t, err)
+	list := registry.ReadNetworkServiceList(stream)
+	require.Len(t, list, 1)
+	require.Equal(t, "b", list[0].Name)
+	require.Equal(t, "IP", list[0].Payload)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistent
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type networkServiceEndpointRegistryServer struct {
+	memory registry.NetworkServiceEndpointRegistryServer
+	store  *store
+}
+
+func (n *networkServiceEndpointRegistryServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	r, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	// The registration is persisted first, so memory never has the values missing in the log file
+	if err := n.store.put(r.Name, r); err != nil {
+		return nil, err
+	}
+	// Memory is the last element here, the next elements have already been called
+	return n.memory.Register(context.Background(), r)
+}
+
+func (n *networkServiceEndpointRegistryServer) Find(query *registry.NetworkServiceEndpointQuery, s registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return n.memory.Find(query, s)
+}
+
+func (n *networkServiceEndpointRegistryServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if err := n.store.delete(nse.Name); err != nil {
+		return nil, err
+	}
+	return n.memory.Unregister(ctx, nse)
+}
+
+// NewNetworkServiceEndpointRegistryServer creates new file based NetworkServiceEndpointRegistryServer. All the
+// registrations are stored in the log file by the path and restored on the next start, except of expired ones.
+// The log file is closed on ctx.Done().
+func NewNetworkServiceEndpointRegistryServer(ctx context.Context, path string, options ...Option) (registry.NetworkServiceEndpointRegistryServer, error) {
+	c := newConfig(options)
+	st, err := newStore(ctx, path, c.compactionThreshold, func() proto.Message {
+		return new(registry.NetworkServiceEndpoint)
+	}, func(m proto.Message) bool {
+		return isExpiredNSE(c.clock, m)
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := &networkServiceEndpointRegistryServer{
+		memory: memory.NewNetworkServiceEndpointRegistryServer(c.memoryOptions...),
+		store:  st,
+	}
+	for _, value := range st.values() {
+		if _, err := r.memory.Register(context.Background(), value.(*registry.NetworkServiceEndpoint)); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func isExpiredNSE(clk clock.Clock, m proto.Message) bool {
+	nse := m.(*registry.NetworkServiceEndpoint)
+	if nse.ExpirationTime == nil {
+		return false
+	}
+	return clk.Until(time.Unix(nse.ExpirationTime.Seconds, int64(nse.ExpirationTime.Nanos))) <= 0
+}
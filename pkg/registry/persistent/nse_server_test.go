@@ -0,0 +1,257 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistent_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/persistent"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+func tempPath(t *testing.T) (path string, cleanup func()) {
+	dir, err := ioutil.TempDir("", "persistent")
+	require.NoError(t, err)
+	return filepath.Join(dir, "registry.log"), func() {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func findNSEs(t *testing.T, s registry.NetworkServiceEndpointRegistryServer) []*registry.NetworkServiceEndpoint {
+	stream, err := adapters.NetworkServiceEndpointServerToClient(s).Find(context.Background(), &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+	})
+	require.NoError(t, err)
+	return registry.ReadNetworkServiceEndpointList(stream)
+}
+
+func names(nses []*registry.NetworkServiceEndpoint) []string {
+	var result []string
+	for _, nse := range nses {
+		result = append(result, nse.Name)
+	}
+	return result
+}
+
+func TestNetworkServiceEndpointRegistryServer_Restart(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	path, cleanup := tempPath(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := persistent.NewNetworkServiceEndpointRegistryServer(ctx, path)
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "c"} {
+		_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+			Name:                name,
+			NetworkServiceNames: []string{"ns"},
+		})
+		require.NoError(t, err)
+	}
+	_, err = s.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "b"})
+	require.NoError(t, err)
+	cancel()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	s, err = persistent.NewNetworkServiceEndpointRegistryServer(ctx, path)
+	require.NoError(t, err)
+
+	nses := findNSEs(t, s)
+	require.ElementsMatch(t, []string{"a", "c"}, names(nses))
+	require.Equal(t, []string{"ns"}, nses[0].NetworkServiceNames)
+}
+
+func TestNetworkServiceEndpointRegistryServer_RestartSkipsExpired(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	path, cleanup := tempPath(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := persistent.NewNetworkServiceEndpointRegistryServer(ctx, path)
+	require.NoError(t, err)
+
+	clk := clock.NewFake(time.Now())
+	expired := clk.Now().Add(time.Minute)
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:           "expired",
+		ExpirationTime: &timestamp.Timestamp{Seconds: expired.Unix()},
+	})
+	require.NoError(t, err)
+	actual := clk.Now().Add(time.Hour)
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:           "actual",
+		ExpirationTime: &timestamp.Timestamp{Seconds: actual.Unix()},
+	})
+	require.NoError(t, err)
+	cancel()
+
+	clk.Add(time.Minute)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	s, err = persistent.NewNetworkServiceEndpointRegistryServer(ctx, path, persistent.WithClock(clk))
+	require.NoError(t, err)
+
+	nses := findNSEs(t, s)
+	require.Equal(t, []string{"actual"}, names(nses))
+	require.Equal(t, actual.Unix(), nses[0].ExpirationTime.Seconds)
+}
+
+func TestNetworkServiceEndpointRegistryServer_TornRecord(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	path, cleanup := tempPath(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := persistent.NewNetworkServiceEndpointRegistryServer(ctx, path)
+	require.NoError(t, err)
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "a"})
+	require.NoError(t, err)
+	cancel()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"key":"b","value":{"na`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	s, err = persistent.NewNetworkServiceEndpointRegistryServer(ctx, path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, names(findNSEs(t, s)))
+}
+
+func TestNetworkServiceEndpointRegistryServer_Compaction(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	path, cleanup := tempPath(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := persistent.NewNetworkServiceEndpointRegistryServer(ctx, path, persistent.WithCompactionThreshold(10))
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "a"})
+		require.NoError(t, err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.LessOrEqual(t, strings.Count(string(data), "\n"), 10)
+}
+
+func TestNetworkServiceEndpointRegistryServer_NoCompactionWithoutGarbage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	path, cleanup := tempPath(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := persistent.NewNetworkServiceEndpointRegistryServer(ctx, path, persistent.WithCompactionThreshold(10))
+	require.NoError(t, err)
+
+	var expected []string
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("nse-%02d", i)
+		_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: name})
+		require.NoError(t, err)
+		expected = append(expected, name)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 20)
+	for i, line := range lines {
+		require.Contains(t, line, expected[i])
+	}
+}
+
+func TestNetworkServiceEndpointRegistryServer_StoreFailure(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	path, cleanup := tempPath(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := persistent.NewNetworkServiceEndpointRegistryServer(ctx, path)
+	require.NoError(t, err)
+
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "a"})
+	require.NoError(t, err)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "a"})
+		return err != nil
+	}, time.Second, time.Millisecond*10)
+
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "b"})
+	require.Error(t, err)
+	require.Equal(t, []string{"a"}, names(findNSEs(t, s)))
+}
+
+func TestNetworkServiceEndpointRegistryServer_RegisterAndFindWatch(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	path, cleanup := tempPath(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mem, err := persistent.NewNetworkServiceEndpointRegistryServer(ctx, path)
+	require.NoError(t, err)
+	s := next.NewNetworkServiceEndpointRegistryServer(mem)
+
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "a"})
+	require.NoError(t, err)
+
+	findCtx, findCancel := context.WithCancel(context.Background())
+	defer findCancel()
+	stream, err := adapters.NetworkServiceEndpointServerToClient(s).Find(findCtx, &registry.NetworkServiceEndpointQuery{
+		Watch:                  true,
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "a"},
+	})
+	require.NoError(t, err)
+
+	nse, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "a", nse.Name)
+
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "a", Url: "tcp://1.1.1.1"})
+	require.NoError(t, err)
+
+	nse, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "tcp://1.1.1.1", nse.Url)
+}
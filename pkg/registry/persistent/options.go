@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistent
+
+import (
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const defaultCompactionThreshold = 1000
+
+type configurable interface {
+	setEventChannelSize(int)
+	setCompactionThreshold(int)
+	setClock(clock.Clock)
+}
+
+// Option is persistent registry configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithEventChannelSize sets specific size of event channels
+func WithEventChannelSize(l int) Option {
+	return applierFunc(func(c configurable) {
+		c.setEventChannelSize(l)
+	})
+}
+
+// WithCompactionThreshold sets number of obsolete records in the log file after which the log file is compacted
+func WithCompactionThreshold(n int) Option {
+	return applierFunc(func(c configurable) {
+		c.setCompactionThreshold(n)
+	})
+}
+
+// WithClock sets the clock used to skip the expired registrations on restore, by default the real clock is used
+func WithClock(c clock.Clock) Option {
+	return applierFunc(func(cfg configurable) {
+		cfg.setClock(c)
+	})
+}
+
+type config struct {
+	memoryOptions       []memory.Option
+	compactionThreshold int
+	clock               clock.Clock
+}
+
+func (c *config) setEventChannelSize(l int) {
+	c.memoryOptions = append(c.memoryOptions, memory.WithEventChannelSize(l))
+}
+
+func (c *config) setCompactionThreshold(n int) {
+	c.compactionThreshold = n
+}
+
+func (c *config) setClock(clk clock.Clock) {
+	c.clock = clk
+}
+
+func newConfig(options []Option) *config {
+	c := &config{
+		compactionThreshold: defaultCompactionThreshold,
+		clock:               clock.New(),
+	}
+	for _, o := range options {
+		o.apply(c)
+	}
+	return c
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const filePermissions = 0600
+
+// record is a single line of the log file. Record without value means that the key has been deleted.
+type record struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// store is an append-only log file of the proto messages. Every change is appended to the end of the file, the file
+// is compacted to contain only actual values when the number of obsolete records reaches the threshold.
+type store struct {
+	path       string
+	threshold  int
+	newMessage func() proto.Message
+	isExpired  func(proto.Message) bool
+
+	file    *os.File
+	items   map[string]proto.Message
+	records int
+	mutex   sync.Mutex
+}
+
+func newStore(ctx context.Context, path string, threshold int, newMessage func() proto.Message, isExpired func(proto.Message) bool) (*store, error) {
+	s := &store{
+		path:       path,
+		threshold:  threshold,
+		newMessage: newMessage,
+		isExpired:  isExpired,
+		items:      map[string]proto.Message{},
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	if err := s.compact(); err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		_ = s.file.Close()
+		s.file = nil
+	}()
+	return s, nil
+}
+
+// values returns all actual values from the store
+func (s *store) values() []proto.Message {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var result []proto.Message
+	for _, v := range s.items {
+		result = append(result, v)
+	}
+	return result
+}
+
+func (s *store) put(key string, value proto.Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	data, err := marshalRecord(key, value)
+	if err != nil {
+		return err
+	}
+	if err := s.append(data); err != nil {
+		return err
+	}
+	s.items[key] = proto.Clone(value)
+	return s.compactIfNeeded()
+}
+
+func (s *store) delete(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	data, err := marshalRecord(key, nil)
+	if err != nil {
+		return err
+	}
+	if err := s.append(data); err != nil {
+		return err
+	}
+	delete(s.items, key)
+	return s.compactIfNeeded()
+}
+
+func (s *store) append(data []byte) error {
+	if s.file == nil {
+		return errors.Errorf("store %s is closed", s.path)
+	}
+	if _, err := s.file.Write(data); err != nil {
+		return errors.Wrapf(err, "failed to write to %s", s.path)
+	}
+	if err := s.file.Sync(); err != nil {
+		return errors.Wrapf(err, "failed to sync %s", s.path)
+	}
+	s.records++
+	return nil
+}
+
+func (s *store) compactIfNeeded() error {
+	if s.threshold <= 0 || s.records-len(s.items) < s.threshold {
+		return nil
+	}
+	return s.compact()
+}
+
+// load replays the log file. The last line may be partially written in case of crash, so it is skipped if it is
+// broken.
+func (s *store) load() error {
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", s.path)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	var lines [][]byte
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrapf(err, "failed to read %s", s.path)
+	}
+	for i, line := range lines {
+		key, value, err := s.unmarshalRecord(line)
+		if err != nil {
+			if i == len(lines)-1 {
+				break
+			}
+			return errors.Wrapf(err, "%s is corrupted at record %d", s.path, i)
+		}
+		if value == nil {
+			delete(s.items, key)
+			continue
+		}
+		s.items[key] = value
+	}
+	return nil
+}
+
+// compact rewrites the log file with the actual not expired values only
+func (s *store) compact() error {
+	for key, value := range s.items {
+		if s.isExpired(value) {
+			delete(s.items, key)
+		}
+	}
+
+	tmpPath := s.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, filePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", tmpPath)
+	}
+	if err := s.writeAll(tmp); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close %s", tmpPath)
+	}
+
+	if s.file != nil {
+		_ = s.file.Close()
+		s.file = nil
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return errors.Wrapf(err, "failed to replace %s", s.path)
+	}
+	if s.file, err = os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, filePermissions); err != nil {
+		return errors.Wrapf(err, "failed to open %s", s.path)
+	}
+	s.records = len(s.items)
+	return nil
+}
+
+func (s *store) writeAll(file *os.File) error {
+	w := bufio.NewWriter(file)
+	for key, value := range s.items {
+		data, err := marshalRecord(key, value)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return errors.Wrapf(err, "failed to write to %s", file.Name())
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return errors.Wrapf(err, "failed to write to %s", file.Name())
+	}
+	return errors.Wrapf(file.Sync(), "failed to sync %s", file.Name())
+}
+
+func (s *store) unmarshalRecord(data []byte) (key string, value proto.Message, err error) {
+	r := new(record)
+	if err := json.Unmarshal(data, r); err != nil {
+		return "", nil, errors.WithStack(err)
+	}
+	if len(r.Value) == 0 {
+		return r.Key, nil, nil
+	}
+	value = s.newMessage()
+	if err := jsonpb.Unmarshal(bytes.NewReader(r.Value), value); err != nil {
+		return "", nil, errors.WithStack(err)
+	}
+	return r.Key, value, nil
+}
+
+func marshalRecord(key string, value proto.Message) ([]byte, error) {
+	r := &record{Key: key}
+	if value != nil {
+		s, err := new(jsonpb.Marshaler).MarshalToString(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal %s", key)
+		}
+		r.Value = json.RawMessage(s)
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %s", key)
+	}
+	return append(data, '\n'), nil
+}
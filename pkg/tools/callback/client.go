@@ -22,8 +22,9 @@ import (
 	"errors"
 	"net"
 
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 // Client - a callback server client, main functionality is to serve local grpc and perform operations on it.
@@ -124,7 +125,7 @@ func (c *callbackClient) Serve(ctx context.Context) {
 			// Client call handle to clientConnInterface
 			cl, err := c.scClient.HandleCallbacks(ctx)
 			if err != nil {
-				logrus.Infof("Error: %v. try again", err)
+				log.FromContext(ctx).Warnf("Error: %v. try again", err)
 				c.errorChan <- err
 				continue
 			}
@@ -24,7 +24,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/sirupsen/logrus"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 type clientConnection struct {
@@ -71,7 +71,7 @@ func (c *clientConnection) Read(b []byte) (n int, err error) {
 		data, err := c.client.Recv()
 		if err != nil {
 			if c.isShowError(err) {
-				logrus.Errorf("error receive: %v", err)
+				log.FromContext(c.ctx).Errorf("error receive: %v", err)
 			}
 			return 0, err
 		}
@@ -25,7 +25,6 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
@@ -65,7 +64,7 @@ func IdentityByAuthority(ctx context.Context) (string, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		err := errors.New("Not metadata provided")
-		logrus.Error(err)
+		log.FromContext(ctx).Errorf("%v", err)
 		return "", err
 	}
 	return md.Get(":authority")[0], nil
@@ -164,7 +163,7 @@ func (s *serverImpl) WithCallbackDialer() grpc.DialOption {
 		if ok {
 			if srv.created {
 				err := errors.New("Client is already created")
-				log.Entry(ctx).Errorf("Failed to connect to callback: %v", err)
+				log.FromContext(ctx).Errorf("Failed to connect to callback: %v", err)
 				return nil, err
 			}
 			srv.created = true
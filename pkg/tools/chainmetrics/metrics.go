@@ -0,0 +1,122 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chainmetrics provides Prometheus collectors of the chain metrics, registered on the given
+// prometheus.Registerer:
+//   - nsm_chain_element_duration_seconds - latency of each chain element method (including the next chain elements)
+//   - nsm_chain_element_errors_total - errors of each chain element method by gRPC code
+//   - nsm_active_connections - active connections by network service and network service endpoint
+// Chains collecting the metrics are created with chain.WithMetrics of the networkservice and registry chain packages,
+// the collected metrics are exposed by Handler.
+package chainmetrics
+
+import (
+	"net/http"
+	"path"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics - Prometheus collectors of the chain metrics
+type Metrics struct {
+	durations         *prometheus.HistogramVec
+	errors            *prometheus.CounterVec
+	activeConnections *prometheus.GaugeVec
+}
+
+// New - creates the chain metrics registered on registerer. Collectors already registered on registerer (e.g. by
+//       another New call) are reused, so the chains created with the same registerer share the metrics.
+func New(registerer prometheus.Registerer) *Metrics {
+	return &Metrics{
+		durations: register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "nsm_chain_element_duration_seconds",
+			Help:    "Latency of the chain element method including the next chain elements",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 4, 10),
+		}, []string{"chain", "element", "method"})).(*prometheus.HistogramVec),
+		errors: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "nsm_chain_element_errors_total",
+			Help: "Errors of the chain element method by gRPC code",
+		}, []string{"chain", "element", "method", "code"})).(*prometheus.CounterVec),
+		activeConnections: register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "nsm_active_connections",
+			Help: "Active connections by network service and network service endpoint",
+		}, []string{"network_service", "network_service_endpoint"})).(*prometheus.GaugeVec),
+	}
+}
+
+// Handler - returns the HTTP handler exposing the metrics gathered by gatherer in the Prometheus format, e.g. the
+//           *prometheus.Registry passed to New
+func Handler(gatherer prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+}
+
+// register - registers c on registerer and returns it, or returns the already registered collector
+func register(registerer prometheus.Registerer, c prometheus.Collector) prometheus.Collector {
+	if err := registerer.Register(c); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector
+		}
+		panic(err)
+	}
+	return c
+}
+
+func elementName(element interface{}) string {
+	t := reflect.TypeOf(element)
+	if t == nil {
+		return "nil"
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+// ActiveConnections - returns the nsm_active_connections gauges
+func (m *Metrics) ActiveConnections() *prometheus.GaugeVec {
+	return m.activeConnections
+}
+
+// ElementMetrics - latency and errors metrics of the chain element
+type ElementMetrics struct {
+	durations prometheus.ObserverVec
+	errors    *prometheus.CounterVec
+}
+
+// Element - returns the metrics of the element in the chain of the given kind (e.g. "server", "registry_nse_client")
+func (m *Metrics) Element(chain string, element interface{}) *ElementMetrics {
+	labels := prometheus.Labels{
+		"chain":   chain,
+		"element": elementName(element),
+	}
+	return &ElementMetrics{
+		durations: m.durations.MustCurryWith(labels),
+		errors:    m.errors.MustCurryWith(labels),
+	}
+}
+
+// Observe - observes the method call started at start and completed with err
+func (m *ElementMetrics) Observe(method string, start time.Time, err error) {
+	m.durations.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(method, status.Code(errors.Cause(err)).String()).Inc()
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package circuitbreaker provides circuit breakers keyed by URL to fail fast on the requests to the failing
+// downstream servers
+package circuitbreaker
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 10 * time.Second
+)
+
+// State - circuit state
+type State int
+
+const (
+	// Closed - requests are passed through, consecutive failures are counted
+	Closed State = iota
+	// Open - requests fail fast
+	Open
+	// HalfOpen - open timeout has passed, a single probe request is passed through to decide if the circuit should be
+	//            closed or opened again
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+type circuit struct {
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// Breakers - set of circuit breakers keyed by URL
+type Breakers struct {
+	failureThreshold int
+	openTimeout      time.Duration
+	clock            clock.Clock
+	circuits         map[string]*circuit
+	mutex            sync.Mutex
+}
+
+// New - creates a new Breakers
+//       - options - configuration options
+func New(options ...Option) *Breakers {
+	b := &Breakers{
+		failureThreshold: defaultFailureThreshold,
+		openTimeout:      defaultOpenTimeout,
+		clock:            clock.New(),
+		circuits:         make(map[string]*circuit),
+	}
+	for _, o := range options {
+		o.apply(b)
+	}
+	return b
+}
+
+func (b *Breakers) setFailureThreshold(failureThreshold int) {
+	b.failureThreshold = failureThreshold
+}
+
+func (b *Breakers) setOpenTimeout(openTimeout time.Duration) {
+	b.openTimeout = openTimeout
+}
+
+func (b *Breakers) setClock(c clock.Clock) {
+	b.clock = c
+}
+
+// State - returns the circuit state for u. Open circuit is reported as HalfOpen once the open timeout has passed
+func (b *Breakers) State(u *url.URL) State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c, ok := b.circuits[u.String()]
+	if !ok {
+		return Closed
+	}
+	if c.state == Open && b.clock.Since(c.openedAt) >= b.openTimeout {
+		return HalfOpen
+	}
+	return c.state
+}
+
+// Allow - returns Unavailable error if the request to u should fail fast. Each allowed request should be followed by
+//         the Report of its result
+func (b *Breakers) Allow(u *url.URL) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c, ok := b.circuits[u.String()]
+	if !ok {
+		return nil
+	}
+	switch c.state {
+	case Open:
+		if b.clock.Since(c.openedAt) < b.openTimeout {
+			return status.Errorf(codes.Unavailable, "circuit breaker is open for %s", u)
+		}
+		c.state = HalfOpen
+		c.probing = true
+	case HalfOpen:
+		if c.probing {
+			return status.Errorf(codes.Unavailable, "circuit breaker is half-open for %s, probe is in progress", u)
+		}
+		c.probing = true
+	}
+	return nil
+}
+
+// Report - reports the result of the request to u. Only the errors showing that the server is not reachable
+//          (Unavailable, DeadlineExceeded) are counted as failures. Canceled request has no result: the circuit is
+//          kept as is, half-open circuit lets the next probe through
+func (b *Breakers) Report(u *url.URL, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	key := u.String()
+	c, ok := b.circuits[key]
+	if isCanceled(err) {
+		if ok {
+			c.probing = false
+		}
+		return
+	}
+	if !isFailure(err) {
+		// Closed circuit without failures is the same as no circuit
+		delete(b.circuits, key)
+		return
+	}
+	if !ok {
+		c = &circuit{}
+		b.circuits[key] = c
+	}
+	c.failures++
+	c.probing = false
+	if c.state == HalfOpen || c.failures >= b.failureThreshold {
+		c.state = Open
+		c.openedAt = b.clock.Now()
+	}
+}
+
+func isCanceled(err error) bool {
+	return errors.Cause(err) == context.Canceled || status.Code(errors.Cause(err)) == codes.Canceled
+}
+
+func isFailure(err error) bool {
+	if errors.Cause(err) == context.DeadlineExceeded {
+		return true
+	}
+	switch status.Code(errors.Cause(err)) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
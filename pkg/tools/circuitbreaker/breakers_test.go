@@ -0,0 +1,114 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/circuitbreaker"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const openTimeout = time.Minute
+
+func TestBreakers(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	b := circuitbreaker.New(circuitbreaker.WithFailureThreshold(2), circuitbreaker.WithOpenTimeout(openTimeout),
+		circuitbreaker.WithClock(clk))
+	u := &url.URL{Scheme: "tcp", Host: "127.0.0.1:5000"}
+	unavailable := errors.Wrap(status.Error(codes.Unavailable, "unavailable"), "wrapped")
+
+	// Circuit is opened after the consecutive failures
+	require.NoError(t, b.Allow(u))
+	b.Report(u, unavailable)
+	require.Equal(t, circuitbreaker.Closed, b.State(u))
+	require.NoError(t, b.Allow(u))
+	b.Report(u, unavailable)
+	require.Equal(t, circuitbreaker.Open, b.State(u))
+
+	err := b.Allow(u)
+	require.Error(t, err)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	// Only one probe is allowed in half-open state
+	clk.Add(openTimeout - time.Millisecond)
+	require.Equal(t, circuitbreaker.Open, b.State(u))
+	clk.Add(time.Millisecond)
+	require.Equal(t, circuitbreaker.HalfOpen, b.State(u))
+	require.NoError(t, b.Allow(u))
+	require.Error(t, b.Allow(u))
+
+	// Failed probe opens the circuit again
+	b.Report(u, unavailable)
+	require.Equal(t, circuitbreaker.Open, b.State(u))
+	require.Error(t, b.Allow(u))
+
+	// Successful probe closes the circuit
+	clk.Add(openTimeout)
+	require.NoError(t, b.Allow(u))
+	b.Report(u, nil)
+	require.Equal(t, circuitbreaker.Closed, b.State(u))
+	require.NoError(t, b.Allow(u))
+}
+
+func TestBreakers_NotFailure(t *testing.T) {
+	b := circuitbreaker.New(circuitbreaker.WithFailureThreshold(1))
+	u := &url.URL{Scheme: "tcp", Host: "127.0.0.1:5000"}
+
+	// Server is reachable, so the circuit is not opened
+	b.Report(u, errors.New("request is not valid"))
+	b.Report(u, status.Error(codes.PermissionDenied, "permission denied"))
+	require.Equal(t, circuitbreaker.Closed, b.State(u))
+
+	// Circuits are independent
+	b.Report(&url.URL{Scheme: "tcp", Host: "127.0.0.1:5001"}, status.Error(codes.DeadlineExceeded, "timeout"))
+	require.Equal(t, circuitbreaker.Closed, b.State(u))
+	require.NoError(t, b.Allow(u))
+}
+
+func TestBreakers_Canceled(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	b := circuitbreaker.New(circuitbreaker.WithFailureThreshold(2), circuitbreaker.WithOpenTimeout(openTimeout),
+		circuitbreaker.WithClock(clk))
+	u := &url.URL{Scheme: "tcp", Host: "127.0.0.1:5000"}
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	// Canceled request doesn't reset the failures
+	b.Report(u, unavailable)
+	b.Report(u, status.Error(codes.Canceled, "canceled"))
+	b.Report(u, unavailable)
+	require.Equal(t, circuitbreaker.Open, b.State(u))
+
+	// Canceled probe keeps the circuit half-open and lets the next probe through
+	clk.Add(openTimeout)
+	require.NoError(t, b.Allow(u))
+	b.Report(u, errors.Wrap(context.Canceled, "wrapped"))
+	require.Equal(t, circuitbreaker.HalfOpen, b.State(u))
+	require.NoError(t, b.Allow(u))
+	require.Error(t, b.Allow(u))
+
+	b.Report(u, nil)
+	require.Equal(t, circuitbreaker.Closed, b.State(u))
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"context"
+	"net/url"
+)
+
+const breakerURLKey contextKeyType = "BreakerURL"
+
+type contextKeyType string
+
+// WithURL - wraps parent in a new Context with the URL the circuit breaker for the Request is keyed by. It should be
+//
+//	set by the endpoint selection, so the circuit is kept for the selected endpoint even if the Request is
+//	sent to it via another URL, e.g. via forwarder
+func WithURL(parent context.Context, u *url.URL) context.Context {
+	return context.WithValue(parent, breakerURLKey, u)
+}
+
+// URL - returns the URL set by WithURL, nil if it is not set
+func URL(ctx context.Context) *url.URL {
+	if rv, ok := ctx.Value(breakerURLKey).(*url.URL); ok {
+		return rv
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type configurable interface {
+	setFailureThreshold(int)
+	setOpenTimeout(time.Duration)
+	setClock(clock.Clock)
+}
+
+// Option is circuit breakers configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithFailureThreshold sets the number of consecutive failures opening the circuit, by default 5
+func WithFailureThreshold(failureThreshold int) Option {
+	return applierFunc(func(c configurable) {
+		c.setFailureThreshold(failureThreshold)
+	})
+}
+
+// WithOpenTimeout sets how long the circuit is kept open before letting a probe request through, by default 10 seconds
+func WithOpenTimeout(openTimeout time.Duration) Option {
+	return applierFunc(func(c configurable) {
+		c.setOpenTimeout(openTimeout)
+	})
+}
+
+// WithClock sets the clock used to check the open timeout, by default the real clock is used
+func WithClock(c clock.Clock) Option {
+	return applierFunc(func(cfg configurable) {
+		cfg.setClock(c)
+	})
+}
@@ -44,12 +44,12 @@ func AddClientInfo(ctx context.Context, labels map[string]string) {
 	for envName, labelName := range names {
 		value, exists := os.LookupEnv(envName)
 		if !exists {
-			trace.Log(ctx).Warningf("Environment variable %s is not set. Skipping.", envName)
+			trace.Log(ctx).Warnf("Environment variable %s is not set. Skipping.", envName)
 			continue
 		}
 		oldValue, isPresent := labels[labelName]
 		if isPresent {
-			trace.Log(ctx).Warningf("The label %s was already assigned to %s. Overwriting.", labelName, oldValue)
+			trace.Log(ctx).Warnf("The label %s was already assigned to %s. Overwriting.", labelName, oldValue)
 		}
 		labels[labelName] = value
 	}
@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clock provides the time API which can be replaced with the fake one to control the time in tests
+package clock
+
+import "time"
+
+// Clock - time API used by the time-driven chain elements
+type Clock interface {
+	// Now - returns the current time
+	Now() time.Time
+	// Since - returns the time elapsed since t
+	Since(t time.Time) time.Duration
+	// Until - returns the duration until t
+	Until(t time.Time) time.Duration
+	// After - waits for the duration to elapse and then sends the current time on the returned channel
+	After(d time.Duration) <-chan time.Time
+	// AfterFunc - waits for the duration to elapse and then calls f in its own goroutine
+	AfterFunc(d time.Duration, f func()) Timer
+	// NewTimer - creates a new Timer sending the current time on its channel after at least duration d
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer - time.Timer API
+type Timer interface {
+	// C - returns the channel the time is sent on when the Timer fires, it is nil for the AfterFunc timers
+	C() <-chan time.Time
+	// Stop - prevents the Timer from firing, returns false if the timer has already fired or been stopped
+	Stop() bool
+	// Reset - changes the Timer to fire after duration d, returns true if the timer had been active
+	Reset(d time.Duration) bool
+}
+
+type realClock struct{}
+
+// New - returns the Clock using the time package
+func New() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (realClock) Until(t time.Time) time.Duration {
+	return time.Until(t)
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return &realTimer{Timer: time.AfterFunc(d, f)}
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return &realTimer{Timer: time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t *realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake - Clock with the time changed only by Add and Set, the timers fire when their time comes
+type Fake struct {
+	now    time.Time
+	timers []*fakeTimer
+	mutex  sync.Mutex
+	cond   *sync.Cond
+}
+
+// NewFake - returns a new Fake clock with the current time set to now
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mutex)
+	return f
+}
+
+// Now - returns the current fake time
+func (f *Fake) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.now
+}
+
+// Since - returns the fake time elapsed since t
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// Until - returns the fake duration until t
+func (f *Fake) Until(t time.Time) time.Duration {
+	return t.Sub(f.Now())
+}
+
+// After - returns the channel receiving the fake time when it is advanced by d
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// AfterFunc - returns the Timer calling f in its own goroutine when the fake time is advanced by d
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	return f.newTimer(d, fn)
+}
+
+// NewTimer - returns the Timer firing when the fake time is advanced by d
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	return f.newTimer(d, nil)
+}
+
+// Add - advances the fake time by d and fires the expired timers in the order of their time
+func (f *Fake) Add(d time.Duration) {
+	f.mutex.Lock()
+	f.set(f.now.Add(d))
+}
+
+// Set - sets the fake time to now and fires the expired timers in the order of their time
+func (f *Fake) Set(now time.Time) {
+	f.mutex.Lock()
+	f.set(now)
+}
+
+// BlockUntil - blocks until there are at least n active timers, it is useful to wait for the timers created in the
+//              other goroutines before advancing the time
+func (f *Fake) BlockUntil(n int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for len(f.timers) < n {
+		f.cond.Wait()
+	}
+}
+
+// set - should be called under the locked mutex, unlocks it
+func (f *Fake) set(now time.Time) {
+	f.now = now
+
+	var expired []*fakeTimer
+	active := f.timers[:0]
+	for _, t := range f.timers {
+		if t.deadline.After(now) {
+			active = append(active, t)
+		} else {
+			expired = append(expired, t)
+		}
+	}
+	f.timers = active
+	f.mutex.Unlock()
+
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].deadline.Before(expired[j].deadline)
+	})
+	for _, t := range expired {
+		t.fire(now)
+	}
+}
+
+func (f *Fake) newTimer(d time.Duration, fn func()) *fakeTimer {
+	t := &fakeTimer{
+		clock: f,
+		fn:    fn,
+	}
+	if fn == nil {
+		t.c = make(chan time.Time, 1)
+	}
+	t.Reset(d)
+	return t
+}
+
+// add - should be called under the locked mutex
+func (f *Fake) add(t *fakeTimer) {
+	f.timers = append(f.timers, t)
+	f.cond.Broadcast()
+}
+
+// remove - should be called under the locked mutex, returns false if t is not active
+func (f *Fake) remove(t *fakeTimer) bool {
+	for i := range f.timers {
+		if f.timers[i] == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock    *Fake
+	deadline time.Time
+	c        chan time.Time
+	fn       func()
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	return t.clock.remove(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mutex.Lock()
+	active := t.clock.remove(t)
+	t.deadline = t.clock.now.Add(d)
+	if d > 0 {
+		t.clock.add(t)
+		t.clock.mutex.Unlock()
+		return active
+	}
+	now := t.clock.now
+	t.clock.mutex.Unlock()
+
+	t.fire(now)
+	return active
+}
+
+func (t *fakeTimer) fire(now time.Time) {
+	if t.fn != nil {
+		go t.fn()
+		return
+	}
+	select {
+	case t.c <- now:
+	default:
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+func TestFake_Timers(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clock.NewFake(start)
+
+	timer := c.NewTimer(time.Second)
+	after := c.After(2 * time.Second)
+	stopped := c.NewTimer(time.Second)
+	require.True(t, stopped.Stop())
+	require.False(t, stopped.Stop())
+
+	c.Add(time.Second - 1)
+	require.Len(t, timer.C(), 0)
+
+	c.Add(1)
+	require.Equal(t, start.Add(time.Second), <-timer.C())
+	require.Len(t, stopped.C(), 0)
+	require.False(t, timer.Stop())
+
+	require.False(t, timer.Reset(time.Second))
+	c.Set(start.Add(3 * time.Second))
+	require.Equal(t, start.Add(3*time.Second), <-after)
+	require.Equal(t, start.Add(3*time.Second), <-timer.C())
+	require.Equal(t, 3*time.Second, c.Since(start))
+	require.Equal(t, -3*time.Second, c.Until(start))
+}
+
+func TestFake_AfterFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	c := clock.NewFake(time.Now())
+
+	fired := make(chan struct{})
+	go c.AfterFunc(time.Minute, func() {
+		close(fired)
+	})
+
+	// Wait for the timer created in the other goroutine
+	c.BlockUntil(1)
+	c.Add(time.Minute)
+	<-fired
+}
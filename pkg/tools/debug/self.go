@@ -33,7 +33,8 @@ import (
 	"syscall"
 
 	"github.com/pkg/errors"
-	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 const (
@@ -107,7 +108,7 @@ func Self(envVariableParts ...string) error {
 		}
 	}
 	// Make the syscall.Exec
-	logrus.Infof("About to exec: \"%s %s\"", dlv, strings.Join(args[1:], " "))
+	log.Default().Infof("About to exec: \"%s %s\"", dlv, strings.Join(args[1:], " "))
 	// About to debug this not working at host rather than container level
 	return syscall.Exec(dlv, args, envv)
 }
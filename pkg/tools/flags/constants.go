@@ -78,4 +78,14 @@ const (
 	AuthzPolicyFileDefault = "/etc/nsm/authz.rego"
 	// AuthzPolicyFileUsageDefault - default authzPolicyFile usage
 	AuthzPolicyFileUsageDefault = "File containing OPA Policy"
+
+	// AdminListenOnURLKey - key for flag for AdminListenOnURL
+	AdminListenOnURLKey = "admin-listen-on-url"
+	// AdminListenOnURLUsageDefault - default usage for AdminListenOnURL
+	AdminListenOnURLUsageDefault = "URL to listen for the admin RPC calls, should be reachable only by the administrators (e.g. unix socket). Admin service is not served if not set"
+
+	// MetricsListenOnURLKey - key for flag for MetricsListenOnURL
+	MetricsListenOnURLKey = "metrics-listen-on-url"
+	// MetricsListenOnURLUsageDefault - default usage for MetricsListenOnURL
+	MetricsListenOnURLUsageDefault = "URL to serve the Prometheus metrics over HTTP on /metrics, the metrics are not collected if not set"
 )
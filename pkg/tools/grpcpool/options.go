@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcpool
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type configurable interface {
+	setDialOptions([]grpc.DialOption)
+	setIdleTimeout(time.Duration)
+	setEvictionGracePeriod(time.Duration)
+	setMaxConnections(int)
+	setClock(clock.Clock)
+}
+
+// Option is grpc connections pool configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithDialOptions sets grpc.DialOptions used to dial new connections. If grpc.WithBlock() is used, Acquire for the
+// URL blocks until the connection is established
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return applierFunc(func(c configurable) {
+		c.setDialOptions(dialOptions)
+	})
+}
+
+// WithIdleTimeout sets how long an unused connection is kept open, by default 1 minute
+func WithIdleTimeout(idleTimeout time.Duration) Option {
+	return applierFunc(func(c configurable) {
+		c.setIdleTimeout(idleTimeout)
+	})
+}
+
+// WithEvictionGracePeriod sets how long a connection in TRANSIENT_FAILURE is given to reconnect before it is evicted,
+// by default 15 seconds
+func WithEvictionGracePeriod(gracePeriod time.Duration) Option {
+	return applierFunc(func(c configurable) {
+		c.setEvictionGracePeriod(gracePeriod)
+	})
+}
+
+// WithMaxConnections sets the limit of open connections, by default there is no limit. When the limit is reached,
+// idle connections are closed to dial the new ones, if there are no idle connections Acquire fails
+func WithMaxConnections(maxConnections int) Option {
+	return applierFunc(func(c configurable) {
+		c.setMaxConnections(maxConnections)
+	})
+}
+
+// WithClock sets the clock used to expire the idle connections, by default the real clock is used
+func WithClock(c clock.Clock) Option {
+	return applierFunc(func(cfg configurable) {
+		cfg.setClock(c)
+	})
+}
@@ -0,0 +1,395 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpcpool provides a pool of grpc.ClientConns shared by the URL they are dialed to, with explicit lifecycle
+// management: connections are refcounted, closed after being idle for some time and evicted if they fail to recover
+// from TRANSIENT_FAILURE within the grace period
+package grpcpool
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+const (
+	defaultIdleTimeout         = time.Minute
+	defaultEvictionGracePeriod = 15 * time.Second
+)
+
+// Metrics - snapshot of the Pool metrics
+type Metrics struct {
+	// Open - number of open connections, including evicted connections still in use
+	Open int
+	// Idle - number of open connections not in use
+	Idle int
+	// Dials - total number of dialed connections
+	Dials uint64
+	// Evictions - total number of connections evicted after failing to recover from TRANSIENT_FAILURE
+	Evictions uint64
+	// Expirations - total number of connections closed after being idle for too long or to free space for new ones
+	Expirations uint64
+}
+
+// ConnInfo - snapshot of the pooled connection
+type ConnInfo struct {
+	// URL - URL the connection is dialed to
+	URL string
+	// State - connectivity state of the connection
+	State connectivity.State
+	// Refs - number of the connection users
+	Refs int
+	// IdleSince - time the connection has become idle, zero if it is in use
+	IdleSince time.Time
+}
+
+type entry struct {
+	key       string
+	cc        *grpc.ClientConn
+	ready     chan struct{}
+	dialErr   error
+	refs      int
+	idleTimer clock.Timer
+	idleSince time.Time
+	evicted   bool
+	closed    bool
+}
+
+// Pool - pool of grpc.ClientConns
+type Pool struct {
+	ctx            context.Context
+	dialOptions    []grpc.DialOption
+	idleTimeout    time.Duration
+	gracePeriod    time.Duration
+	maxConnections int
+	clock          clock.Clock
+
+	entries map[string]*entry
+	metrics Metrics
+	mutex   sync.Mutex
+}
+
+// New - creates a new Pool. All the connections are closed when ctx is done
+//       - ctx - context for the lifecycle of the Pool
+//       - options - configuration options
+func New(ctx context.Context, options ...Option) *Pool {
+	p := &Pool{
+		ctx:         ctx,
+		idleTimeout: defaultIdleTimeout,
+		gracePeriod: defaultEvictionGracePeriod,
+		clock:       clock.New(),
+		entries:     make(map[string]*entry),
+	}
+	for _, o := range options {
+		o.apply(p)
+	}
+	go func() {
+		<-ctx.Done()
+		p.closeAll()
+	}()
+	return p
+}
+
+func (p *Pool) setDialOptions(dialOptions []grpc.DialOption) {
+	p.dialOptions = dialOptions
+}
+
+func (p *Pool) setIdleTimeout(idleTimeout time.Duration) {
+	p.idleTimeout = idleTimeout
+}
+
+func (p *Pool) setEvictionGracePeriod(gracePeriod time.Duration) {
+	p.gracePeriod = gracePeriod
+}
+
+func (p *Pool) setMaxConnections(maxConnections int) {
+	p.maxConnections = maxConnections
+}
+
+func (p *Pool) setClock(c clock.Clock) {
+	p.clock = c
+}
+
+// Acquire - returns a connection to u, dials a new one if there is no healthy connection in the pool. Returned Conn
+//           should be released after use. New connection is dialed outside of the Pool lock, so concurrent Acquires
+//           for the same URL wait for it and the other Pool methods are not blocked by the dial.
+func (p *Pool) Acquire(u *url.URL) (*Conn, error) {
+	key := u.String()
+
+	p.mutex.Lock()
+
+	if err := p.ctx.Err(); err != nil {
+		p.mutex.Unlock()
+		return nil, errors.Wrap(err, "pool is closed")
+	}
+
+	e, ok := p.entries[key]
+	if !ok {
+		if p.maxConnections > 0 && p.metrics.Open >= p.maxConnections && !p.expireOldestIdle() {
+			p.mutex.Unlock()
+			return nil, status.Errorf(codes.ResourceExhausted, "too many open connections: %d", p.metrics.Open)
+		}
+		// Entry is a placeholder until the dial completes, so there is only one dial per URL
+		e = &entry{
+			key:   key,
+			ready: make(chan struct{}),
+			refs:  1,
+		}
+		p.entries[key] = e
+		p.metrics.Open++
+		p.mutex.Unlock()
+
+		return p.dial(u, e)
+	}
+
+	if e.refs == 0 && e.idleTimer != nil {
+		e.idleTimer.Stop()
+		e.idleTimer = nil
+	}
+	e.refs++
+	p.mutex.Unlock()
+
+	<-e.ready
+	if e.dialErr != nil {
+		p.mutex.Lock()
+		e.refs--
+		p.mutex.Unlock()
+		return nil, e.dialErr
+	}
+	if e.cc.GetState() == connectivity.TransientFailure {
+		// Connection is reconnecting within the grace period, new user shouldn't wait for the backoff
+		e.cc.ResetConnectBackoff()
+	}
+
+	return &Conn{
+		ClientConn: e.cc,
+		pool:       p,
+		entry:      e,
+	}, nil
+}
+
+// dial - dials the connection for the placeholder entry e acquired by the caller
+func (p *Pool) dial(u *url.URL, e *entry) (*Conn, error) {
+	cc, err := grpc.DialContext(p.ctx, grpcutils.URLToTarget(u), p.dialOptions...)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	defer close(e.ready)
+
+	if err == nil && e.closed {
+		// Pool has been closed while dialing
+		_ = cc.Close()
+		err = errors.Wrap(p.ctx.Err(), "pool is closed")
+	}
+	if err != nil {
+		e.dialErr = errors.Wrapf(err, "failed to dial %s", e.key)
+		e.refs--
+		p.close(e)
+		return nil, e.dialErr
+	}
+
+	e.cc = cc
+	p.metrics.Dials++
+	go p.watch(e)
+
+	return &Conn{
+		ClientConn: e.cc,
+		pool:       p,
+		entry:      e,
+	}, nil
+}
+
+// Metrics - returns the current Pool metrics
+func (p *Pool) Metrics() Metrics {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	rv := p.metrics
+	for _, e := range p.entries {
+		if e.refs == 0 {
+			rv.Idle++
+		}
+	}
+	return rv
+}
+
+// List - returns the snapshot of the pooled connections
+func (p *Pool) List() []ConnInfo {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	rv := make([]ConnInfo, 0, len(p.entries))
+	for _, e := range p.entries {
+		info := ConnInfo{
+			URL:   e.key,
+			State: connectivity.Connecting,
+			Refs:  e.refs,
+		}
+		if e.cc != nil {
+			info.State = e.cc.GetState()
+		}
+		if e.refs == 0 {
+			info.IdleSince = e.idleSince
+		}
+		rv = append(rv, info)
+	}
+	return rv
+}
+
+func (p *Pool) release(e *entry) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	e.refs--
+	if e.refs > 0 || e.closed {
+		return
+	}
+	if e.evicted || p.ctx.Err() != nil {
+		p.close(e)
+		return
+	}
+	e.idleSince = p.clock.Now()
+	e.idleTimer = p.clock.AfterFunc(p.idleTimeout, func() {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+
+		if e.refs == 0 && !e.closed {
+			p.metrics.Expirations++
+			p.close(e)
+		}
+	})
+}
+
+// watch - evicts the connection if it doesn't recover from TRANSIENT_FAILURE within the grace period, so the new
+// Acquires dial a new one. grpc.ClientConn reconnects with backoff, so a short failure doesn't cause an eviction
+func (p *Pool) watch(e *entry) {
+	for state := e.cc.GetState(); state != connectivity.Shutdown; state = e.cc.GetState() {
+		if state == connectivity.TransientFailure && !p.waitForReady(e.cc) {
+			if p.ctx.Err() == nil {
+				p.evict(e)
+			}
+			return
+		}
+		if !e.cc.WaitForStateChange(p.ctx, state) {
+			return
+		}
+	}
+}
+
+// waitForReady - returns true if cc becomes READY within the grace period
+func (p *Pool) waitForReady(cc *grpc.ClientConn) bool {
+	ctx, cancel := context.WithTimeout(p.ctx, p.gracePeriod)
+	defer cancel()
+	for state := cc.GetState(); state != connectivity.Ready; state = cc.GetState() {
+		if state == connectivity.Shutdown || !cc.WaitForStateChange(ctx, state) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Pool) evict(e *entry) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if e.evicted || e.closed {
+		return
+	}
+	e.evicted = true
+	p.metrics.Evictions++
+	if p.entries[e.key] == e {
+		delete(p.entries, e.key)
+	}
+	if e.refs == 0 {
+		p.close(e)
+	}
+}
+
+// expireOldestIdle - should be called under the lock
+func (p *Pool) expireOldestIdle() bool {
+	var oldest *entry
+	for _, e := range p.entries {
+		if e.refs == 0 && (oldest == nil || e.idleSince.Before(oldest.idleSince)) {
+			oldest = e
+		}
+	}
+	if oldest == nil {
+		return false
+	}
+	p.metrics.Expirations++
+	p.close(oldest)
+	return true
+}
+
+// close - should be called under the lock
+func (p *Pool) close(e *entry) {
+	if e.closed {
+		return
+	}
+	e.closed = true
+	if e.idleTimer != nil {
+		e.idleTimer.Stop()
+	}
+	if p.entries[e.key] == e {
+		delete(p.entries, e.key)
+	}
+	p.metrics.Open--
+	if e.cc != nil {
+		_ = e.cc.Close()
+	}
+}
+
+func (p *Pool) closeAll() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, e := range p.entries {
+		p.close(e)
+	}
+}
+
+// Conn - grpc.ClientConn acquired from the Pool
+type Conn struct {
+	*grpc.ClientConn
+	pool        *Pool
+	entry       *entry
+	releaseOnce sync.Once
+}
+
+// Evicted - returns true if the connection has been evicted from the Pool, new users should acquire a new one
+func (c *Conn) Evicted() bool {
+	c.pool.mutex.Lock()
+	defer c.pool.mutex.Unlock()
+
+	return c.entry.evicted
+}
+
+// Release - returns the connection to the Pool, it should not be used after that
+func (c *Conn) Release() {
+	c.releaseOnce.Do(func() {
+		c.pool.release(c.entry)
+	})
+}
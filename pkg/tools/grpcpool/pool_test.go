@@ -0,0 +1,259 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcpool_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcpool"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+func serve(ctx context.Context, t *testing.T) *url.URL {
+	s := grpc.NewServer()
+	go func() {
+		<-ctx.Done()
+		s.Stop()
+	}()
+	u := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	require.NotNil(t, grpcutils.ListenAndServe(ctx, u, s))
+	return u
+}
+
+func waitMetrics(t *testing.T, pool *grpcpool.Pool, check func(metrics grpcpool.Metrics) bool) {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if check(pool.Metrics()) {
+			return
+		}
+	}
+	require.FailNow(t, "unexpected metrics", "%+v", pool.Metrics())
+}
+
+func TestPool_IdleTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	u := serve(ctx, t)
+	clk := clock.NewFake(time.Now())
+	pool := grpcpool.New(ctx, grpcpool.WithDialOptions(grpc.WithInsecure()), grpcpool.WithIdleTimeout(time.Minute),
+		grpcpool.WithClock(clk))
+
+	cc1, err := pool.Acquire(u)
+	require.NoError(t, err)
+	cc2, err := pool.Acquire(u)
+	require.NoError(t, err)
+	require.Same(t, cc1.ClientConn, cc2.ClientConn)
+	require.Equal(t, grpcpool.Metrics{Open: 1, Dials: 1}, pool.Metrics())
+
+	cc1.Release()
+	cc1.Release()
+	require.Equal(t, grpcpool.Metrics{Open: 1, Dials: 1}, pool.Metrics())
+
+	cc2.Release()
+	require.Equal(t, grpcpool.Metrics{Open: 1, Idle: 1, Dials: 1}, pool.Metrics())
+	require.Equal(t, clk.Now(), pool.List()[0].IdleSince)
+
+	clk.Add(time.Minute - time.Millisecond)
+	require.Equal(t, grpcpool.Metrics{Open: 1, Idle: 1, Dials: 1}, pool.Metrics())
+
+	clk.Add(time.Millisecond)
+	waitMetrics(t, pool, func(metrics grpcpool.Metrics) bool {
+		return metrics == grpcpool.Metrics{Dials: 1, Expirations: 1}
+	})
+}
+
+func TestPool_MaxConnections(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	u1, u2 := serve(ctx, t), serve(ctx, t)
+	pool := grpcpool.New(ctx, grpcpool.WithDialOptions(grpc.WithInsecure()), grpcpool.WithMaxConnections(1))
+
+	cc1, err := pool.Acquire(u1)
+	require.NoError(t, err)
+
+	_, err = pool.Acquire(u2)
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Idle connection is closed to free space for the new one
+	cc1.Release()
+	cc2, err := pool.Acquire(u2)
+	require.NoError(t, err)
+	require.Equal(t, grpcpool.Metrics{Open: 1, Dials: 2, Expirations: 1}, pool.Metrics())
+
+	cc2.Release()
+}
+
+func TestPool_TransientFailure(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	u := grpcutils.AddressToURL(l.Addr())
+	require.NoError(t, l.Close())
+
+	pool := grpcpool.New(ctx, grpcpool.WithDialOptions(grpc.WithInsecure()), grpcpool.WithEvictionGracePeriod(100*time.Millisecond))
+
+	cc1, err := pool.Acquire(u)
+	require.NoError(t, err)
+
+	// Failed connection is evicted, but kept open while in use
+	waitMetrics(t, pool, func(metrics grpcpool.Metrics) bool {
+		return metrics.Evictions == 1
+	})
+	require.Equal(t, 1, pool.Metrics().Open)
+
+	cc2, err := pool.Acquire(u)
+	require.NoError(t, err)
+	require.NotSame(t, cc1.ClientConn, cc2.ClientConn)
+
+	cc1.Release()
+	cc2.Release()
+	cancel()
+
+	waitMetrics(t, pool, func(metrics grpcpool.Metrics) bool {
+		return metrics.Open == 0
+	})
+}
+
+func TestPool_TransientFailureRecovery(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	u := grpcutils.AddressToURL(l.Addr())
+	s := grpc.NewServer()
+	go func() { _ = s.Serve(l) }()
+
+	pool := grpcpool.New(ctx, grpcpool.WithDialOptions(
+		grpc.WithInsecure(),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  10 * time.Millisecond,
+				Multiplier: 1,
+				MaxDelay:   10 * time.Millisecond,
+			},
+		}),
+	))
+
+	cc, err := pool.Acquire(u)
+	require.NoError(t, err)
+	defer cc.Release()
+	require.True(t, cc.WaitForStateChange(ctx, connectivity.Idle))
+	for state := cc.GetState(); state != connectivity.Ready; state = cc.GetState() {
+		require.True(t, cc.WaitForStateChange(ctx, state))
+	}
+
+	// Server restarts, so the connection fails and reconnects within the grace period
+	s.Stop()
+	for state := cc.GetState(); state != connectivity.TransientFailure; state = cc.GetState() {
+		require.True(t, cc.WaitForStateChange(ctx, state))
+	}
+	l, err = net.Listen("tcp", u.Host)
+	require.NoError(t, err)
+	s = grpc.NewServer()
+	defer s.Stop()
+	go func() { _ = s.Serve(l) }()
+
+	for state := cc.GetState(); state != connectivity.Ready; state = cc.GetState() {
+		require.True(t, cc.WaitForStateChange(ctx, state))
+	}
+	require.False(t, cc.Evicted())
+	require.Equal(t, uint64(0), pool.Metrics().Evictions)
+}
+
+func TestPool_BlockingDial(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadURL := grpcutils.AddressToURL(l.Addr())
+	require.NoError(t, l.Close())
+
+	u := serve(ctx, t)
+	pool := grpcpool.New(ctx, grpcpool.WithDialOptions(grpc.WithInsecure(), grpc.WithBlock()))
+
+	acquireErr := make(chan error, 1)
+	go func() {
+		_, err := pool.Acquire(deadURL)
+		acquireErr <- err
+	}()
+	waitMetrics(t, pool, func(metrics grpcpool.Metrics) bool {
+		return metrics.Open == 1
+	})
+
+	// Dial to the dead URL doesn't block the other URLs
+	cc, err := pool.Acquire(u)
+	require.NoError(t, err)
+	require.Len(t, pool.List(), 2)
+	cc.Release()
+	require.Empty(t, acquireErr)
+
+	cancel()
+	require.Error(t, <-acquireErr)
+	waitMetrics(t, pool, func(metrics grpcpool.Metrics) bool {
+		return metrics.Open == 0
+	})
+}
+
+func TestPool_Closed(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	u := serve(ctx, t)
+	pool := grpcpool.New(ctx, grpcpool.WithDialOptions(grpc.WithInsecure()))
+
+	cc, err := pool.Acquire(u)
+	require.NoError(t, err)
+	cc.Release()
+
+	cancel()
+	waitMetrics(t, pool, func(metrics grpcpool.Metrics) bool {
+		return metrics.Open == 0
+	})
+
+	_, err = pool.Acquire(u)
+	require.Error(t, err)
+}
@@ -16,7 +16,10 @@
 
 package interdomain
 
-import "strings"
+import (
+	"net/url"
+	"strings"
+)
 
 const identifier = "@"
 
@@ -44,3 +47,33 @@ func Target(s string) string {
 	pieces := strings.SplitN(s, identifier, 2)
 	return pieces[0]
 }
+
+// IsAny returns true if any of passed strings can be represented as interdomain URL
+func IsAny(s ...string) bool {
+	for _, item := range s {
+		if Is(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// FirstDomain returns domain name from the first interdomain query of passed ones
+func FirstDomain(s ...string) string {
+	for _, item := range s {
+		if Is(item) {
+			return Domain(item)
+		}
+	}
+	return ""
+}
+
+// URL returns domain from interdomain query parsed as URL, e.g. for "nse@tcp://1.1.1.1:5000" it returns
+// "tcp://1.1.1.1:5000". If domain is not a valid URL, returns nil.
+func URL(s string) *url.URL {
+	u, err := url.Parse(Domain(s))
+	if err != nil || u.Scheme == "" || (u.Host == "" && u.Path == "") {
+		return nil
+	}
+	return u
+}
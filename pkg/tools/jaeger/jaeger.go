@@ -24,9 +24,10 @@ import (
 	"strconv"
 
 	"github.com/opentracing/opentracing-go"
-	"github.com/sirupsen/logrus"
 	"github.com/uber/jaeger-client-go"
 	"github.com/uber/jaeger-client-go/config"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 const (
@@ -66,7 +67,7 @@ func InitJaeger(service string) io.Closer {
 		return &emptyCloser{}
 	}
 	if opentracing.IsGlobalTracerRegistered() {
-		logrus.Warningf("global opentracer is already initialized")
+		log.Default().Warnf("global opentracer is already initialized")
 	}
 	cfg, err := config.FromEnv()
 	if err != nil {
@@ -92,10 +93,10 @@ func InitJaeger(service string) io.Closer {
 		cfg.Reporter.LogSpans = true
 	}
 
-	logrus.Infof("Creating logger from config: %v", cfg)
+	log.Default().Infof("Creating logger from config: %v", cfg)
 	tracer, closer, err := cfg.NewTracer(config.Logger(jaeger.StdLogger))
 	if err != nil {
-		logrus.Errorf("ERROR: cannot init Jaeger: %v\n", err)
+		log.Default().Errorf("ERROR: cannot init Jaeger: %v", err)
 		return &emptyCloser{}
 	}
 	opentracing.SetGlobalTracer(tracer)
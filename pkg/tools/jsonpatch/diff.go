@@ -0,0 +1,136 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonpatch provides a function creating the JSON patch (RFC 6902) between two JSON documents
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Operation - JSON patch operation
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON - marshals the operation, value is omitted only for remove: null is a valid value for add and replace
+func (o Operation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+	type operation Operation
+	return json.Marshal(operation(o))
+}
+
+// Diff - returns the JSON patch transforming from into to. Arrays are compared element by element, so an element
+//        inserted in the middle of an array results in the replace operations for all the following elements.
+func Diff(from, to []byte) ([]Operation, error) {
+	fromValue, err := decode(from)
+	if err != nil {
+		return nil, err
+	}
+	toValue, err := decode(to)
+	if err != nil {
+		return nil, err
+	}
+	return diff(nil, "", fromValue, toValue), nil
+}
+
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var rv interface{}
+	if err := decoder.Decode(&rv); err != nil {
+		return nil, errors.Wrap(err, "failed to decode JSON document")
+	}
+	return rv, nil
+}
+
+func diff(ops []Operation, path string, from, to interface{}) []Operation {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		if toValue, ok := to.(map[string]interface{}); ok {
+			return diffObjects(ops, path, fromValue, toValue)
+		}
+	case []interface{}:
+		if toValue, ok := to.([]interface{}); ok {
+			return diffArrays(ops, path, fromValue, toValue)
+		}
+	}
+	if !reflect.DeepEqual(from, to) {
+		ops = append(ops, Operation{Op: "replace", Path: path, Value: to})
+	}
+	return ops
+}
+
+func diffObjects(ops []Operation, path string, from, to map[string]interface{}) []Operation {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := path + "/" + escape(key)
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+		switch {
+		case !inTo:
+			ops = append(ops, Operation{Op: "remove", Path: keyPath})
+		case !inFrom:
+			ops = append(ops, Operation{Op: "add", Path: keyPath, Value: toValue})
+		default:
+			ops = diff(ops, keyPath, fromValue, toValue)
+		}
+	}
+	return ops
+}
+
+func diffArrays(ops []Operation, path string, from, to []interface{}) []Operation {
+	for i := 0; i < len(from) && i < len(to); i++ {
+		ops = diff(ops, path+"/"+strconv.Itoa(i), from[i], to[i])
+	}
+	for i := len(from); i < len(to); i++ {
+		ops = append(ops, Operation{Op: "add", Path: path + "/" + strconv.Itoa(i), Value: to[i]})
+	}
+	// Remove from the end, so the indexes of the remaining elements are not shifted
+	for i := len(from) - 1; i >= len(to); i-- {
+		ops = append(ops, Operation{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+	}
+	return ops
+}
+
+// escape - escapes JSON pointer (RFC 6901) reference token
+func escape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/jsonpatch"
+)
+
+func TestDiff(t *testing.T) {
+	from := `{"id":"1","labels":{"a":"1","b/c":"2"},"segments":[{"name":"nsc"},{"name":"nsmgr"}],"n":1}`
+	to := `{"id":"1","labels":{"a":"3","d~":"4"},"segments":[{"name":"nsc","token":"t"}],"n":1.5}`
+
+	ops, err := jsonpatch.Diff([]byte(from), []byte(to))
+	require.NoError(t, err)
+
+	data, err := json.Marshal(ops)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"op":"replace","path":"/labels/a","value":"3"},
+		{"op":"remove","path":"/labels/b~1c"},
+		{"op":"add","path":"/labels/d~0","value":"4"},
+		{"op":"replace","path":"/n","value":1.5},
+		{"op":"add","path":"/segments/0/token","value":"t"},
+		{"op":"remove","path":"/segments/1"}
+	]`, string(data))
+}
+
+func TestDiff_Equal(t *testing.T) {
+	doc := []byte(`{"a":[1,2,{"b":null}],"c":"d"}`)
+	ops, err := jsonpatch.Diff(doc, doc)
+	require.NoError(t, err)
+	require.Empty(t, ops)
+}
+
+func TestDiff_Null(t *testing.T) {
+	from := `{"a":1,"b":[1]}`
+	to := `{"a":null,"b":[1,null],"c":null}`
+
+	ops, err := jsonpatch.Diff([]byte(from), []byte(to))
+	require.NoError(t, err)
+
+	data, err := json.Marshal(ops)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"op":"replace","path":"/a","value":null},
+		{"op":"add","path":"/b/1","value":null},
+		{"op":"add","path":"/c","value":null}
+	]`, string(data))
+}
+
+func TestDiff_Root(t *testing.T) {
+	ops, err := jsonpatch.Diff([]byte(`{"a":1}`), []byte(`[1]`))
+	require.NoError(t, err)
+	require.Equal(t, []jsonpatch.Operation{{Op: "replace", Path: "", Value: []interface{}{json.Number("1")}}}, ops)
+
+	_, err = jsonpatch.Diff([]byte(`{`), []byte(`{}`))
+	require.Error(t, err)
+}
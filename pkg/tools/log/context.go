@@ -14,8 +14,9 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package log provides functions for having a *logrus.Entry per Context
-// This allows the for context associative logging
+// Package log provides a leveled structured Logger carried in the Context, with the adapters for logrus and zap.
+// This allows context associative logging: log output can be redirected or leveled per component by passing
+// the Context with its own Logger.
 package log
 
 import (
@@ -28,38 +29,47 @@ import (
 type contextKeyType string
 
 const (
-	logrusEntry contextKeyType = "LogrusEntry"
+	loggerKey contextKeyType = "Logger"
 )
 
-// WithFields - return new context with fields added to Context's logrus.Entry
-func WithFields(parent context.Context, fields logrus.Fields) context.Context {
-	entry := Entry(parent)
-	entry = entry.WithFields(fields)
-	ctx := context.WithValue(parent, logrusEntry, entry)
-	entry.Context = ctx
-	return ctx
+// WithLog - return new context with logger
+func WithLog(parent context.Context, logger Logger) context.Context {
+	if parent == nil {
+		parent = context.TODO()
+	}
+	return context.WithValue(parent, loggerKey, logger)
+}
+
+// FromContext - returns Logger from context, or Default() if context has no Logger
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if rv, ok := ctx.Value(loggerKey).(Logger); ok {
+			return rv
+		}
+	}
+	return Default()
+}
+
+// WithFields - return new context with fields added to Context's Logger
+func WithFields(parent context.Context, fields map[string]interface{}) context.Context {
+	return WithLog(parent, FromContext(parent).WithFields(fields))
 }
 
-// WithField - return new context with {key:value} added to Context's logrus.Entry
+// WithField - return new context with {key:value} added to Context's Logger
 func WithField(parent context.Context, key string, value interface{}) context.Context {
-	return WithFields(parent, logrus.Fields{
-		key: value,
-	})
+	return WithLog(parent, FromContext(parent).WithField(key, value))
 }
 
-// Entry - returns *logrus.Entry for context.  Note: each context has its *own* Entry with Entry.Context set
-//         to that context (so context values can be used in logrus.Hooks)
+// Entry - returns *logrus.Entry for context, should be used only for the logrus specific things (e.g. Entry.Writer()).
+//         If Context's Logger is not a logrus Logger, returns standard logrus Entry without the Logger fields.
+//         Note: each context has its *own* Entry with Entry.Context set to that context (so context values can be used
+//         in logrus.Hooks)
 func Entry(ctx context.Context) *logrus.Entry {
 	if ctx == nil {
 		ctx = context.TODO()
 	}
-	if entryValue := ctx.Value(logrusEntry); entryValue != nil {
-		if entry := entryValue.(*logrus.Entry); entry != nil {
-			if entry.Context == ctx {
-				return entry
-			}
-			return entry.WithContext(ctx)
-		}
+	if l, ok := FromContext(ctx).(*logrusLogger); ok {
+		return l.entry().WithContext(ctx)
 	}
-	return logrus.WithTime(time.Now())
+	return logrus.WithTime(time.Now()).WithContext(ctx)
 }
@@ -0,0 +1,58 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Field names attached to the Logger by the trace chain elements
+const (
+	ConnectionIDField   = "connection_id"
+	NetworkServiceField = "network_service"
+	ChainElementField   = "chain_element"
+)
+
+// Logger - leveled structured logger
+type Logger interface {
+	Debugf(format string, v ...interface{})
+	Infof(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Errorf(format string, v ...interface{})
+	// WithField - returns Logger with {key:value} added to the fields
+	WithField(key string, value interface{}) Logger
+	// WithFields - returns Logger with fields added
+	WithFields(fields map[string]interface{}) Logger
+}
+
+var defaultLogger atomic.Value
+
+func init() {
+	defaultLogger.Store(NewLogrus(logrus.StandardLogger()))
+}
+
+// Default - returns the Logger used if Context has no Logger, by default it is logrus.StandardLogger
+func Default() Logger {
+	return defaultLogger.Load().(Logger)
+}
+
+// SetDefault - sets the Logger used if Context has no Logger
+func SetDefault(logger Logger) {
+	defaultLogger.Store(logger)
+}
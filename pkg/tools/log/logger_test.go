@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+func TestFromContext_Default(t *testing.T) {
+	require.Equal(t, log.Default(), log.FromContext(context.Background()))
+}
+
+func TestLogrus_Fields(t *testing.T) {
+	buff := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.SetOutput(buff)
+	logger.SetLevel(logrus.InfoLevel)
+
+	ctx := log.WithLog(context.Background(), log.NewLogrus(logger))
+	ctx = log.WithField(ctx, "a", "1")
+	ctx = log.WithFields(ctx, map[string]interface{}{"b": "2"})
+
+	log.FromContext(ctx).Debugf("hidden")
+	log.FromContext(ctx).Infof("shown")
+
+	require.NotContains(t, buff.String(), "hidden")
+	require.Contains(t, buff.String(), "shown")
+	require.Contains(t, buff.String(), "a=1")
+	require.Contains(t, buff.String(), "b=2")
+
+	buff.Reset()
+	log.Entry(ctx).Info("entry")
+	require.Contains(t, buff.String(), "a=1")
+}
+
+func TestZap_Fields(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+
+	ctx := log.WithLog(context.Background(), log.NewZap(zap.New(core)))
+	ctx = log.WithFields(ctx, map[string]interface{}{"a": "1", "b": "2"})
+
+	log.FromContext(ctx).Infof("hidden")
+	log.FromContext(ctx).Warnf("shown")
+
+	require.Equal(t, 0, logs.FilterMessage("hidden").Len())
+	entries := logs.FilterMessage("shown").All()
+	require.Len(t, entries, 1)
+	require.Equal(t, map[string]interface{}{"a": "1", "b": "2"}, entries[0].ContextMap())
+}
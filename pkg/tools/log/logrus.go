@@ -0,0 +1,64 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+type logrusLogger struct {
+	logger logrus.FieldLogger
+}
+
+// NewLogrus - returns Logger writing to the logrus logger (*logrus.Logger, *logrus.Entry)
+func NewLogrus(logger logrus.FieldLogger) Logger {
+	return &logrusLogger{logger: logger}
+}
+
+func (l *logrusLogger) Debugf(format string, v ...interface{}) {
+	l.logger.Debugf(format, v...)
+}
+
+func (l *logrusLogger) Infof(format string, v ...interface{}) {
+	l.logger.Infof(format, v...)
+}
+
+func (l *logrusLogger) Warnf(format string, v ...interface{}) {
+	l.logger.Warnf(format, v...)
+}
+
+func (l *logrusLogger) Errorf(format string, v ...interface{}) {
+	l.logger.Errorf(format, v...)
+}
+
+func (l *logrusLogger) WithField(key string, value interface{}) Logger {
+	return &logrusLogger{logger: l.logger.WithField(key, value)}
+}
+
+func (l *logrusLogger) WithFields(fields map[string]interface{}) Logger {
+	return &logrusLogger{logger: l.logger.WithFields(fields)}
+}
+
+func (l *logrusLogger) entry() *logrus.Entry {
+	switch logger := l.logger.(type) {
+	case *logrus.Entry:
+		return logger
+	case *logrus.Logger:
+		return logrus.NewEntry(logger)
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
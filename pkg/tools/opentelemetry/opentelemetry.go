@@ -0,0 +1,103 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package opentelemetry provides a set of utilities for assisting with using OpenTelemetry tracing. When it is
+// initialized with Init, spanhelper (and so the trace chain elements) creates OpenTelemetry spans instead of the
+// opentracing (Jaeger) ones.
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	instrumentationName = "github.com/networkservicemesh/sdk"
+	shutdownTimeout     = 5 * time.Second
+)
+
+var enabled int32
+
+// IsOpenTelemetryEnabled returns true if OpenTelemetry is initialized with Init
+func IsOpenTelemetryEnabled() bool {
+	return atomic.LoadInt32(&enabled) != 0
+}
+
+// Tracer - returns OpenTelemetry tracer for the spans
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+type config struct {
+	providerOptions []sdktrace.TracerProviderOption
+}
+
+func (c *config) addProviderOption(option sdktrace.TracerProviderOption) {
+	c.providerOptions = append(c.providerOptions, option)
+}
+
+type providerCloser struct {
+	provider *sdktrace.TracerProvider
+}
+
+func (c *providerCloser) Close() error {
+	atomic.StoreInt32(&enabled, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return c.provider.Shutdown(ctx)
+}
+
+// Init - initializes global OpenTelemetry tracer provider sampling all the spans (unless WithSampler is passed) and
+//        W3C trace context propagation. Returned io.Closer flushes the spans and disables OpenTelemetry.
+//        - service - service name, hostname is appended to it
+//        - options - configuration options
+func Init(service string, options ...Option) io.Closer {
+	if IsOpenTelemetryEnabled() {
+		log.Default().Warnf("OpenTelemetry is already initialized")
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		service = fmt.Sprintf("%s@%s", service, hostname)
+	}
+	c := &config{
+		providerOptions: []sdktrace.TracerProviderOption{
+			sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceNameKey.String(service))),
+		},
+	}
+	for _, o := range options {
+		o.apply(c)
+	}
+
+	provider := sdktrace.NewTracerProvider(c.providerOptions...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	atomic.StoreInt32(&enabled, 1)
+
+	return &providerCloser{provider: provider}
+}
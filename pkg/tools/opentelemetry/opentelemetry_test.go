@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetry_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
+	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
+)
+
+func spanByKind(t *testing.T, spans tracetest.SpanStubs, kind trace.SpanKind) tracetest.SpanStub {
+	for i := range spans {
+		if spans[i].SpanKind == kind {
+			return spans[i]
+		}
+	}
+	require.Failf(t, "span not found", "%s", kind)
+	return tracetest.SpanStub{}
+}
+
+func TestGRPCPropagation(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	closer := opentelemetry.Init("test", opentelemetry.WithSyncSpanExporter(exporter))
+	defer func() { _ = closer.Close() }()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(spanhelper.WithTracing()...)
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(l)
+	}()
+	defer server.Stop()
+
+	cc, err := grpc.Dial(l.Addr().String(), append(spanhelper.WithTracingDial(), grpc.WithInsecure())...)
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	ctx, span := opentelemetry.Tracer().Start(context.Background(), "test")
+	_, err = grpc_health_v1.NewHealthClient(cc).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	clientSpan := spanByKind(t, spans, trace.SpanKindClient)
+	require.Equal(t, "/grpc.health.v1.Health/Check", clientSpan.Name)
+	require.Equal(t, span.SpanContext().SpanID(), clientSpan.Parent.SpanID())
+
+	serverSpan := spanByKind(t, spans, trace.SpanKindServer)
+	require.Equal(t, span.SpanContext().TraceID(), serverSpan.SpanContext.TraceID())
+	require.Equal(t, clientSpan.SpanContext.SpanID(), serverSpan.Parent.SpanID())
+	require.True(t, serverSpan.Parent.IsRemote())
+}
+
+type testServer struct{}
+
+func (s *testServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *testServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func TestTraceChain(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	closer := opentelemetry.Init("test", opentelemetry.WithSyncSpanExporter(exporter))
+
+	server := chain.NewNetworkServiceServer(&testServer{}, &testServer{})
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	// Inner span ends first
+	require.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	require.Equal(t, spans[1].SpanContext.TraceID(), spans[0].SpanContext.TraceID())
+	require.Contains(t, spans[1].Name, "testServer.Request")
+	require.Equal(t, "request", spans[1].Events[0].Name)
+
+	require.NoError(t, closer.Close())
+	require.False(t, opentelemetry.IsOpenTelemetryEnabled())
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetry
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type configurable interface {
+	addProviderOption(sdktrace.TracerProviderOption)
+}
+
+// Option is OpenTelemetry configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithSpanExporter sets the exporter the spans are exported to in batches, e.g. OTLP exporter
+func WithSpanExporter(exporter sdktrace.SpanExporter) Option {
+	return applierFunc(func(c configurable) {
+		c.addProviderOption(sdktrace.WithBatcher(exporter))
+	})
+}
+
+// WithSyncSpanExporter sets the exporter the spans are exported to synchronously when they are finished, e.g.
+// tracetest.NewInMemoryExporter() for the tests
+func WithSyncSpanExporter(exporter sdktrace.SpanExporter) Option {
+	return applierFunc(func(c configurable) {
+		c.addProviderOption(sdktrace.WithSyncer(exporter))
+	})
+}
+
+// WithSampler sets the spans sampler, by default all the spans are sampled
+func WithSampler(sampler sdktrace.Sampler) Option {
+	return applierFunc(func(c configurable) {
+		c.addProviderOption(sdktrace.WithSampler(sampler))
+	})
+}
@@ -24,9 +24,10 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
-	"github.com/sirupsen/logrus"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 // PrefixPool is a structure that contains information about prefixes
@@ -144,7 +145,7 @@ func (impl *PrefixPool) ExcludePrefixes(excludedPrefixes []string) (removedPrefi
 	/* Raise an error, if there aren't any available prefixes left after excluding */
 	if len(copyPrefixes) == 0 {
 		err := errors.New("IPAM: The available address pool is empty, probably intersected by excludedPrefix")
-		logrus.Errorf("%v", err)
+		log.Default().Errorf("%v", err)
 		return nil, err
 	}
 	/* Everything should be fine, update the available prefixes with what's left */
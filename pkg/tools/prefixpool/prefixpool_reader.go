@@ -18,8 +18,9 @@ package prefixpool
 
 import (
 	"github.com/fsnotify/fsnotify"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 /* These variables set default path to the config file */
@@ -56,16 +57,16 @@ func NewPrefixPoolReader(path string) *Reader {
 	_ = ph.prefixesConfig.ReadInConfig()
 
 	readPrefixes := func() {
-		logrus.Infof("Reading excluded prefixes config file: %s", ph.configPath)
+		log.Default().Infof("Reading excluded prefixes config file: %s", ph.configPath)
 		prefixes := ph.prefixesConfig.GetStringSlice("prefixes")
-		logrus.Infof("Excluded prefixes: %v", prefixes)
+		log.Default().Infof("Excluded prefixes: %v", prefixes)
 		ph.mutex.Lock()
 		defer ph.mutex.Unlock()
 		ph.init(prefixes)
 	}
 
 	ph.prefixesConfig.OnConfigChange(func(fsnotify.Event) {
-		logrus.Info("Excluded prefixes config file changed")
+		log.Default().Infof("Excluded prefixes config file changed")
 		readPrefixes()
 	})
 	ph.prefixesConfig.WatchConfig()
@@ -0,0 +1,69 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type redactLogger struct {
+	logger log.Logger
+}
+
+// Logger - returns log.Logger formatting the arguments and the fields of logger redacted with Default().Value
+func Logger(logger log.Logger) log.Logger {
+	if _, ok := logger.(*redactLogger); ok {
+		return logger
+	}
+	return &redactLogger{logger: logger}
+}
+
+func (l *redactLogger) Debugf(format string, v ...interface{}) {
+	l.logger.Debugf(format, values(v)...)
+}
+
+func (l *redactLogger) Infof(format string, v ...interface{}) {
+	l.logger.Infof(format, values(v)...)
+}
+
+func (l *redactLogger) Warnf(format string, v ...interface{}) {
+	l.logger.Warnf(format, values(v)...)
+}
+
+func (l *redactLogger) Errorf(format string, v ...interface{}) {
+	l.logger.Errorf(format, values(v)...)
+}
+
+func (l *redactLogger) WithField(key string, value interface{}) log.Logger {
+	return &redactLogger{logger: l.logger.WithField(key, Default().Value(value))}
+}
+
+func (l *redactLogger) WithFields(fields map[string]interface{}) log.Logger {
+	redacted := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		redacted[key] = Default().Value(value)
+	}
+	return &redactLogger{logger: l.logger.WithFields(redacted)}
+}
+
+func values(v []interface{}) []interface{} {
+	rv := make([]interface{}, len(v))
+	for i := range v {
+		rv[i] = Default().Value(v[i])
+	}
+	return rv
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import "regexp"
+
+type configurable interface {
+	addPaths(paths ...string)
+	addKeys(keys ...*regexp.Regexp)
+	addValues(values ...*regexp.Regexp)
+	clear()
+}
+
+// Option is Redactor configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithPaths adds JSON field paths to redact. Path is a dot separated list of the JSON field names starting from the
+// document root, arrays are transparent, "*" matches any single field name:
+//     "connection.path.path_segments.token", "*.mechanism.parameters.psk"
+func WithPaths(paths ...string) Option {
+	return applierFunc(func(c configurable) {
+		c.addPaths(paths...)
+	})
+}
+
+// WithKeys adds regular expressions matching the names of the JSON fields (including map keys) to redact anywhere in
+// the document
+func WithKeys(keys ...*regexp.Regexp) Option {
+	return applierFunc(func(c configurable) {
+		c.addKeys(keys...)
+	})
+}
+
+// WithValues adds regular expressions matching the JSON string values to redact anywhere in the document
+func WithValues(values ...*regexp.Regexp) Option {
+	return applierFunc(func(c configurable) {
+		c.addValues(values...)
+	})
+}
+
+// WithoutDefaultRules drops the default rules (DefaultKeys, DefaultValues), should be passed before the other options
+func WithoutDefaultRules() Option {
+	return applierFunc(func(c configurable) {
+		c.clear()
+	})
+}
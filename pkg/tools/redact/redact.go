@@ -0,0 +1,222 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact provides a Redactor removing the secrets (tokens, private keys, etc.) from the JSON documents before
+// they are logged or sent to the tracing backend
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// Placeholder - replaces the redacted values
+const Placeholder = "[REDACTED]"
+
+// DefaultKeys - JSON field names redacted by default: path segment tokens, private keys, secrets and passwords
+func DefaultKeys() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^token$`),
+		regexp.MustCompile(`(?i)private_?key`),
+		regexp.MustCompile(`(?i)secret`),
+		regexp.MustCompile(`(?i)passw(or)?d`),
+	}
+}
+
+// DefaultValues - JSON string values redacted by default: WireGuard-style keys (base64 encoded 32 bytes)
+func DefaultValues() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		regexp.MustCompile(`^[A-Za-z0-9+/]{42}[AEIMQUYcgkosw048]=$`),
+	}
+}
+
+// Redactor - replaces the secrets in the JSON documents with the Placeholder
+type Redactor struct {
+	paths  [][]string
+	keys   []*regexp.Regexp
+	values []*regexp.Regexp
+}
+
+// New - creates a new Redactor with the default rules
+//       - options - configuration options
+func New(options ...Option) *Redactor {
+	r := &Redactor{
+		keys:   DefaultKeys(),
+		values: DefaultValues(),
+	}
+	for _, o := range options {
+		o.apply(r)
+	}
+	return r
+}
+
+func (r *Redactor) addPaths(paths ...string) {
+	for _, path := range paths {
+		r.paths = append(r.paths, strings.Split(path, "."))
+	}
+}
+
+func (r *Redactor) addKeys(keys ...*regexp.Regexp) {
+	r.keys = append(r.keys, keys...)
+}
+
+func (r *Redactor) addValues(values ...*regexp.Regexp) {
+	r.values = append(r.values, values...)
+}
+
+func (r *Redactor) clear() {
+	r.paths, r.keys, r.values = nil, nil, nil
+}
+
+var defaultRedactor atomic.Value
+
+func init() {
+	defaultRedactor.Store(New())
+}
+
+// Default - returns the Redactor used by the trace chain elements and the span logs (see Logger,
+//           spanhelper.SpanHelper.LogObject)
+func Default() *Redactor {
+	return defaultRedactor.Load().(*Redactor)
+}
+
+// SetDefault - sets the Redactor used by the trace chain elements
+func SetDefault(r *Redactor) {
+	defaultRedactor.Store(r)
+}
+
+// JSON - returns data with the secrets replaced by the Placeholder. data is returned as is if it has nothing to redact
+//        or is not a valid JSON.
+func (r *Redactor) JSON(data []byte) []byte {
+	rv, _ := r.redactJSON(data)
+	return rv
+}
+
+// Value - returns value to format in the logs with the secrets replaced by the Placeholder: structs, maps and slices
+//         having something to redact are replaced with their redacted JSON, matching strings are replaced with the
+//         Placeholder, the other values (including errors) are returned as is
+func (r *Redactor) Value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, error, []byte:
+		return value
+	case string:
+		if _, redacted := r.redact(nil, v); redacted {
+			return Placeholder
+		}
+		return value
+	}
+	t := reflect.TypeOf(value)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+	default:
+		return value
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	if rv, redacted := r.redactJSON(data); redacted {
+		return string(rv)
+	}
+	return value
+}
+
+func (r *Redactor) redactJSON(data []byte) ([]byte, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return data, false
+	}
+	document, redacted := r.redact(nil, document)
+	if !redacted {
+		return data, false
+	}
+	rv, err := json.Marshal(document)
+	if err != nil {
+		return data, false
+	}
+	return rv, true
+}
+
+func (r *Redactor) redact(path []string, value interface{}) (interface{}, bool) {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			fieldPath := append(path[:len(path):len(path)], key)
+			if r.matchField(fieldPath, field) {
+				v[key] = Placeholder
+				redacted = true
+				continue
+			}
+			var ok bool
+			if v[key], ok = r.redact(fieldPath, field); ok {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			var ok bool
+			if v[i], ok = r.redact(path, item); ok {
+				redacted = true
+			}
+		}
+	case string:
+		for _, re := range r.values {
+			if re.MatchString(v) {
+				return Placeholder, true
+			}
+		}
+	}
+	return value, redacted
+}
+
+func (r *Redactor) matchField(path []string, value interface{}) bool {
+	if value == nil || value == "" {
+		return false
+	}
+	for _, re := range r.keys {
+		if re.MatchString(path[len(path)-1]) {
+			return true
+		}
+	}
+	for _, p := range r.paths {
+		if matchPath(p, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPath(pattern, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
+)
+
+const wgKey = "yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3fBmk="
+
+func request() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "conn-1",
+			NetworkService: "ns-1",
+			Mechanism: &networkservice.Mechanism{
+				Type: "WIREGUARD",
+				Parameters: map[string]string{
+					"src_ip":         "10.0.0.1",
+					"peer_key":       wgKey,
+					"wg_private_key": "secret-value",
+				},
+			},
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc", Token: "nsc-token"},
+					{Name: "nsmgr", Token: "nsmgr-token"},
+				},
+			},
+		},
+	}
+}
+
+func redacted(t *testing.T, r *redact.Redactor, value interface{}) string {
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	rv := r.JSON(data)
+	require.True(t, json.Valid(rv))
+	return string(rv)
+}
+
+func TestRedactor_Defaults(t *testing.T) {
+	rv := redacted(t, redact.New(), request())
+
+	require.NotContains(t, rv, "nsc-token")
+	require.NotContains(t, rv, "nsmgr-token")
+	require.NotContains(t, rv, wgKey)
+	require.NotContains(t, rv, "secret-value")
+	require.Contains(t, rv, redact.Placeholder)
+	require.Contains(t, rv, "10.0.0.1")
+	require.Contains(t, rv, `"name":"nsmgr"`)
+}
+
+func TestRedactor_Paths(t *testing.T) {
+	r := redact.New(
+		redact.WithoutDefaultRules(),
+		redact.WithPaths("connection.path.path_segments.token", "*.mechanism.parameters.src_ip"),
+	)
+	rv := redacted(t, r, request())
+
+	require.NotContains(t, rv, "nsc-token")
+	require.NotContains(t, rv, "10.0.0.1")
+	require.Contains(t, rv, wgKey)
+	require.Contains(t, rv, "secret-value")
+}
+
+func TestRedactor_KeysAndValues(t *testing.T) {
+	r := redact.New(
+		redact.WithKeys(regexp.MustCompile("^url$")),
+		redact.WithValues(regexp.MustCompile("^ns-")),
+	)
+	rv := redacted(t, r, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		Url:                 "tcp://127.0.0.1:5000",
+	})
+
+	require.NotContains(t, rv, "tcp://127.0.0.1:5000")
+	require.NotContains(t, rv, "ns-1")
+	require.Contains(t, rv, "nse-1")
+}
+
+func TestRedactor_NothingToRedact(t *testing.T) {
+	data := []byte(`{"b":1.50,"a":{"token":""}}`)
+	require.Equal(t, data, redact.New().JSON(data))
+
+	invalid := []byte(`{"token":`)
+	require.Equal(t, invalid, redact.New().JSON(invalid))
+}
+
+func TestRedactor_Value(t *testing.T) {
+	r := redact.New()
+
+	rv := fmt.Sprintf("%+v", r.Value(request()))
+	require.NotContains(t, rv, "nsc-token")
+	require.NotContains(t, rv, wgKey)
+	require.Contains(t, rv, "10.0.0.1")
+
+	require.Equal(t, redact.Placeholder, r.Value(wgKey))
+
+	conn := &networkservice.Connection{Id: "conn-1"}
+	require.Equal(t, conn, r.Value(conn))
+	err := errors.New("error")
+	require.Equal(t, err, r.Value(err))
+	require.Equal(t, 1, r.Value(1))
+}
+
+func TestLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := logrus.New()
+	logger.Out = buf
+
+	redact.Logger(log.NewLogrus(logger)).WithField("request", request()).Infof("request: %+v", request())
+	require.NotContains(t, buf.String(), "nsc-token")
+	require.NotContains(t, buf.String(), "secret-value")
+	require.Contains(t, buf.String(), "10.0.0.1")
+}
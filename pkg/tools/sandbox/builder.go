@@ -0,0 +1,225 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sandbox provides an in-process NSM for the integration tests: domains with the registries, nsmgr-proxies
+// and the nodes with nsmgrs, endpoints and clients, all served on the unix sockets in a temporary directory
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"testing"
+	"time"
+
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgrproxy"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/registry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+const (
+	defaultDomainName = "sandbox"
+	defaultNodesCount = 1
+)
+
+// GenerateTestToken - generates the test token valid for an hour
+func GenerateTestToken(_ credentials.AuthInfo) (tokenValue string, expireTime time.Time, err error) {
+	return "TestToken", time.Now().Add(time.Hour), nil
+}
+
+// Builder - builds the sandbox Domain
+type Builder struct {
+	t              *testing.T
+	ctx            context.Context
+	name           string
+	nodesCount     int
+	resolver       *Resolver
+	dialOptions    []grpc.DialOption
+	tokenGenerator token.GeneratorFunc
+}
+
+// NewBuilder - returns a new Builder of the domain with a single node, the domain is cleaned up when ctx is done
+func NewBuilder(ctx context.Context, t *testing.T) *Builder {
+	return &Builder{
+		t:              t,
+		ctx:            ctx,
+		name:           defaultDomainName,
+		nodesCount:     defaultNodesCount,
+		resolver:       NewResolver(),
+		dialOptions:    []grpc.DialOption{grpc.WithInsecure()},
+		tokenGenerator: GenerateTestToken,
+	}
+}
+
+// SetDomainName - sets the domain name, the domains sharing the Resolver should have the different names
+func (b *Builder) SetDomainName(name string) *Builder {
+	b.name = name
+	return b
+}
+
+// SetNodesCount - sets the number of the nodes
+func (b *Builder) SetNodesCount(nodesCount int) *Builder {
+	b.nodesCount = nodesCount
+	return b
+}
+
+// SetResolver - sets the Resolver shared by the domains, so they can reach each other
+func (b *Builder) SetResolver(resolver *Resolver) *Builder {
+	b.resolver = resolver
+	return b
+}
+
+// SetDialOptions - sets the gRPC dial options for the connections between the components, grpc.WithInsecure() is
+//                  used by default
+func (b *Builder) SetDialOptions(dialOptions ...grpc.DialOption) *Builder {
+	b.dialOptions = dialOptions
+	return b
+}
+
+// SetTokenGenerator - sets the token generator for the components, GenerateTestToken is used by default
+func (b *Builder) SetTokenGenerator(tokenGenerator token.GeneratorFunc) *Builder {
+	b.tokenGenerator = tokenGenerator
+	return b
+}
+
+// Build - starts the domain:
+//
+//	registry (also served on the TCP port registered in the Resolver) -> proxy registry -> the other domains
+//	nsmgr-N (one per node) -> registry
+//	nsmgr-proxy -> nsmgr-0 and the nsmgrs of the other domains
+//
+// Domain should be cleaned up by the caller with Domain.Cleanup, e.g. `defer domain.Cleanup()` right after Build, so
+// the components are stopped gracefully. It is also cleaned up when the Builder context is done.
+func (b *Builder) Build() *Domain {
+	dir, err := ioutil.TempDir("", "sandbox")
+	require.NoError(b.t, err)
+
+	ctx, cancel := context.WithCancel(b.ctx)
+	d := &Domain{
+		Name:           b.name,
+		t:              b.t,
+		ctx:            ctx,
+		cancel:         cancel,
+		dir:            dir,
+		dialOptions:    b.dialOptions,
+		tokenGenerator: b.tokenGenerator,
+	}
+	go func() {
+		<-ctx.Done()
+		d.Cleanup()
+	}()
+
+	// Cleanup on the failed build
+	built := false
+	defer func() {
+		if !built {
+			d.Cleanup()
+		}
+	}()
+
+	nsmgrProxyURL := d.socketURL("nsmgr-proxy")
+	nsmgrURLs := make([]*url.URL, b.nodesCount)
+	for i := range nsmgrURLs {
+		nsmgrURLs[i] = d.socketURL(fmt.Sprintf("nsmgr-%d", i))
+	}
+
+	d.ProxyRegistry = b.newProxyRegistry(d, nsmgrProxyURL)
+	d.Registry = b.newRegistry(d)
+	for i := range nsmgrURLs {
+		d.Nodes = append(d.Nodes, b.newNode(d, i, nsmgrURLs[i]))
+	}
+	d.NSMgrProxy = b.newNSMgrProxy(d, nsmgrProxyURL, nsmgrURLs[0])
+
+	built = true
+	return d
+}
+
+func (b *Builder) newProxyRegistry(d *Domain, nsmgrProxyURL *url.URL) *RegistryEntry {
+	entry := new(RegistryEntry)
+	entry.component = d.newComponent(func(ctx context.Context) (func(*grpc.Server), func(context.Context)) {
+		entry.Registry = registry.NewProxyServer(d.Name, nsmgrProxyURL, nsmgrProxyURL,
+			registry.WithResolver(b.resolver),
+			registry.WithDialOptions(d.dialOptions...))
+		return entry.Register, nil
+	}, d.socketURL("proxy-registry"))
+	return entry
+}
+
+func (b *Builder) newRegistry(d *Domain) *RegistryEntry {
+	publicURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+
+	entry := new(RegistryEntry)
+	entry.component = d.newComponent(func(ctx context.Context) (func(*grpc.Server), func(context.Context)) {
+		r, err := registry.NewLocalServer(ctx,
+			registry.WithProxyRegistryURL(d.ProxyRegistry.URL),
+			registry.WithDialOptions(d.dialOptions...))
+		require.NoError(d.t, err)
+		entry.Registry = r
+		return entry.Register, nil
+	}, d.socketURL("registry"), publicURL)
+
+	b.resolver.Register(d.Name, publicURL)
+	return entry
+}
+
+func (b *Builder) newNode(d *Domain, index int, nsmgrURL *url.URL) *Node {
+	node := &Node{domain: d}
+	registryCC := d.dial(d.Registry.URL)
+
+	node.NSMgr = new(NSMgrEntry)
+	node.NSMgr.component = d.newComponent(func(ctx context.Context) (func(*grpc.Server), func(context.Context)) {
+		node.NSMgr.Nsmgr = nsmgr.NewServer(ctx,
+			&registryapi.NetworkServiceEndpoint{
+				Name: fmt.Sprintf("%s-nsmgr-%d", d.Name, index),
+				Url:  nsmgrURL.String(),
+			},
+			authorize.NewServer(),
+			d.tokenGenerator,
+			registryCC,
+			d.dialOptions...)
+		for _, entry := range node.endpoints {
+			if entry.alive() {
+				node.register(ctx, entry.nse)
+			}
+		}
+		return node.NSMgr.Register, func(ctx context.Context) {
+			_ = node.NSMgr.Drain(ctx)
+		}
+	}, nsmgrURL)
+	return node
+}
+
+func (b *Builder) newNSMgrProxy(d *Domain, nsmgrProxyURL, nsmgrURL *url.URL) *EndpointEntry {
+	proxyRegistryCC := d.dial(d.ProxyRegistry.URL)
+
+	entry := new(EndpointEntry)
+	entry.component = d.newComponent(func(ctx context.Context) (func(*grpc.Server), func(context.Context)) {
+		entry.Endpoint = nsmgrproxy.NewServer(ctx, d.Name+"-nsmgr-proxy", authorize.NewServer(), d.tokenGenerator,
+			nsmgrURL, proxyRegistryCC, d.dialOptions...)
+		return entry.Register, func(ctx context.Context) {
+			_ = entry.Drain(ctx)
+		}
+	}, nsmgrProxyURL)
+	return entry
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+// stopTimeout - time given to the component to stop gracefully on Kill
+const stopTimeout = time.Second
+
+// startFunc - creates the component instance living until ctx is done, returns the function registering it with the
+//             gRPC server and the function stopping it gracefully (could be nil)
+type startFunc func(ctx context.Context) (register func(s *grpc.Server), stop func(ctx context.Context))
+
+// component - gRPC server of the sandbox component, it can be killed and restarted on the same URLs
+type component struct {
+	// URL - URL of the component, it is a unix socket in the sandbox directory
+	URL *url.URL
+
+	ctx      context.Context
+	listenOn []*url.URL
+	start    startFunc
+	cancel   context.CancelFunc
+	server   *grpc.Server
+	stop     func(ctx context.Context)
+	mutex    sync.Mutex
+}
+
+func newComponent(ctx context.Context, start startFunc, listenOn ...*url.URL) *component {
+	return &component{
+		URL:      listenOn[0],
+		ctx:      ctx,
+		listenOn: listenOn,
+		start:    start,
+	}
+}
+
+// Kill - stops the component as on the shutdown: its context is canceled, it is stopped gracefully within a second
+//        and its server is stopped
+func (c *component) Kill() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.kill()
+}
+
+func (c *component) kill() {
+	if c.cancel == nil {
+		return
+	}
+	c.cancel()
+	if c.stop != nil {
+		stopCtx, cancelStop := context.WithTimeout(context.Background(), stopTimeout)
+		c.stop(stopCtx)
+		cancelStop()
+	}
+	c.server.Stop()
+	c.cancel, c.server, c.stop = nil, nil, nil
+}
+
+// Restart - kills the component if it is alive and starts a new instance on the same URLs
+func (c *component) Restart() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.kill()
+	if c.ctx.Err() != nil {
+		return c.ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(c.ctx)
+	register, stop := c.start(ctx)
+	server := grpc.NewServer()
+	register(server)
+
+	for _, u := range c.listenOn {
+		l, err := listen(u)
+		if err != nil {
+			cancel()
+			server.Stop()
+			return err
+		}
+		go func() {
+			_ = server.Serve(l)
+		}()
+	}
+	go func() {
+		<-ctx.Done()
+		server.Stop()
+	}()
+
+	c.cancel, c.server, c.stop = cancel, server, stop
+	return nil
+}
+
+func (c *component) alive() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.cancel != nil
+}
+
+// listen - listens on u, u is updated with the real address if the port is not specified
+func listen(u *url.URL) (net.Listener, error) {
+	l, err := net.Listen(grpcutils.TargetToNetAddr(grpcutils.URLToTarget(u)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on %s", u)
+	}
+	*u = *grpcutils.AddressToURL(l.Addr())
+	return l, nil
+}
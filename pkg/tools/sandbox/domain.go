@@ -0,0 +1,186 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/endpoint"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/registry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+// Domain - NSM domain running in the sandbox: registry, proxy registry, nsmgr-proxy and the nodes
+type Domain struct {
+	// Name - name of the domain, it is resolved by the Resolver to the registry
+	Name          string
+	Registry      *RegistryEntry
+	ProxyRegistry *RegistryEntry
+	NSMgrProxy    *EndpointEntry
+	Nodes         []*Node
+
+	t              *testing.T
+	ctx            context.Context
+	cancel         context.CancelFunc
+	dir            string
+	dialOptions    []grpc.DialOption
+	tokenGenerator token.GeneratorFunc
+	components     []*component
+	conns          []*grpc.ClientConn
+	endpointsCount int
+	cleanupOnce    sync.Once
+	mutex          sync.Mutex
+}
+
+// Node - node of the domain with its own nsmgr
+type Node struct {
+	NSMgr *NSMgrEntry
+
+	domain    *Domain
+	endpoints []*EndpointEntry
+}
+
+// RegistryEntry - registry served in the sandbox
+type RegistryEntry struct {
+	registry.Registry
+	*component
+}
+
+// NSMgrEntry - nsmgr served in the sandbox
+type NSMgrEntry struct {
+	nsmgr.Nsmgr
+	*component
+}
+
+// EndpointEntry - endpoint served in the sandbox
+type EndpointEntry struct {
+	endpoint.Endpoint
+	*component
+
+	nse *registryapi.NetworkServiceEndpoint
+}
+
+// NewEndpoint - serves the endpoint with the additionalFunctionality and registers it with its Network Services in
+//               the node nsmgr. Endpoint is unregistered on Kill and registered again on Restart, all the alive
+//               endpoints of the node are registered again when the node nsmgr is restarted.
+func (n *Node) NewEndpoint(nse *registryapi.NetworkServiceEndpoint, additionalFunctionality ...networkservice.NetworkServiceServer) *EndpointEntry {
+	d := n.domain
+
+	nse = proto.Clone(nse).(*registryapi.NetworkServiceEndpoint)
+	d.mutex.Lock()
+	d.endpointsCount++
+	if nse.Name == "" {
+		nse.Name = fmt.Sprintf("nse-%d", d.endpointsCount)
+	}
+	d.mutex.Unlock()
+
+	u := d.socketURL(nse.Name)
+	nse.Url = u.String()
+
+	entry := &EndpointEntry{nse: nse}
+	entry.component = d.newComponent(func(ctx context.Context) (func(*grpc.Server), func(context.Context)) {
+		entry.Endpoint = endpoint.NewServer(ctx, nse.Name, authorize.NewServer(), d.tokenGenerator, additionalFunctionality...)
+		registered := n.register(ctx, nse)
+		return entry.Register, func(ctx context.Context) {
+			entry.Stop()
+			_, _ = n.NSMgr.NetworkServiceEndpointRegistryServer().Unregister(ctx, registered)
+			_ = entry.Drain(ctx)
+		}
+	}, u)
+
+	n.endpoints = append(n.endpoints, entry)
+	return entry
+}
+
+func (n *Node) register(ctx context.Context, nse *registryapi.NetworkServiceEndpoint) *registryapi.NetworkServiceEndpoint {
+	for _, name := range nse.NetworkServiceNames {
+		_, err := n.NSMgr.NetworkServiceRegistryServer().Register(ctx, &registryapi.NetworkService{Name: name})
+		require.NoError(n.domain.t, err)
+	}
+	registered, err := n.NSMgr.NetworkServiceEndpointRegistryServer().Register(ctx, proto.Clone(nse).(*registryapi.NetworkServiceEndpoint))
+	require.NoError(n.domain.t, err)
+	return registered
+}
+
+// NewClient - returns the client chain with the additionalFunctionality connected to the node nsmgr
+func (n *Node) NewClient(name string, additionalFunctionality ...networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+	d := n.domain
+	return client.NewClient(d.ctx, name, nil, d.tokenGenerator, d.dial(n.NSMgr.URL), additionalFunctionality...)
+}
+
+// Cleanup - kills all the components, closes the connections and removes the sandbox directory. It is also called
+//           when the Builder context is done, but then the components are already canceled and cannot stop gracefully.
+func (d *Domain) Cleanup() {
+	d.cleanupOnce.Do(func() {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+
+		// Kill in the reverse order, so the endpoints are unregistered by the alive nsmgrs. Domain context is canceled
+		// only after that, otherwise the components are stopped by their canceled contexts before being killed
+		for i := len(d.components) - 1; i >= 0; i-- {
+			d.components[i].Kill()
+		}
+		d.cancel()
+
+		for _, cc := range d.conns {
+			_ = cc.Close()
+		}
+		_ = os.RemoveAll(d.dir)
+	})
+}
+
+func (d *Domain) newComponent(start startFunc, listenOn ...*url.URL) *component {
+	c := newComponent(d.ctx, start, listenOn...)
+
+	d.mutex.Lock()
+	d.components = append(d.components, c)
+	d.mutex.Unlock()
+
+	require.NoError(d.t, c.Restart())
+	return c
+}
+
+func (d *Domain) socketURL(name string) *url.URL {
+	return &url.URL{Scheme: "unix", Path: filepath.Join(d.dir, name+".sock")}
+}
+
+func (d *Domain) dial(u *url.URL) *grpc.ClientConn {
+	cc, err := grpc.DialContext(d.ctx, grpcutils.URLToTarget(u), d.dialOptions...)
+	require.NoError(d.t, err)
+
+	d.mutex.Lock()
+	d.conns = append(d.conns, cc)
+	d.mutex.Unlock()
+
+	return cc
+}
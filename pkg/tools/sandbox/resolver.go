@@ -0,0 +1,87 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/dnsresolve"
+)
+
+// Resolver - dnsresolve.Resolver resolving the sandbox domain names to the TCP URLs of their registries. Domains
+//            sharing the Resolver can reach each other with the interdomain calls.
+type Resolver struct {
+	domains map[string]*url.URL
+	mutex   sync.RWMutex
+}
+
+// NewResolver - returns a new empty Resolver
+func NewResolver() *Resolver {
+	return &Resolver{
+		domains: map[string]*url.URL{},
+	}
+}
+
+// Register - makes domain resolvable to the registry URL u
+func (r *Resolver) Register(domain string, u *url.URL) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.domains[domain] = u
+}
+
+func (r *Resolver) lookup(domain string) (*url.URL, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	u, ok := r.domains[domain]
+	if !ok {
+		return nil, errors.Errorf("%s not found", domain)
+	}
+	return u, nil
+}
+
+// LookupSRV - returns the SRV record with the registry port for the domain
+func (r *Resolver) LookupSRV(_ context.Context, service, proto, domain string) (cname string, srvs []*net.SRV, err error) {
+	u, err := r.lookup(domain)
+	if err != nil {
+		return "", nil, err
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "invalid port of %s", u)
+	}
+	return fmt.Sprintf("_%v._%v.%v", service, proto, domain), []*net.SRV{{Target: domain, Port: uint16(port)}}, nil
+}
+
+// LookupIPAddr - returns the registry IP address for the domain
+func (r *Resolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	u, err := r.lookup(host)
+	if err != nil {
+		return nil, err
+	}
+	return []net.IPAddr{{IP: net.ParseIP(u.Hostname())}}, nil
+}
+
+var _ dnsresolve.Resolver = (*Resolver)(nil)
@@ -0,0 +1,129 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+)
+
+func request(ctx context.Context, nsc networkservice.NetworkServiceClient, id, networkService string) (*networkservice.Connection, error) {
+	requestCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return nsc.Request(requestCtx, &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Cls: cls.LOCAL, Type: kernel.MECHANISM},
+		},
+		Connection: &networkservice.Connection{
+			Id:             id,
+			NetworkService: networkService,
+			Context:        &networkservice.ConnectionContext{},
+		},
+	})
+}
+
+func TestSandbox_Request(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	domain := sandbox.NewBuilder(ctx, t).SetNodesCount(2).Build()
+	defer domain.Cleanup()
+
+	domain.Nodes[1].NewEndpoint(&registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns"},
+	})
+	nsc := domain.Nodes[0].NewClient("nsc")
+
+	conn, err := request(ctx, nsc, "1", "ns")
+	require.NoError(t, err)
+	require.Equal(t, "nse-1", conn.NetworkServiceEndpointName)
+	// nsc, nsmgr-0, nsmgr-1, nse-1
+	require.Len(t, conn.Path.PathSegments, 4)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
+func TestSandbox_Interdomain(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resolver := sandbox.NewResolver()
+	domain1 := sandbox.NewBuilder(ctx, t).SetDomainName("domain1").SetResolver(resolver).Build()
+	defer domain1.Cleanup()
+	domain2 := sandbox.NewBuilder(ctx, t).SetDomainName("domain2").SetResolver(resolver).Build()
+	defer domain2.Cleanup()
+
+	domain2.Nodes[0].NewEndpoint(&registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns"},
+	})
+	nsc := domain1.Nodes[0].NewClient("nsc")
+
+	conn, err := request(ctx, nsc, "1", "ns@domain2")
+	require.NoError(t, err)
+	require.Equal(t, "nse-1@"+domain2.Nodes[0].NSMgr.URL.String(), conn.NetworkServiceEndpointName)
+	// nsc, nsmgr-0, nsmgr-proxy, domain2 nsmgr-0, nse-1
+	require.Len(t, conn.Path.PathSegments, 5)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
+func TestSandbox_KillRestart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	domain := sandbox.NewBuilder(ctx, t).Build()
+	defer domain.Cleanup()
+
+	nse := domain.Nodes[0].NewEndpoint(&registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"ns"},
+	})
+	nsc := domain.Nodes[0].NewClient("nsc")
+
+	_, err := request(ctx, nsc, "1", "ns")
+	require.NoError(t, err)
+
+	nse.Kill()
+	_, err = request(ctx, nsc, "2", "ns")
+	require.Error(t, err)
+
+	// Failed connection to the endpoint is kept by nsmgr for the grace period, so it reconnects with the backoff
+	require.NoError(t, nse.Restart())
+	require.Eventually(t, func() bool {
+		_, err = request(ctx, nsc, "3", "ns")
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// Endpoints are registered again in the restarted nsmgr, client reconnects to it with the backoff
+	require.NoError(t, domain.Nodes[0].NSMgr.Restart())
+	require.Eventually(t, func() bool {
+		_, err = request(ctx, nsc, "4", "ns")
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond)
+}
@@ -48,7 +48,7 @@ func WithSignals(parent context.Context, sig ...os.Signal) context.Context {
 	go func() {
 		select {
 		case s := <-c:
-			log.Entry(ctx).Warnf("Caught signal %s, exiting...", s)
+			log.FromContext(ctx).Warnf("Caught signal %s, exiting...", s)
 			cancel()
 		case <-parent.Done():
 		}
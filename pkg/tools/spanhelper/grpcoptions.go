@@ -25,10 +25,15 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/jaeger"
+	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
 )
 
 // WithTracing - returns array of grpc.ServerOption that should be passed to grpc.Dial to enable opentracing
+// (or OpenTelemetry if it is initialized)
 func WithTracing() []grpc.ServerOption {
+	if opentelemetry.IsOpenTelemetryEnabled() {
+		return opentelemetry.ServerOptions()
+	}
 	if jaeger.IsOpentracingEnabled() {
 		interceptor := func(
 			ctx context.Context,
@@ -51,7 +56,11 @@ func WithTracing() []grpc.ServerOption {
 }
 
 // WithTracingDial returns array of grpc.DialOption that should be passed to grpc.Dial to enable opentracing
+// (or OpenTelemetry if it is initialized)
 func WithTracingDial() []grpc.DialOption {
+	if opentelemetry.IsOpenTelemetryEnabled() {
+		return opentelemetry.DialOptions()
+	}
 	if jaeger.IsOpentracingEnabled() {
 		interceptor := func(
 			ctx context.Context,
@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanhelper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newOpenTelemetrySpanHelper(ctx context.Context, span trace.Span, operation string) *spanHelper {
+	return &spanHelper{
+		ctx:       withTraceDepth(ctx, traceDepth(ctx)+1),
+		otelSpan:  span,
+		operation: operation,
+	}
+}
+
+func logOpenTelemetryEvent(span trace.Span, name, value string) {
+	span.AddEvent(name, trace.WithAttributes(attribute.String(name, value)))
+}
+
+func logOpenTelemetryError(span trace.Span, err error, msg string) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, msg)
+}
+
+// logFromOpenTelemetrySpan - return a logger that also logs messages to the span events
+func logFromOpenTelemetrySpan(span trace.Span) logrus.FieldLogger {
+	logger := LogFromSpan(nil).(*logrus.Logger)
+	logger.AddHook(&otelTraceHook{span: span})
+	return logger.WithField("span", span.SpanContext().TraceID().String()+":"+span.SpanContext().SpanID().String())
+}
+
+type otelTraceHook struct {
+	index int
+	span  trace.Span
+}
+
+func (h *otelTraceHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *otelTraceHook) Fire(entry *logrus.Entry) error {
+	msg, err := entry.String()
+	if err != nil {
+		return err
+	}
+	logOpenTelemetryEvent(h.span, fmt.Sprintf("log[%d]", h.index), msg)
+	h.index++
+	return nil
+}
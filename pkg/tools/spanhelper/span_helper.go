@@ -14,7 +14,8 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package spanhelper provides a set of utilities to assist in working with opentracing spans
+// Package spanhelper provides a set of utilities to assist in working with opentracing spans, or OpenTelemetry spans
+// if OpenTelemetry is initialized (see opentelemetry.Init)
 package spanhelper
 
 import (
@@ -29,8 +30,12 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/jaeger"
+	nsmlog "github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
 )
 
 type spanHelperKeyType string
@@ -41,7 +46,7 @@ const (
 	dotCount             int               = 3
 )
 
-var defaultFields logrus.Fields = logrus.Fields{
+var defaultFields = map[string]interface{}{
 	"cmd": os.Args[0],
 }
 
@@ -108,7 +113,9 @@ type SpanHelper interface {
 	Finish()
 	Context() context.Context
 	Logger() logrus.FieldLogger
+	// LogObject - logs value marshaled to JSON, secrets are replaced with redact.Placeholder (see redact.Default)
 	LogObject(attribute string, value interface{})
+	// LogValue - logs value formatted with fmt, secrets are replaced with redact.Placeholder (see redact.Redactor.Value)
 	LogValue(attribute string, value interface{})
 	LogError(err error)
 	LogErrorf(format string, err error)
@@ -118,6 +125,7 @@ type SpanHelper interface {
 type spanHelper struct {
 	operation string
 	span      opentracing.Span
+	otelSpan  trace.Span
 	ctx       context.Context
 	logger    logrus.FieldLogger
 }
@@ -131,12 +139,16 @@ func (s *spanHelper) LogError(err error) {
 }
 
 func (s *spanHelper) LogErrorf(format string, err error) {
+	if s.otelSpan != nil && err != nil {
+		logOpenTelemetryError(s.otelSpan, err, limitString(fmt.Sprintf(format, err)))
+		nsmlog.Default().WithFields(defaultFields).Errorf(">><<%s %s=%v span=%v", strings.Repeat("--", traceDepth(s.ctx)), "error", fmt.Sprintf(format, err), s.spanString())
+	}
 	if s.span != nil && err != nil {
 		d := limitString(string(debug.Stack()))
 		msg := limitString(fmt.Sprintf(format, err))
 		otgrpc.SetSpanTags(s.span, err, false)
 		s.span.LogFields(log.String("event", "error"), log.String("message", msg), log.String("stacktrace", d))
-		logrus.WithFields(defaultFields).Errorf(">><<%s %s=%v span=%v", strings.Repeat("--", traceDepth(s.ctx)), "error", fmt.Sprintf(format, err), s.span)
+		nsmlog.Default().WithFields(defaultFields).Errorf(">><<%s %s=%v span=%v", strings.Repeat("--", traceDepth(s.ctx)), "error", fmt.Sprintf(format, err), s.span)
 	}
 }
 
@@ -144,21 +156,28 @@ func (s *spanHelper) LogObject(attribute string, value interface{}) {
 	cc, err := json.Marshal(value)
 	msg := ""
 	if err == nil {
-		msg = string(cc)
+		msg = string(redact.Default().JSON(cc))
 	} else {
 		msg = fmt.Sprint(msg)
 	}
 	if s.span != nil {
 		s.span.LogFields(log.Object(attribute, limitString(msg)))
 	}
-	logrus.WithFields(defaultFields).Infof(">><<%s %s=%v span=%v", strings.Repeat("--", traceDepth(s.ctx)), attribute, msg, s.span)
+	if s.otelSpan != nil {
+		logOpenTelemetryEvent(s.otelSpan, attribute, limitString(msg))
+	}
+	nsmlog.Default().WithFields(defaultFields).Infof(">><<%s %s=%v span=%v", strings.Repeat("--", traceDepth(s.ctx)), attribute, msg, s.spanString())
 }
 
 func (s *spanHelper) LogValue(attribute string, value interface{}) {
+	value = redact.Default().Value(value)
 	if s.span != nil {
 		s.span.LogFields(log.Object(attribute, limitString(fmt.Sprint(value))))
 	}
-	logrus.WithFields(defaultFields).Infof(">><<%s %s=%v span=%v", strings.Repeat("--", traceDepth(s.ctx)), attribute, value, s.span)
+	if s.otelSpan != nil {
+		logOpenTelemetryEvent(s.otelSpan, attribute, limitString(fmt.Sprint(value)))
+	}
+	nsmlog.Default().WithFields(defaultFields).Infof(">><<%s %s=%v span=%v", strings.Repeat("--", traceDepth(s.ctx)), attribute, value, s.spanString())
 }
 
 func (s *spanHelper) Finish() {
@@ -166,9 +185,24 @@ func (s *spanHelper) Finish() {
 		s.span.Finish()
 		s.span = nil
 	}
+	if s.otelSpan != nil {
+		s.otelSpan.End()
+		s.otelSpan = nil
+	}
+}
+
+// spanString - returns the span to print in the logs
+func (s *spanHelper) spanString() interface{} {
+	if s.otelSpan != nil {
+		return s.otelSpan.SpanContext().TraceID().String() + ":" + s.otelSpan.SpanContext().SpanID().String()
+	}
+	return s.span
 }
 
 func (s *spanHelper) Logger() logrus.FieldLogger {
+	if s.logger == nil && s.otelSpan != nil {
+		s.logger = logFromOpenTelemetrySpan(s.otelSpan)
+	}
 	if s.logger == nil {
 		s.logger = LogFromSpan(s.span)
 		if s.operation != "" {
@@ -191,9 +225,12 @@ func (s *spanHelper) Context() context.Context {
 	return s.ctx
 }
 
-// FromContext - return span helper from context and if opentracing is enabled start new span
+// FromContext - return span helper from context and if OpenTelemetry or opentracing is enabled start new span
 func FromContext(ctx context.Context, operation string) (result SpanHelper) {
-	if jaeger.IsOpentracingEnabled() {
+	if opentelemetry.IsOpenTelemetryEnabled() {
+		newCtx, newSpan := opentelemetry.Tracer().Start(ctx, operation)
+		result = newOpenTelemetrySpanHelper(newCtx, newSpan, operation)
+	} else if jaeger.IsOpentracingEnabled() {
 		newSpan, newCtx := opentracing.StartSpanFromContext(ctx, operation)
 		result = NewSpanHelper(newCtx, newSpan, operation)
 	} else {
@@ -206,11 +243,18 @@ func FromContext(ctx context.Context, operation string) (result SpanHelper) {
 
 func printStart(result SpanHelper, operation string) {
 	prefix := strings.Repeat("--", traceDepth(result.Context()))
-	logrus.WithFields(defaultFields).Infof("==%s> %v() span:%v", prefix, operation, result.Span())
+	var span interface{} = result.Span()
+	if h, ok := result.(*spanHelper); ok {
+		span = h.spanString()
+	}
+	nsmlog.Default().WithFields(defaultFields).Infof("==%s> %v() span:%v", prefix, operation, span)
 }
 
 // GetSpanHelper - construct a span helper object from current context span
 func GetSpanHelper(ctx context.Context) SpanHelper {
+	if opentelemetry.IsOpenTelemetryEnabled() {
+		return newOpenTelemetrySpanHelper(ctx, trace.SpanFromContext(ctx), "")
+	}
 	if jaeger.IsOpentracingEnabled() {
 		span := opentracing.SpanFromContext(ctx)
 		return NewSpanHelper(ctx, span, "")
@@ -225,6 +269,12 @@ func GetSpanHelper(ctx context.Context) SpanHelper {
 // CopySpan - construct span helper object with ctx and copy span from spanContext
 // Will start new operation on span
 func CopySpan(ctx context.Context, spanContext SpanHelper, operation string) SpanHelper {
+	if h, ok := spanContext.(*spanHelper); ok && h.otelSpan != nil && opentelemetry.IsOpenTelemetryEnabled() {
+		newCtx, newSpan := opentelemetry.Tracer().Start(trace.ContextWithSpan(ctx, h.otelSpan), operation)
+		result := newOpenTelemetrySpanHelper(newCtx, newSpan, operation)
+		printStart(result, operation)
+		return result
+	}
 	return WithSpan(ctx, spanContext.Span(), operation)
 }
 
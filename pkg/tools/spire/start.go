@@ -32,7 +32,6 @@ import (
 
 	"github.com/edwarnicke/exechelper"
 	"github.com/matryer/try"
-	"github.com/sirupsen/logrus"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
@@ -77,7 +76,7 @@ func Start(options ...Option) <-chan error {
 		close(errCh)
 		return errCh
 	}
-	logrus.Infof("Env variable %s=%s are set", workloadapi.SocketEnv, "unix:"+spireSocketPath)
+	log.FromContext(opt.ctx).Infof("Env variable %s=%s are set", workloadapi.SocketEnv, "unix:"+spireSocketPath)
 	if err = os.Setenv(workloadapi.SocketEnv, "unix:"+spireSocketPath); err != nil {
 		errCh <- err
 		close(errCh)
@@ -209,7 +208,7 @@ func writeDefaultConfigFiles(ctx context.Context, spireRoot string) (string, err
 	for configName, contents := range configFiles {
 		filename := path.Join(spireRoot, configName)
 		if _, err := os.Stat(filename); os.IsNotExist(err) {
-			log.Entry(ctx).Infof("Configuration file: %q not found, using defaults", filename)
+			log.FromContext(ctx).Infof("Configuration file: %q not found, using defaults", filename)
 			if err := os.MkdirAll(path.Dir(filename), 0700); err != nil {
 				return "", err
 			}